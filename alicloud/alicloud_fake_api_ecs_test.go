@@ -0,0 +1,1773 @@
+package alicloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The zones, instance types, spot prices and disk categories supported by the fake API server
+var (
+	fakeApiZones          = []string{"cn-beijing-a", "cn-beijing-b"}
+	fakeApiInstanceTypes  = []string{"ecs.n4.large", "ecs.sn1ne.large"}
+	fakeApiSpotPrices     = []float64{0.25, 0.75, 0.5}
+	fakeApiDiskCategories = []string{string(DiskCloudEfficiency), string(DiskCloudSSD), string(DiskCloud)}
+)
+
+// The only system image of the fake API server
+const fakeApiImage = "ubuntu_14_0405_64_20G_alibase_20170824.vhd"
+
+// registerEcs registers the handlers of the ECS APIs used by the zones, images, security groups and instances.
+func (s *fakeApiServer) registerEcs() {
+	s.handlers["ECS.DescribeRegions"] = func(request *fakeApiRequest) (interface{}, error) {
+		region := fakeObject{"RegionId": string(fakeApiRegion), "LocalName": string(fakeApiRegion), "Status": "available"}
+		return fakeObject{"Regions": fakeObject{"Region": []fakeObject{region}}}, nil
+	}
+
+	s.handlers["ECS.DescribeZones"] = func(request *fakeApiRequest) (interface{}, error) {
+		var zones []fakeObject
+		for _, zoneId := range fakeApiZones {
+			zones = append(zones, fakeObject{
+				"ZoneId":    zoneId,
+				"LocalName": zoneId,
+				"AvailableResourceCreation": fakeObject{
+					"ResourceTypes": []string{string(ResourceTypeInstance), string(ResourceTypeDisk), string(ResourceTypeVSwitch), "IoOptimized"},
+				},
+				"AvailableDiskCategories": fakeObject{"DiskCategories": fakeApiDiskCategories},
+				"AvailableInstanceTypes":  fakeObject{"InstanceTypes": fakeApiInstanceTypes},
+			})
+		}
+		return fakeObject{"Zones": fakeObject{"Zone": zones}}, nil
+	}
+
+	s.handlers["ECS.DescribeAvailableResource"] = func(request *fakeApiRequest) (interface{}, error) {
+		destination := request.Params.Get("DestinationResource")
+		var zones []fakeObject
+		for _, zoneId := range fakeApiZones {
+			values := []string{zoneId}
+			if destination == string(InstanceTypeResource) {
+				values = fakeApiInstanceTypes
+			}
+			var supported []fakeObject
+			for _, value := range values {
+				supported = append(supported, fakeObject{"Value": value, "Status": string(Available)})
+			}
+			zones = append(zones, fakeObject{
+				"ZoneId":         zoneId,
+				"RegionId":       string(fakeApiRegion),
+				"Status":         string(Available),
+				"StatusCategory": "WithStock",
+				"AvailableResources": fakeObject{"AvailableResource": []fakeObject{{
+					"Type":               destination,
+					"SupportedResources": fakeObject{"SupportedResource": supported},
+				}}},
+			})
+		}
+		return fakeObject{"AvailableZones": fakeObject{"AvailableZone": zones}}, nil
+	}
+
+	s.handlers["ECS.DescribeInstanceTypes"] = func(request *fakeApiRequest) (interface{}, error) {
+		var types []fakeObject
+		for _, instanceType := range fakeApiInstanceTypes {
+			types = append(types, fakeObject{
+				"InstanceTypeId":     instanceType,
+				"InstanceTypeFamily": instanceType[:strings.LastIndex(instanceType, ".")],
+				"CpuCoreCount":       2,
+				"MemorySize":         4,
+				"EniQuantity":        2,
+				"CpuArchitecture":    "X86",
+			})
+		}
+		return fakeObject{"InstanceTypes": fakeObject{"InstanceType": types}}, nil
+	}
+
+	// The later instance types are cheaper, and the monthly price is 720 times the hourly one.
+	s.handlers["ECS.DescribePrice"] = func(request *fakeApiRequest) (interface{}, error) {
+		for i, instanceType := range fakeApiInstanceTypes {
+			if instanceType != request.Params.Get("InstanceType") {
+				continue
+			}
+			price := 0.5 - 0.125*float64(i)
+			if request.Params.Get("PriceUnit") == string(Month) {
+				price *= 720
+			}
+			return fakeObject{"PriceInfo": fakeObject{"Price": fakeObject{
+				"OriginalPrice": price, "DiscountPrice": 0, "TradePrice": price, "Currency": "CNY",
+			}}}, nil
+		}
+		return nil, &fakeApiError{http.StatusBadRequest, "InvalidInstanceType.ValueNotSupported", "The specified InstanceType does not exist."}
+	}
+
+	// The spot prices are the same in each zone, and they are returned two at a time to cover the paging.
+	s.handlers["ECS.DescribeSpotPriceHistory"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		if params.Get("NetworkType") == "" {
+			return nil, &fakeApiError{http.StatusBadRequest, "MissingNetworkType", "NetworkType is mandatory for this action."}
+		}
+		var prices []fakeObject
+		for _, zoneId := range fakeApiZones {
+			if v := params.Get("ZoneId"); v != "" && v != zoneId {
+				continue
+			}
+			for i, price := range fakeApiSpotPrices {
+				timestamp := fmt.Sprintf("2019-01-01T0%d:00:00Z", i)
+				if (params.Get("StartTime") != "" && timestamp < params.Get("StartTime")) || (params.Get("EndTime") != "" && timestamp > params.Get("EndTime")) {
+					continue
+				}
+				prices = append(prices, fakeObject{
+					"ZoneId":       zoneId,
+					"InstanceType": params.Get("InstanceType"),
+					"NetworkType":  params.Get("NetworkType"),
+					"IoOptimized":  params.Get("IoOptimized"),
+					"Timestamp":    timestamp,
+					"SpotPrice":    price,
+					"OriginPrice":  1.0,
+				})
+			}
+		}
+		offset, _ := strconv.Atoi(params.Get("Offset"))
+		page := []fakeObject{}
+		if offset < len(prices) {
+			end := offset + 2
+			if end > len(prices) {
+				end = len(prices)
+			}
+			page = prices[offset:end]
+		}
+		return fakeObject{"Currency": "CNY", "NextOffset": offset + len(page), "SpotPrices": fakeObject{"SpotPriceType": page}}, nil
+	}
+
+	s.handlers["ECS.DescribeImages"] = func(request *fakeApiRequest) (interface{}, error) {
+		images := []fakeObject{}
+		if pageNumber := request.Params.Get("PageNumber"); pageNumber == "" || pageNumber == "1" {
+			images = append(images, fakeObject{
+				"ImageId":         fakeApiImage,
+				"ImageName":       fakeApiImage,
+				"ImageOwnerAlias": string(ImageOwnerSystem),
+				"OSType":          "linux",
+				"Architecture":    "x86_64",
+				"Status":          "Available",
+				"Size":            40,
+				"CreationTime":    "2017-08-24T00:00:00Z",
+			})
+			if owner := request.Params.Get("ImageOwnerAlias"); owner == "" || owner == string(ImageOwnerSelf) {
+				for _, image := range s.images {
+					images = append(images, image)
+				}
+			}
+		}
+		if imageId := request.Params.Get("ImageId"); imageId != "" {
+			var matched []fakeObject
+			for _, image := range images {
+				if image["ImageId"] == imageId {
+					matched = append(matched, image)
+				}
+			}
+			images = matched
+		}
+		return fakeObject{"Images": fakeObject{"Image": images}, "TotalCount": len(images)}, nil
+	}
+
+	s.registerEcsTags()
+	s.registerEcsImages()
+	s.registerEcsSecurityGroups()
+	s.registerEcsInstances()
+	s.registerEcsDisks()
+	s.registerEcsSnapshots()
+	s.registerEcsLaunchTemplates()
+	s.registerEcsNetworkInterfaces()
+	s.registerEcsDedicatedHosts()
+}
+
+func (s *fakeApiServer) registerEcsTags() {
+	s.handlers["ECS.DescribeTags"] = func(request *fakeApiRequest) (interface{}, error) {
+		tags := s.fakeTags(request.Params.Get("ResourceId"))
+		return fakeObject{"Tags": fakeObject{"Tag": tags}, "TotalCount": len(tags), "PageNumber": 1, "PageSize": 50}, nil
+	}
+
+	s.handlers["ECS.AddTags"] = func(request *fakeApiRequest) (interface{}, error) {
+		keys := fakeListParam(request.Params, "Tag", "Key")
+		values := fakeListParam(request.Params, "Tag", "Value")
+		for i, key := range keys {
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+			s.setTag(request.Params.Get("ResourceId"), key, value)
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.RemoveTags"] = func(request *fakeApiRequest) (interface{}, error) {
+		for _, key := range fakeListParam(request.Params, "Tag", "Key") {
+			delete(s.tags[request.Params.Get("ResourceId")], key)
+		}
+		return fakeObject{}, nil
+	}
+}
+
+func (s *fakeApiServer) registerEcsImages() {
+	newImage := func(params url.Values, name, description string, mappings []fakeObject) fakeObject {
+		imageId := s.newId("m")
+		image := fakeObject{
+			"ImageId":            imageId,
+			"ImageName":          name,
+			"Description":        description,
+			"ImageVersion":       params.Get("ImageVersion"),
+			"ImageOwnerAlias":    string(ImageOwnerSelf),
+			"Platform":           "Ubuntu",
+			"OSType":             "linux",
+			"Architecture":       "x86_64",
+			"Status":             string(ImageAvailable),
+			"Size":               40,
+			"CreationTime":       "2019-01-01T00:00:00Z",
+			"DiskDeviceMappings": fakeObject{"DiskDeviceMapping": mappings},
+		}
+		if image["ImageName"] == "" {
+			image["ImageName"] = imageId
+		}
+		setIfPresent(image, params, "Platform", "Platform")
+		setIfPresent(image, params, "Architecture", "Architecture")
+		s.images[imageId] = image
+		return image
+	}
+
+	s.handlers["ECS.CreateImage"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		var mappings []fakeObject
+		if instanceId := params.Get("InstanceId"); instanceId != "" {
+			instance, ok := s.instances[instanceId]
+			if !ok {
+				return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+			}
+			disk := instance["SystemDisk"].(fakeObject)
+			mappings = append(mappings, fakeObject{"SnapshotId": s.newId("s"), "Size": strconv.Itoa(disk["Size"].(int)), "Device": "/dev/xvda", "Type": string(DiskTypeSystem)})
+		} else if snapshotId := params.Get("SnapshotId"); snapshotId != "" {
+			mappings = append(mappings, fakeObject{"SnapshotId": snapshotId, "Size": "40", "Device": "/dev/xvda", "Type": string(DiskTypeSystem)})
+		} else {
+			snapshotIds := fakeListParam(params, "DiskDeviceMapping", "SnapshotId")
+			for i, snapshotId := range snapshotIds {
+				prefix := fmt.Sprintf("DiskDeviceMapping.%d.", i+1)
+				mapping := fakeObject{"SnapshotId": snapshotId, "Size": "40", "Device": fmt.Sprintf("/dev/xvd%c", 'a'+i), "Type": string(DiskTypeData)}
+				if i == 0 {
+					mapping["Type"] = string(DiskTypeSystem)
+				}
+				setIfPresent(mapping, params, prefix+"Size", "Size")
+				setIfPresent(mapping, params, prefix+"Device", "Device")
+				setIfPresent(mapping, params, prefix+"DiskType", "Type")
+				mappings = append(mappings, mapping)
+			}
+		}
+		if len(mappings) < 1 {
+			return nil, &fakeApiError{http.StatusBadRequest, "MissingParameter", "One of InstanceId, SnapshotId and DiskDeviceMapping is required."}
+		}
+		image := newImage(params, params.Get("ImageName"), params.Get("Description"), mappings)
+		return fakeObject{"ImageId": image["ImageId"]}, nil
+	}
+
+	s.handlers["ECS.CopyImage"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		source, ok := s.images[params.Get("ImageId")]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidImageIdNotFound, "image", params.Get("ImageId"))
+		}
+		mappings := source["DiskDeviceMappings"].(fakeObject)["DiskDeviceMapping"].([]fakeObject)
+		image := newImage(params, params.Get("DestinationImageName"), params.Get("DestinationDescription"), mappings)
+		image["IsCopied"] = true
+		return fakeObject{"ImageId": image["ImageId"]}, nil
+	}
+
+	s.handlers["ECS.ModifyImageAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		imageId := request.Params.Get("ImageId")
+		image, ok := s.images[imageId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidImageIdNotFound, "image", imageId)
+		}
+		setIfPresent(image, request.Params, "ImageName", "ImageName")
+		setIfPresent(image, request.Params, "Description", "Description")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteImage"] = func(request *fakeApiRequest) (interface{}, error) {
+		imageId := request.Params.Get("ImageId")
+		if _, ok := s.images[imageId]; !ok {
+			return nil, fakeNotFoundError(InvalidImageIdNotFound, "image", imageId)
+		}
+		delete(s.images, imageId)
+		delete(s.imageShares, imageId)
+		delete(s.tags, imageId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.ModifyImageSharePermission"] = func(request *fakeApiRequest) (interface{}, error) {
+		imageId := request.Params.Get("ImageId")
+		if _, ok := s.images[imageId]; !ok {
+			return nil, fakeNotFoundError(InvalidImageIdNotFound, "image", imageId)
+		}
+		removed := make(map[string]bool)
+		for _, account := range fakeListParam(request.Params, "RemoveAccount", "") {
+			removed[account] = true
+		}
+		var accounts []string
+		for _, account := range append(s.imageShares[imageId], fakeListParam(request.Params, "AddAccount", "")...) {
+			if !removed[account] {
+				accounts = append(accounts, account)
+			}
+		}
+		s.imageShares[imageId] = accounts
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DescribeImageSharePermission"] = func(request *fakeApiRequest) (interface{}, error) {
+		imageId := request.Params.Get("ImageId")
+		if _, ok := s.images[imageId]; !ok {
+			return nil, fakeNotFoundError(InvalidImageIdNotFound, "image", imageId)
+		}
+		accounts := []fakeObject{}
+		for _, account := range s.imageShares[imageId] {
+			accounts = append(accounts, fakeObject{"AliyunId": account})
+		}
+		return fakeObject{
+			"ImageId":     imageId,
+			"RegionId":    string(fakeApiRegion),
+			"Accounts":    fakeObject{"Account": accounts},
+			"ShareGroups": fakeObject{"ShareGroup": []fakeObject{}},
+			"TotalCount":  len(accounts),
+			"PageNumber":  1,
+			"PageSize":    PageSizeLarge,
+		}, nil
+	}
+}
+
+func (s *fakeApiServer) registerEcsSecurityGroups() {
+	s.handlers["ECS.CreateSecurityGroup"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		if vpcId := params.Get("VpcId"); vpcId != "" {
+			if _, ok := s.vpcs[vpcId]; !ok {
+				return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", vpcId)
+			}
+		}
+		groupId := s.newId("sg")
+		s.securityGroups[groupId] = fakeObject{
+			"SecurityGroupId":   groupId,
+			"RegionId":          string(fakeApiRegion),
+			"SecurityGroupName": params.Get("SecurityGroupName"),
+			"Description":       params.Get("Description"),
+			"VpcId":             params.Get("VpcId"),
+			"InnerAccessPolicy": string(GroupInnerAccept),
+			"Permissions":       fakeObject{"Permission": []fakeObject{}},
+		}
+		return fakeObject{"SecurityGroupId": groupId}, nil
+	}
+
+	s.handlers["ECS.DescribeSecurityGroupAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		groupId := request.Params.Get("SecurityGroupId")
+		group, ok := s.securityGroups[groupId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+		}
+		response := fakeObject{}
+		for k, v := range group {
+			response[k] = v
+		}
+		permissions := []fakeObject{}
+		for _, permission := range group["Permissions"].(fakeObject)["Permission"].([]fakeObject) {
+			if direction := request.Params.Get("Direction"); direction == "" || direction == "all" || direction == permission["Direction"] {
+				permissions = append(permissions, permission)
+			}
+		}
+		response["Permissions"] = fakeObject{"Permission": permissions}
+		return response, nil
+	}
+
+	authorize := func(direction Direction) fakeApiHandler {
+		return func(request *fakeApiRequest) (interface{}, error) {
+			groupId := request.Params.Get("SecurityGroupId")
+			group, ok := s.securityGroups[groupId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+			}
+			permissions := group["Permissions"].(fakeObject)
+			for _, params := range fakeSecurityGroupPermissionParams(request.Params) {
+				permission := fakeObject{"Direction": string(direction), "Priority": "1", "NicType": string(GroupRuleIntranet)}
+				for _, param := range append(fakeSecurityGroupPermissionKeys, "Description") {
+					setIfPresent(permission, params, param, param)
+				}
+				permission["IpProtocol"] = strings.ToUpper(permission["IpProtocol"].(string))
+				permissions["Permission"] = append(permissions["Permission"].([]fakeObject), permission)
+			}
+			return fakeObject{}, nil
+		}
+	}
+	s.handlers["ECS.AuthorizeSecurityGroup"] = authorize(DirectionIngress)
+	s.handlers["ECS.AuthorizeSecurityGroupEgress"] = authorize(DirectionEgress)
+
+	revoke := func(direction Direction) fakeApiHandler {
+		return func(request *fakeApiRequest) (interface{}, error) {
+			groupId := request.Params.Get("SecurityGroupId")
+			group, ok := s.securityGroups[groupId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+			}
+			permissions := group["Permissions"].(fakeObject)
+			for _, params := range fakeSecurityGroupPermissionParams(request.Params) {
+				remained := []fakeObject{}
+				for _, permission := range permissions["Permission"].([]fakeObject) {
+					if !fakeSecurityGroupPermissionMatched(permission, direction, params) {
+						remained = append(remained, permission)
+					}
+				}
+				permissions["Permission"] = remained
+			}
+			return fakeObject{}, nil
+		}
+	}
+	s.handlers["ECS.RevokeSecurityGroup"] = revoke(DirectionIngress)
+	s.handlers["ECS.RevokeSecurityGroupEgress"] = revoke(DirectionEgress)
+
+	modifyRule := func(direction Direction) fakeApiHandler {
+		return func(request *fakeApiRequest) (interface{}, error) {
+			groupId := request.Params.Get("SecurityGroupId")
+			group, ok := s.securityGroups[groupId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+			}
+			modified := false
+			for _, permission := range group["Permissions"].(fakeObject)["Permission"].([]fakeObject) {
+				if fakeSecurityGroupPermissionMatched(permission, direction, request.Params) {
+					setIfPresent(permission, request.Params, "Description", "Description")
+					modified = true
+				}
+			}
+			if !modified {
+				return nil, &fakeApiError{http.StatusNotFound, "InvalidSecurityGroupRule.RuleNotExist", "The specified security group rule does not exist."}
+			}
+			return fakeObject{}, nil
+		}
+	}
+	s.handlers["ECS.ModifySecurityGroupRule"] = modifyRule(DirectionIngress)
+	s.handlers["ECS.ModifySecurityGroupEgressRule"] = modifyRule(DirectionEgress)
+
+	s.handlers["ECS.ModifySecurityGroupAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		groupId := request.Params.Get("SecurityGroupId")
+		group, ok := s.securityGroups[groupId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+		}
+		setIfPresent(group, request.Params, "SecurityGroupName", "SecurityGroupName")
+		setIfPresent(group, request.Params, "Description", "Description")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.ModifySecurityGroupPolicy"] = func(request *fakeApiRequest) (interface{}, error) {
+		groupId := request.Params.Get("SecurityGroupId")
+		group, ok := s.securityGroups[groupId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+		}
+		setIfPresent(group, request.Params, "InnerAccessPolicy", "InnerAccessPolicy")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteSecurityGroup"] = func(request *fakeApiRequest) (interface{}, error) {
+		groupId := request.Params.Get("SecurityGroupId")
+		if _, ok := s.securityGroups[groupId]; !ok {
+			return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+		}
+		for _, instance := range s.instances {
+			for _, id := range instance["SecurityGroupIds"].(fakeObject)["SecurityGroupId"].([]string) {
+				if id == groupId {
+					return nil, &fakeApiError{http.StatusForbidden, SgDependencyViolation, "There is still instance(s) in the specified security group."}
+				}
+			}
+		}
+		delete(s.securityGroups, groupId)
+		delete(s.tags, groupId)
+		return fakeObject{}, nil
+	}
+}
+
+// fakeSecurityGroupPermissionKeys are the parameters which identify a permission of the security group.
+var fakeSecurityGroupPermissionKeys = []string{
+	"IpProtocol", "PortRange", "Policy", "Priority", "NicType",
+	"SourceCidrIp", "Ipv6SourceCidrIp", "SourcePrefixListId", "SourceGroupId", "SourceGroupOwnerAccount",
+	"DestCidrIp", "Ipv6DestCidrIp", "DestPrefixListId", "DestGroupId", "DestGroupOwnerAccount",
+}
+
+// fakeSecurityGroupPermissionParams returns the parameters of each permission in the request, which are either given
+// as the batch "Permissions.N.*" or as the single permission.
+func fakeSecurityGroupPermissionParams(params url.Values) []url.Values {
+	if !fakeHasListItem(params, "Permissions", 1) {
+		return []url.Values{params}
+	}
+	var permissions []url.Values
+	for i := 1; fakeHasListItem(params, "Permissions", i); i++ {
+		prefix := fmt.Sprintf("Permissions.%d.", i)
+		permission := url.Values{}
+		for key, value := range params {
+			if strings.HasPrefix(key, prefix) {
+				permission[strings.TrimPrefix(key, prefix)] = value
+			}
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions
+}
+
+func fakeSecurityGroupPermissionMatched(permission fakeObject, direction Direction, params url.Values) bool {
+	if permission["Direction"] != string(direction) {
+		return false
+	}
+	for _, param := range fakeSecurityGroupPermissionKeys {
+		if _, ok := params[param]; !ok {
+			continue
+		}
+		if v, _ := permission[param].(string); !strings.EqualFold(v, params.Get(param)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *fakeApiServer) registerEcsInstances() {
+	s.handlers["ECS.RunInstances"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		if templateId := params.Get("LaunchTemplateId"); templateId != "" {
+			data, err := s.fakeLaunchTemplateData(templateId, params.Get("LaunchTemplateVersion"))
+			if err != nil {
+				return nil, err
+			}
+			// The parameters which are not specified are taken from the template
+			for _, key := range fakeLaunchTemplateParams {
+				if v, ok := data[key]; ok && params.Get(key) == "" && fmt.Sprint(v) != "" && fmt.Sprint(v) != "0" {
+					params.Set(key, fmt.Sprint(v))
+				}
+			}
+		}
+		instanceId := s.newId("i")
+		zoneId := params.Get("ZoneId")
+		vpcId := ""
+		privateIps := []string{}
+		if vswitchId := params.Get("VSwitchId"); vswitchId != "" {
+			vswitch, ok := s.vswitches[vswitchId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+			}
+			zoneId = vswitch["ZoneId"].(string)
+			vpcId = vswitch["VpcId"].(string)
+			privateIp := params.Get("PrivateIpAddress")
+			if privateIp == "" {
+				privateIp = fmt.Sprintf("172.16.0.%d", s.sequence%250+2)
+			}
+			privateIps = append(privateIps, privateIp)
+		}
+		if zoneId == "" {
+			zoneId = fakeApiZones[0]
+		}
+		groupId := params.Get("SecurityGroupId")
+		if _, ok := s.securityGroups[groupId]; !ok {
+			return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+		}
+		hostId := params.Get("DedicatedHostId")
+		if hostId != "" {
+			host, ok := s.dedicatedHosts[hostId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidDedicatedHostIdNotFound, "dedicated host", hostId)
+			}
+			if host["ZoneId"] != zoneId {
+				return nil, &fakeApiError{http.StatusForbidden, "InvalidParameter.ZoneId", "The instance must be in the zone of the dedicated host."}
+			}
+		}
+		if setId := params.Get("DeploymentSetId"); setId != "" {
+			if _, ok := s.deploymentSets[setId]; !ok {
+				return nil, fakeNotFoundError(InvalidDeploymentSetIdNotFound, "deployment set", setId)
+			}
+		}
+
+		bandwidthOut, _ := strconv.Atoi(params.Get("InternetMaxBandwidthOut"))
+		publicIps := []string{}
+		if bandwidthOut > 0 {
+			publicIps = append(publicIps, fmt.Sprintf("47.95.0.%d", s.sequence%250+2))
+		}
+		systemDiskSize, _ := strconv.Atoi(params.Get("SystemDisk.Size"))
+		if systemDiskSize == 0 {
+			systemDiskSize = 40
+		}
+		systemDiskCategory := params.Get("SystemDisk.Category")
+		if systemDiskCategory == "" {
+			systemDiskCategory = string(DiskCloudEfficiency)
+		}
+
+		instance := fakeObject{
+			"InstanceId":              instanceId,
+			"RegionId":                string(fakeApiRegion),
+			"ZoneId":                  zoneId,
+			"Status":                  string(Running),
+			"InstanceName":            params.Get("InstanceName"),
+			"Description":             params.Get("Description"),
+			"HostName":                params.Get("HostName"),
+			"ImageId":                 params.Get("ImageId"),
+			"InstanceType":            params.Get("InstanceType"),
+			"InstanceChargeType":      string(PostPaid),
+			"InternetChargeType":      string(PayByTraffic),
+			"InternetMaxBandwidthOut": bandwidthOut,
+			"InternetMaxBandwidthIn":  200,
+			"KeyPairName":             params.Get("KeyPairName"),
+			"SpotStrategy":            string(NoSpot),
+			"DeletionProtection":      params.Get("DeletionProtection") == "true",
+			"PublicIpAddress":         fakeObject{"IpAddress": publicIps},
+			"InnerIpAddress":          fakeObject{"IpAddress": []string{}},
+			"SecurityGroupIds":        fakeObject{"SecurityGroupId": []string{groupId}},
+			"VpcAttributes": fakeObject{
+				"VpcId":            vpcId,
+				"VSwitchId":        params.Get("VSwitchId"),
+				"PrivateIpAddress": fakeObject{"IpAddress": privateIps},
+			},
+			"SystemDisk": fakeObject{
+				"DiskId":     s.newId("d"),
+				"Type":       string(DiskTypeSystem),
+				"InstanceId": instanceId,
+				"Category":   systemDiskCategory,
+				"Size":       systemDiskSize,
+			},
+			"UserData":               params.Get("UserData"),
+			"DeploymentSetId":        params.Get("DeploymentSetId"),
+			"DedicatedHostAttribute": fakeObject{"DedicatedHostId": hostId},
+		}
+		if instance["InstanceName"] == "" {
+			instance["InstanceName"] = instanceId
+		}
+		if instance["HostName"] == "" {
+			instance["HostName"] = fmt.Sprintf("iZ%sZ", instanceId)
+		}
+		setIfPresent(instance, params, "InstanceChargeType", "InstanceChargeType")
+		setIfPresent(instance, params, "InternetChargeType", "InternetChargeType")
+		setIfPresent(instance, params, "SpotStrategy", "SpotStrategy")
+		s.instances[instanceId] = instance
+		if len(privateIps) > 0 {
+			eni := s.fakeNetworkInterface(params.Get("VSwitchId"), privateIps[0], []string{groupId})
+			eni["Type"] = string(NetworkInterfacePrimary)
+			eni["Status"] = string(InUse)
+			eni["InstanceId"] = instanceId
+		}
+		return fakeObject{"InstanceIdSets": fakeObject{"InstanceIdSet": []string{instanceId}}}, nil
+	}
+
+	s.handlers["ECS.DescribeInstances"] = func(request *fakeApiRequest) (interface{}, error) {
+		instances := []fakeObject{}
+		for _, id := range fakeJsonListParam(request.Params, "InstanceIds") {
+			if instance, ok := s.instances[id]; ok {
+				instances = append(instances, instance)
+			}
+		}
+		return fakeObject{"Instances": fakeObject{"Instance": instances}, "TotalCount": len(instances), "PageNumber": 1, "PageSize": 10}, nil
+	}
+
+	s.handlers["ECS.DescribeUserData"] = func(request *fakeApiRequest) (interface{}, error) {
+		instanceId := request.Params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		return fakeObject{"InstanceId": instanceId, "RegionId": string(fakeApiRegion), "UserData": instance["UserData"]}, nil
+	}
+
+	s.handlers["ECS.DescribeInstanceRamRole"] = func(request *fakeApiRequest) (interface{}, error) {
+		return fakeObject{"InstanceRamRoleSets": fakeObject{"InstanceRamRoleSet": []fakeObject{}}, "TotalCount": 0}, nil
+	}
+
+	s.handlers["ECS.ModifyInstanceAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		instanceId := request.Params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		setIfPresent(instance, request.Params, "InstanceName", "InstanceName")
+		setIfPresent(instance, request.Params, "Description", "Description")
+		setIfPresent(instance, request.Params, "HostName", "HostName")
+		if _, ok := request.Params["UserData"]; ok {
+			if instance["Status"] != string(Stopped) {
+				return nil, &fakeApiError{http.StatusForbidden, "IncorrectInstanceStatus", fmt.Sprintf("The user data of the instance %s can only be modified when it is stopped.", instanceId)}
+			}
+			instance["UserData"] = request.Params.Get("UserData")
+		}
+		if _, ok := request.Params["DeletionProtection"]; ok {
+			instance["DeletionProtection"] = request.Params.Get("DeletionProtection") == "true"
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.JoinSecurityGroup"] = func(request *fakeApiRequest) (interface{}, error) {
+		instanceId := request.Params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		groups := instance["SecurityGroupIds"].(fakeObject)
+		groups["SecurityGroupId"] = append(groups["SecurityGroupId"].([]string), request.Params.Get("SecurityGroupId"))
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.LeaveSecurityGroup"] = func(request *fakeApiRequest) (interface{}, error) {
+		instanceId := request.Params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		groups := instance["SecurityGroupIds"].(fakeObject)
+		var ids []string
+		for _, id := range groups["SecurityGroupId"].([]string) {
+			if id != request.Params.Get("SecurityGroupId") {
+				ids = append(ids, id)
+			}
+		}
+		groups["SecurityGroupId"] = ids
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.ModifyInstanceChargeType"] = func(request *fakeApiRequest) (interface{}, error) {
+		for _, instanceId := range fakeJsonListParam(request.Params, "InstanceIds") {
+			instance, ok := s.instances[instanceId]
+			if !ok {
+				return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+			}
+			instance["InstanceChargeType"] = request.Params.Get("InstanceChargeType")
+		}
+		return fakeObject{"OrderId": s.newId("order")}, nil
+	}
+
+	s.handlers["ECS.DescribeInstanceAutoRenewAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		instanceId := request.Params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		renew := fakeObject{"InstanceId": instanceId, "AutoRenewEnabled": false, "Duration": 1, "RenewalStatus": string(RenewNormal)}
+		if status, ok := instance["RenewalStatus"]; ok {
+			renew["RenewalStatus"] = status
+			renew["AutoRenewEnabled"] = status == string(RenewAutoRenewal)
+			renew["Duration"] = instance["RenewalDuration"]
+		}
+		return fakeObject{"InstanceRenewAttributes": fakeObject{"InstanceRenewAttribute": []fakeObject{renew}}, "TotalCount": 1}, nil
+	}
+
+	s.handlers["ECS.ModifyInstanceAutoRenewAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		instanceId := request.Params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		duration, _ := strconv.Atoi(request.Params.Get("Duration"))
+		if duration == 0 {
+			duration = 1
+		}
+		instance["RenewalStatus"] = request.Params.Get("RenewalStatus")
+		instance["RenewalDuration"] = duration
+		return fakeObject{}, nil
+	}
+
+	// The spec of the PostPaid instance and the system disk are modified only when the instance is stopped.
+	modifySpec := func(chargeType PayType, stopped bool) fakeApiHandler {
+		return func(request *fakeApiRequest) (interface{}, error) {
+			params := request.Params
+			instanceId := params.Get("InstanceId")
+			instance, ok := s.instances[instanceId]
+			if !ok {
+				return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+			}
+			if instance["InstanceChargeType"] != string(chargeType) {
+				return nil, &fakeApiError{http.StatusForbidden, "InvalidInstanceChargeType.NotFound", fmt.Sprintf("The instance %s is not %s.", instanceId, chargeType)}
+			}
+			if stopped && instance["Status"] != string(Stopped) {
+				return nil, &fakeApiError{http.StatusForbidden, "IncorrectInstanceStatus", fmt.Sprintf("The instance %s must be stopped before modifying its spec.", instanceId)}
+			}
+			supported := false
+			for _, instanceType := range fakeApiInstanceTypes {
+				supported = supported || instanceType == params.Get("InstanceType")
+			}
+			if !supported {
+				return nil, &fakeApiError{http.StatusForbidden, "InvalidInstanceType.ValueNotSupported", "The specified instance type is not supported."}
+			}
+			instance["InstanceType"] = params.Get("InstanceType")
+			return fakeObject{}, nil
+		}
+	}
+	s.handlers["ECS.ModifyInstanceSpec"] = modifySpec(PostPaid, true)
+	s.handlers["ECS.ModifyPrepayInstanceSpec"] = modifySpec(PrePaid, false)
+
+	s.handlers["ECS.ReplaceSystemDisk"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		instanceId := params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		if instance["Status"] != string(Stopped) {
+			return nil, &fakeApiError{http.StatusForbidden, "IncorrectInstanceStatus", fmt.Sprintf("The instance %s must be stopped before replacing its system disk.", instanceId)}
+		}
+		imageId := params.Get("ImageId")
+		if _, ok := s.images[imageId]; !ok && imageId != fakeApiImage {
+			return nil, fakeNotFoundError(InvalidImageIdNotFound, "image", imageId)
+		}
+		disk := instance["SystemDisk"].(fakeObject)
+		replaced := fakeObject{}
+		for k, v := range disk {
+			replaced[k] = v
+		}
+		replaced["DiskId"] = s.newId("d")
+		if size, _ := strconv.Atoi(params.Get("SystemDisk.Size")); size > 0 {
+			replaced["Size"] = size
+		}
+		instance["SystemDisk"] = replaced
+		instance["ImageId"] = imageId
+		return fakeObject{"DiskId": replaced["DiskId"]}, nil
+	}
+
+	setStatus := func(status Status, expected ...Status) fakeApiHandler {
+		return func(request *fakeApiRequest) (interface{}, error) {
+			instanceId := request.Params.Get("InstanceId")
+			instance, ok := s.instances[instanceId]
+			if !ok {
+				return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+			}
+			for _, e := range expected {
+				if instance["Status"] == string(e) {
+					instance["Status"] = string(status)
+					return fakeObject{}, nil
+				}
+			}
+			return nil, &fakeApiError{http.StatusForbidden, "IncorrectInstanceStatus", fmt.Sprintf("The current status of the instance %s does not support this action.", instanceId)}
+		}
+	}
+	s.handlers["ECS.StartInstance"] = setStatus(Running, Stopped)
+	stopInstance := setStatus(Stopped, Running, Stopped)
+	s.handlers["ECS.StopInstance"] = func(request *fakeApiRequest) (interface{}, error) {
+		response, err := stopInstance(request)
+		if err != nil {
+			return nil, err
+		}
+		instance := s.instances[request.Params.Get("InstanceId")]
+		mode := request.Params.Get("StoppedMode")
+		if mode == "" {
+			mode = string(KeepCharging)
+		}
+		if mode == string(StopCharging) && instance["VpcAttributes"].(fakeObject)["VpcId"] == "" {
+			return nil, &fakeApiError{http.StatusForbidden, "InvalidParameter.StoppedMode", "The StopCharging mode is only supported by the VPC instances."}
+		}
+		instance["StoppedMode"] = mode
+		return response, nil
+	}
+	s.handlers["ECS.RebootInstance"] = setStatus(Running, Running)
+
+	s.handlers["ECS.DeleteInstance"] = func(request *fakeApiRequest) (interface{}, error) {
+		instanceId := request.Params.Get("InstanceId")
+		instance, ok := s.instances[instanceId]
+		if !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		if instance["Status"] != string(Stopped) && request.Params.Get("Force") != "true" {
+			return nil, &fakeApiError{http.StatusForbidden, "IncorrectInstanceStatus", fmt.Sprintf("The instance %s must be stopped before deleting.", instanceId)}
+		}
+		if instance["DeletionProtection"] == true {
+			return nil, &fakeApiError{http.StatusForbidden, "InvalidOperation.DeletionProtection", "The instance is protected from deletion."}
+		}
+		delete(s.instances, instanceId)
+		delete(s.tags, instanceId)
+		// The primary network interface is released along with the instance, and the secondary ones are detached.
+		for eniId, eni := range s.networkInterfaces {
+			if eni["InstanceId"] != instanceId {
+				continue
+			}
+			if eni["Type"] == string(NetworkInterfacePrimary) {
+				delete(s.networkInterfaces, eniId)
+				continue
+			}
+			eni["InstanceId"] = ""
+			eni["Status"] = string(Available)
+		}
+		return fakeObject{}, nil
+	}
+}
+
+// registerEcsDisks registers the handlers of the ECS APIs used by the disks. Besides the data disks, the
+// system disks of the instances are described as well.
+func (s *fakeApiServer) registerEcsDisks() {
+	s.handlers["ECS.CreateDisk"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		diskId := s.newId("d")
+		size, _ := strconv.Atoi(params.Get("Size"))
+		if size == 0 {
+			size = 40
+		}
+		disk := fakeObject{
+			"DiskId":               diskId,
+			"DiskName":             params.Get("DiskName"),
+			"Description":          params.Get("Description"),
+			"ZoneId":               params.Get("ZoneId"),
+			"Type":                 string(DiskTypeData),
+			"Category":             string(DiskCloud),
+			"Size":                 size,
+			"SourceSnapshotId":     params.Get("SnapshotId"),
+			"Encrypted":            params.Get("Encrypted") == "true",
+			"Status":               string(Available),
+			"AutoSnapshotPolicyId": "",
+		}
+		setIfPresent(disk, params, "DiskCategory", "Category")
+		s.disks[diskId] = disk
+		return fakeObject{"DiskId": diskId}, nil
+	}
+
+	s.handlers["ECS.DescribeDisks"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		all := []fakeObject{}
+		for _, instance := range s.instances {
+			all = append(all, instance["SystemDisk"].(fakeObject))
+		}
+		for _, disk := range s.disks {
+			all = append(all, disk)
+		}
+		diskIds := make(map[string]bool)
+		for _, id := range fakeJsonListParam(params, "DiskIds") {
+			diskIds[id] = true
+		}
+		disks := []fakeObject{}
+		for _, disk := range all {
+			if instanceId := params.Get("InstanceId"); instanceId != "" && disk["InstanceId"] != instanceId {
+				continue
+			}
+			if diskType := params.Get("DiskType"); diskType != "" && diskType != string(DiskTypeAll) && disk["Type"] != diskType {
+				continue
+			}
+			if len(diskIds) > 0 && !diskIds[disk["DiskId"].(string)] {
+				continue
+			}
+			disks = append(disks, disk)
+		}
+		return fakeObject{"Disks": fakeObject{"Disk": disks}, "TotalCount": len(disks), "PageNumber": 1, "PageSize": 10}, nil
+	}
+
+	s.handlers["ECS.ModifyDiskAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		diskId := request.Params.Get("DiskId")
+		disk, ok := s.disks[diskId]
+		if !ok {
+			return nil, fakeNotFoundError("InvalidDiskId.NotFound", "disk", diskId)
+		}
+		setIfPresent(disk, request.Params, "DiskName", "DiskName")
+		setIfPresent(disk, request.Params, "Description", "Description")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteDisk"] = func(request *fakeApiRequest) (interface{}, error) {
+		diskId := request.Params.Get("DiskId")
+		if _, ok := s.disks[diskId]; !ok {
+			return nil, fakeNotFoundError("InvalidDiskId.NotFound", "disk", diskId)
+		}
+		delete(s.disks, diskId)
+		delete(s.tags, diskId)
+		return fakeObject{}, nil
+	}
+}
+
+// fakeDisk returns the data disk or the system disk of an instance.
+func (s *fakeApiServer) fakeDisk(diskId string) (fakeObject, bool) {
+	if disk, ok := s.disks[diskId]; ok {
+		return disk, true
+	}
+	for _, instance := range s.instances {
+		if disk := instance["SystemDisk"].(fakeObject); disk["DiskId"] == diskId {
+			return disk, true
+		}
+	}
+	return nil, false
+}
+
+// registerEcsSnapshots registers the handlers of the ECS APIs used by the snapshots and the automatic snapshot
+// policies. The snapshots are accomplished as soon as they are created.
+func (s *fakeApiServer) registerEcsSnapshots() {
+	s.handlers["ECS.CreateSnapshot"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		disk, ok := s.fakeDisk(params.Get("DiskId"))
+		if !ok {
+			return nil, fakeNotFoundError("InvalidDiskId.NotFound", "disk", params.Get("DiskId"))
+		}
+		snapshotId := s.newId("s")
+		snapshot := fakeObject{
+			"SnapshotId":     snapshotId,
+			"SnapshotName":   params.Get("SnapshotName"),
+			"Description":    params.Get("Description"),
+			"SourceDiskId":   disk["DiskId"],
+			"SourceDiskType": disk["Type"],
+			"SourceDiskSize": strconv.Itoa(disk["Size"].(int)),
+			"Status":         string(SnapshotAccomplished),
+			"Progress":       "100%",
+			"Usage":          "none",
+			"Encrypted":      disk["Encrypted"] == true,
+			"CreationTime":   "2019-01-01T00:00:00Z",
+		}
+		if snapshot["SnapshotName"] == "" {
+			snapshot["SnapshotName"] = snapshotId
+		}
+		s.snapshots[snapshotId] = snapshot
+		return fakeObject{"SnapshotId": snapshotId}, nil
+	}
+
+	s.handlers["ECS.DescribeSnapshots"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		snapshotIds := make(map[string]bool)
+		for _, id := range fakeJsonListParam(params, "SnapshotIds") {
+			snapshotIds[id] = true
+		}
+		tagKeys := fakeListParam(params, "Tag", "Key")
+		tagValues := fakeListParam(params, "Tag", "Value")
+		snapshots := []fakeObject{}
+		for id, snapshot := range s.snapshots {
+			if len(snapshotIds) > 0 && !snapshotIds[id] {
+				continue
+			}
+			if diskId := params.Get("DiskId"); diskId != "" && snapshot["SourceDiskId"] != diskId {
+				continue
+			}
+			if status := params.Get("Status"); status != "" && status != "all" && snapshot["Status"] != status {
+				continue
+			}
+			matched := true
+			for i, key := range tagKeys {
+				if value, ok := s.tags[id][key]; !ok || (i < len(tagValues) && value != tagValues[i]) {
+					matched = false
+				}
+			}
+			if !matched {
+				continue
+			}
+			snapshot["Tags"] = fakeObject{"Tag": s.fakeTags(id)}
+			snapshots = append(snapshots, snapshot)
+		}
+		return fakeObject{"Snapshots": fakeObject{"Snapshot": snapshots}, "TotalCount": len(snapshots), "PageNumber": 1, "PageSize": PageSizeLarge}, nil
+	}
+
+	s.handlers["ECS.ModifySnapshotAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		snapshotId := request.Params.Get("SnapshotId")
+		snapshot, ok := s.snapshots[snapshotId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidSnapshotIdNotFound, "snapshot", snapshotId)
+		}
+		setIfPresent(snapshot, request.Params, "SnapshotName", "SnapshotName")
+		setIfPresent(snapshot, request.Params, "Description", "Description")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteSnapshot"] = func(request *fakeApiRequest) (interface{}, error) {
+		snapshotId := request.Params.Get("SnapshotId")
+		if _, ok := s.snapshots[snapshotId]; !ok {
+			return nil, fakeNotFoundError(InvalidSnapshotIdNotFound, "snapshot", snapshotId)
+		}
+		delete(s.snapshots, snapshotId)
+		delete(s.tags, snapshotId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.CreateAutoSnapshotPolicy"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		policyId := s.newId("sp")
+		retentionDays, _ := strconv.Atoi(params.Get("retentionDays"))
+		policy := fakeObject{
+			"AutoSnapshotPolicyId":   policyId,
+			"AutoSnapshotPolicyName": params.Get("autoSnapshotPolicyName"),
+			"RegionId":               string(fakeApiRegion),
+			"TimePoints":             params.Get("timePoints"),
+			"RepeatWeekdays":         params.Get("repeatWeekdays"),
+			"RetentionDays":          retentionDays,
+			"Status":                 "Normal",
+			"CreationTime":           "2019-01-01T00:00:00Z",
+		}
+		if policy["AutoSnapshotPolicyName"] == "" {
+			policy["AutoSnapshotPolicyName"] = policyId
+		}
+		s.snapshotPolicies[policyId] = policy
+		return fakeObject{"AutoSnapshotPolicyId": policyId}, nil
+	}
+
+	s.handlers["ECS.DescribeAutoSnapshotPolicyEx"] = func(request *fakeApiRequest) (interface{}, error) {
+		policies := []fakeObject{}
+		for id, policy := range s.snapshotPolicies {
+			if policyId := request.Params.Get("AutoSnapshotPolicyId"); policyId != "" && policyId != id {
+				continue
+			}
+			diskNums := 0
+			for _, disk := range s.disks {
+				if disk["AutoSnapshotPolicyId"] == id {
+					diskNums++
+				}
+			}
+			policy["DiskNums"] = diskNums
+			policies = append(policies, policy)
+		}
+		return fakeObject{"AutoSnapshotPolicies": fakeObject{"AutoSnapshotPolicy": policies}, "TotalCount": len(policies), "PageNumber": 1, "PageSize": 10}, nil
+	}
+
+	s.handlers["ECS.ModifyAutoSnapshotPolicyEx"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		policyId := params.Get("autoSnapshotPolicyId")
+		policy, ok := s.snapshotPolicies[policyId]
+		if !ok {
+			return nil, fakeNotFoundError("ParameterInvalid", "auto snapshot policy", policyId)
+		}
+		setIfPresent(policy, params, "autoSnapshotPolicyName", "AutoSnapshotPolicyName")
+		setIfPresent(policy, params, "timePoints", "TimePoints")
+		setIfPresent(policy, params, "repeatWeekdays", "RepeatWeekdays")
+		if v := params.Get("retentionDays"); v != "" {
+			policy["RetentionDays"], _ = strconv.Atoi(v)
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteAutoSnapshotPolicy"] = func(request *fakeApiRequest) (interface{}, error) {
+		policyId := request.Params.Get("autoSnapshotPolicyId")
+		if _, ok := s.snapshotPolicies[policyId]; !ok {
+			return nil, fakeNotFoundError("ParameterInvalid", "auto snapshot policy", policyId)
+		}
+		for _, disk := range s.disks {
+			if disk["AutoSnapshotPolicyId"] == policyId {
+				disk["AutoSnapshotPolicyId"] = ""
+			}
+		}
+		delete(s.snapshotPolicies, policyId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.ApplyAutoSnapshotPolicy"] = func(request *fakeApiRequest) (interface{}, error) {
+		policyId := request.Params.Get("autoSnapshotPolicyId")
+		if _, ok := s.snapshotPolicies[policyId]; !ok {
+			return nil, fakeNotFoundError("ParameterInvalid", "auto snapshot policy", policyId)
+		}
+		for _, diskId := range fakeJsonListParam(request.Params, "diskIds") {
+			disk, ok := s.fakeDisk(diskId)
+			if !ok {
+				return nil, fakeNotFoundError("InvalidDiskId.NotFound", "disk", diskId)
+			}
+			disk["AutoSnapshotPolicyId"] = policyId
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.CancelAutoSnapshotPolicy"] = func(request *fakeApiRequest) (interface{}, error) {
+		for _, diskId := range fakeJsonListParam(request.Params, "diskIds") {
+			if disk, ok := s.fakeDisk(diskId); ok {
+				disk["AutoSnapshotPolicyId"] = ""
+			}
+		}
+		return fakeObject{}, nil
+	}
+}
+
+// The parameters of the launch data kept by the launch templates, which are named the same as the ones of RunInstances
+var fakeLaunchTemplateParams = []string{
+	"ImageId", "InstanceType", "SecurityGroupId", "VSwitchId", "InstanceName", "Description", "HostName", "ZoneId",
+	"InternetChargeType", "InternetMaxBandwidthIn", "InternetMaxBandwidthOut", "InstanceChargeType",
+	"SystemDisk.Category", "SystemDisk.Size", "SystemDisk.DiskName", "SystemDisk.Description", "UserData",
+	"KeyPairName", "RamRoleName", "SpotStrategy", "SpotPriceLimit", "SecurityEnhancementStrategy",
+}
+
+// registerEcsLaunchTemplates registers the handlers of the ECS APIs used by the launch templates and their versions.
+func (s *fakeApiServer) registerEcsLaunchTemplates() {
+	s.handlers["ECS.CreateLaunchTemplate"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		name := params.Get("LaunchTemplateName")
+		for _, template := range s.launchTemplates {
+			if template["LaunchTemplateName"] == name {
+				return nil, &fakeApiError{http.StatusBadRequest, "InvalidLaunchTemplateName.Duplicated", fmt.Sprintf("The launch template %s already exists.", name)}
+			}
+		}
+		templateId := s.newId("lt")
+		s.launchTemplates[templateId] = fakeObject{
+			"LaunchTemplateId":     templateId,
+			"LaunchTemplateName":   name,
+			"DefaultVersionNumber": 1,
+			"LatestVersionNumber":  1,
+			"CreateTime":           "2019-01-01T00:00:00Z",
+		}
+		s.launchTemplateVersions[templateId] = []fakeObject{fakeLaunchTemplateVersion(templateId, name, 1, params)}
+		return fakeObject{"LaunchTemplateId": templateId}, nil
+	}
+
+	s.handlers["ECS.CreateLaunchTemplateVersion"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		templateId := params.Get("LaunchTemplateId")
+		template, ok := s.launchTemplates[templateId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidLaunchTemplateNotFound, "launch template", templateId)
+		}
+		versions := s.launchTemplateVersions[templateId]
+		if len(versions) >= 30 {
+			return nil, &fakeApiError{http.StatusForbidden, "QuotaExceed.LaunchTemplateVersion", "The versions of the launch template exceed the quota 30."}
+		}
+		number := len(versions) + 1
+		s.launchTemplateVersions[templateId] = append(versions, fakeLaunchTemplateVersion(templateId, template["LaunchTemplateName"].(string), number, params))
+		template["LatestVersionNumber"] = number
+		return fakeObject{"LaunchTemplateVersionNumber": number}, nil
+	}
+
+	s.handlers["ECS.DescribeLaunchTemplates"] = func(request *fakeApiRequest) (interface{}, error) {
+		templateIds := make(map[string]bool)
+		for _, id := range fakeListParam(request.Params, "LaunchTemplateId", "") {
+			templateIds[id] = true
+		}
+		templates := []fakeObject{}
+		for id, template := range s.launchTemplates {
+			if len(templateIds) > 0 && !templateIds[id] {
+				continue
+			}
+			templates = append(templates, template)
+		}
+		return fakeObject{"LaunchTemplateSets": fakeObject{"LaunchTemplateSet": templates}, "TotalCount": len(templates), "PageNumber": 1, "PageSize": 10}, nil
+	}
+
+	s.handlers["ECS.DescribeLaunchTemplateVersions"] = func(request *fakeApiRequest) (interface{}, error) {
+		templateId := request.Params.Get("LaunchTemplateId")
+		template, ok := s.launchTemplates[templateId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidLaunchTemplateNotFound, "launch template", templateId)
+		}
+		numbers := make(map[string]bool)
+		for _, number := range fakeListParam(request.Params, "LaunchTemplateVersion", "") {
+			numbers[number] = true
+		}
+		versions := []fakeObject{}
+		for _, version := range s.launchTemplateVersions[templateId] {
+			if len(numbers) > 0 && !numbers[strconv.Itoa(version["VersionNumber"].(int))] {
+				continue
+			}
+			version["DefaultVersion"] = version["VersionNumber"] == template["DefaultVersionNumber"]
+			versions = append(versions, version)
+		}
+		return fakeObject{"LaunchTemplateVersionSets": fakeObject{"LaunchTemplateVersionSet": versions}, "TotalCount": len(versions), "PageNumber": 1, "PageSize": 10}, nil
+	}
+
+	s.handlers["ECS.ModifyLaunchTemplateDefaultVersion"] = func(request *fakeApiRequest) (interface{}, error) {
+		templateId := request.Params.Get("LaunchTemplateId")
+		template, ok := s.launchTemplates[templateId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidLaunchTemplateNotFound, "launch template", templateId)
+		}
+		number, _ := strconv.Atoi(request.Params.Get("DefaultVersionNumber"))
+		if number < 1 || number > len(s.launchTemplateVersions[templateId]) {
+			return nil, fakeNotFoundError("InvalidLaunchTemplateVersion.NotFound", "launch template version", request.Params.Get("DefaultVersionNumber"))
+		}
+		template["DefaultVersionNumber"] = number
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteLaunchTemplate"] = func(request *fakeApiRequest) (interface{}, error) {
+		templateId := request.Params.Get("LaunchTemplateId")
+		if _, ok := s.launchTemplates[templateId]; !ok {
+			return nil, fakeNotFoundError(InvalidLaunchTemplateNotFound, "launch template", templateId)
+		}
+		delete(s.launchTemplates, templateId)
+		delete(s.launchTemplateVersions, templateId)
+		return fakeObject{}, nil
+	}
+}
+
+// fakeLaunchTemplateVersion builds the version of the launch template from the parameters of CreateLaunchTemplate
+// or CreateLaunchTemplateVersion.
+func fakeLaunchTemplateVersion(templateId, name string, number int, params url.Values) fakeObject {
+	data := fakeObject{}
+	for _, key := range fakeLaunchTemplateParams {
+		data[key] = params.Get(key)
+	}
+	for _, key := range []string{"InternetMaxBandwidthIn", "InternetMaxBandwidthOut", "SystemDisk.Size"} {
+		data[key], _ = strconv.Atoi(params.Get(key))
+	}
+	data["SpotPriceLimit"], _ = strconv.ParseFloat(params.Get("SpotPriceLimit"), 64)
+
+	disks := []fakeObject{}
+	for i := 1; fakeHasListItem(params, "DataDisk", i); i++ {
+		prefix := fmt.Sprintf("DataDisk.%d.", i)
+		size, _ := strconv.Atoi(params.Get(prefix + "Size"))
+		disks = append(disks, fakeObject{
+			"Size":               size,
+			"Category":           params.Get(prefix + "Category"),
+			"Encrypted":          params.Get(prefix + "Encrypted"),
+			"DiskName":           params.Get(prefix + "DiskName"),
+			"Description":        params.Get(prefix + "Description"),
+			"SnapshotId":         params.Get(prefix + "SnapshotId"),
+			"DeleteWithInstance": params.Get(prefix+"DeleteWithInstance") == "true",
+		})
+	}
+	data["DataDisks"] = fakeObject{"DataDisk": disks}
+
+	interfaces := []fakeObject{}
+	for i := 1; fakeHasListItem(params, "NetworkInterface", i); i++ {
+		prefix := fmt.Sprintf("NetworkInterface.%d.", i)
+		interfaces = append(interfaces, fakeObject{
+			"NetworkInterfaceName": params.Get(prefix + "NetworkInterfaceName"),
+			"VSwitchId":            params.Get(prefix + "VSwitchId"),
+			"SecurityGroupId":      params.Get(prefix + "SecurityGroupId"),
+			"PrimaryIpAddress":     params.Get(prefix + "PrimaryIpAddress"),
+			"Description":          params.Get(prefix + "Description"),
+		})
+	}
+	data["NetworkInterfaces"] = fakeObject{"NetworkInterface": interfaces}
+
+	tags := []fakeObject{}
+	values := fakeListParam(params, "Tag", "Value")
+	for i, key := range fakeListParam(params, "Tag", "Key") {
+		tag := fakeObject{"Key": key}
+		if i < len(values) {
+			tag["Value"] = values[i]
+		}
+		tags = append(tags, tag)
+	}
+	data["Tags"] = fakeObject{"InstanceTag": tags}
+
+	return fakeObject{
+		"LaunchTemplateId":   templateId,
+		"LaunchTemplateName": name,
+		"VersionNumber":      number,
+		"VersionDescription": params.Get("VersionDescription"),
+		"CreateTime":         "2019-01-01T00:00:00Z",
+		"LaunchTemplateData": data,
+	}
+}
+
+// fakeLaunchTemplateData returns the launch data of the given version of the template, and the default version is
+// used when the version is not specified.
+func (s *fakeApiServer) fakeLaunchTemplateData(templateId, version string) (fakeObject, error) {
+	template, ok := s.launchTemplates[templateId]
+	if !ok {
+		return nil, fakeNotFoundError(InvalidLaunchTemplateNotFound, "launch template", templateId)
+	}
+	number := template["DefaultVersionNumber"].(int)
+	if version != "" {
+		number, _ = strconv.Atoi(version)
+	}
+	versions := s.launchTemplateVersions[templateId]
+	if number < 1 || number > len(versions) {
+		return nil, fakeNotFoundError("InvalidLaunchTemplateVersion.NotFound", "launch template version", version)
+	}
+	return versions[number-1]["LaunchTemplateData"].(fakeObject), nil
+}
+
+// fakeHasListItem returns whether any field of the i-th item of the repeated parameter is present, such as
+// DataDisk.1.Size, since the empty fields of the items are not sent.
+func fakeHasListItem(params url.Values, prefix string, i int) bool {
+	itemPrefix := fmt.Sprintf("%s.%d.", prefix, i)
+	for key := range params {
+		if strings.HasPrefix(key, itemPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// registerEcsNetworkInterfaces registers the handlers of the ECS APIs used by the network interfaces and their
+// secondary private IPs. The network interfaces are attached and detached as soon as they are requested.
+func (s *fakeApiServer) registerEcsNetworkInterfaces() {
+	s.handlers["ECS.CreateNetworkInterface"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		vswitchId := params.Get("VSwitchId")
+		if _, ok := s.vswitches[vswitchId]; !ok {
+			return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+		}
+		groupId := params.Get("SecurityGroupId")
+		if _, ok := s.securityGroups[groupId]; !ok {
+			return nil, fakeNotFoundError(InvalidSecurityGroupIdNotFound, "security group", groupId)
+		}
+		primaryIp := params.Get("PrimaryIpAddress")
+		if primaryIp == "" {
+			primaryIp = fmt.Sprintf("172.16.0.%d", s.sequence%250+2)
+		}
+		eni := s.fakeNetworkInterface(vswitchId, primaryIp, []string{groupId})
+		setIfPresent(eni, params, "NetworkInterfaceName", "NetworkInterfaceName")
+		setIfPresent(eni, params, "Description", "Description")
+		return fakeObject{"NetworkInterfaceId": eni["NetworkInterfaceId"]}, nil
+	}
+
+	s.handlers["ECS.DescribeNetworkInterfaces"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		eniIds := make(map[string]bool)
+		for _, id := range fakeListParam(params, "NetworkInterfaceId", "") {
+			eniIds[id] = true
+		}
+		enis := []fakeObject{}
+		for id, eni := range s.networkInterfaces {
+			if len(eniIds) > 0 && !eniIds[id] {
+				continue
+			}
+			if instanceId := params.Get("InstanceId"); instanceId != "" && eni["InstanceId"] != instanceId {
+				continue
+			}
+			if eniType := params.Get("Type"); eniType != "" && eni["Type"] != eniType {
+				continue
+			}
+			eni["Tags"] = fakeObject{"Tag": s.fakeTags(id)}
+			enis = append(enis, eni)
+		}
+		return fakeObject{"NetworkInterfaceSets": fakeObject{"NetworkInterfaceSet": enis}, "TotalCount": len(enis), "PageNumber": 1, "PageSize": PageSizeLarge}, nil
+	}
+
+	s.handlers["ECS.ModifyNetworkInterfaceAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		eni, err := s.fakeNetworkInterfaceById(params.Get("NetworkInterfaceId"))
+		if err != nil {
+			return nil, err
+		}
+		setIfPresent(eni, params, "NetworkInterfaceName", "NetworkInterfaceName")
+		setIfPresent(eni, params, "Description", "Description")
+		if groups := fakeListParam(params, "SecurityGroupId", ""); len(groups) > 0 {
+			eni["SecurityGroupIds"] = fakeObject{"SecurityGroupId": groups}
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.AttachNetworkInterface"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		instanceId := params.Get("InstanceId")
+		if _, ok := s.instances[instanceId]; !ok {
+			return nil, fakeNotFoundError(EcsNotFound[0], "instance", instanceId)
+		}
+		eni, err := s.fakeNetworkInterfaceById(params.Get("NetworkInterfaceId"))
+		if err != nil {
+			return nil, err
+		}
+		if eni["Status"] != string(Available) {
+			return nil, &fakeApiError{http.StatusForbidden, "InvalidOperation.InvalidEniState", "The network interface is not available."}
+		}
+		eni["InstanceId"] = instanceId
+		eni["Status"] = string(InUse)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DetachNetworkInterface"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		eni, err := s.fakeNetworkInterfaceById(params.Get("NetworkInterfaceId"))
+		if err != nil {
+			return nil, err
+		}
+		if eni["InstanceId"] != params.Get("InstanceId") || eni["Type"] == string(NetworkInterfacePrimary) {
+			return nil, &fakeApiError{http.StatusForbidden, "InvalidOperation.InvalidEniState", "The network interface is not attached to the instance."}
+		}
+		eni["InstanceId"] = ""
+		eni["Status"] = string(Available)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteNetworkInterface"] = func(request *fakeApiRequest) (interface{}, error) {
+		eniId := request.Params.Get("NetworkInterfaceId")
+		eni, err := s.fakeNetworkInterfaceById(eniId)
+		if err != nil {
+			return nil, err
+		}
+		if eni["Status"] != string(Available) {
+			return nil, &fakeApiError{http.StatusForbidden, "InvalidOperation.InvalidEniState", "The network interface must be detached before deleting."}
+		}
+		delete(s.networkInterfaces, eniId)
+		delete(s.tags, eniId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.AssignPrivateIpAddresses"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		eni, err := s.fakeNetworkInterfaceById(params.Get("NetworkInterfaceId"))
+		if err != nil {
+			return nil, err
+		}
+		ips := fakeListParam(params, "PrivateIpAddress", "")
+		count, _ := strconv.Atoi(params.Get("SecondaryPrivateIpAddressCount"))
+		for i := 0; i < count; i++ {
+			s.sequence++
+			ips = append(ips, fmt.Sprintf("172.16.1.%d", s.sequence%250+2))
+		}
+		sets := eni["PrivateIpSets"].(fakeObject)
+		for _, ip := range ips {
+			sets["PrivateIpSet"] = append(sets["PrivateIpSet"].([]fakeObject), fakeObject{"PrivateIpAddress": ip, "Primary": false})
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.UnassignPrivateIpAddresses"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		eni, err := s.fakeNetworkInterfaceById(params.Get("NetworkInterfaceId"))
+		if err != nil {
+			return nil, err
+		}
+		unassigned := make(map[string]bool)
+		for _, ip := range fakeListParam(params, "PrivateIpAddress", "") {
+			unassigned[ip] = true
+		}
+		sets := eni["PrivateIpSets"].(fakeObject)
+		kept := []fakeObject{}
+		for _, ip := range sets["PrivateIpSet"].([]fakeObject) {
+			if ip["Primary"] == true || !unassigned[ip["PrivateIpAddress"].(string)] {
+				kept = append(kept, ip)
+			}
+		}
+		sets["PrivateIpSet"] = kept
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.AssignIpv6Addresses"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		eni, err := s.fakeNetworkInterfaceById(params.Get("NetworkInterfaceId"))
+		if err != nil {
+			return nil, err
+		}
+		block, ok := s.vswitches[eni["VSwitchId"].(string)]["Ipv6CidrBlock"].(string)
+		if !ok {
+			return nil, &fakeApiError{http.StatusForbidden, "InvalidOperation.Ipv6NotSupport", "The vswitch of the network interface has not enabled IPv6."}
+		}
+		ips := fakeListParam(params, "Ipv6Address", "")
+		count, _ := strconv.Atoi(params.Get("Ipv6AddressCount"))
+		for i := 0; i < count; i++ {
+			s.sequence++
+			ips = append(ips, fmt.Sprintf("%s%x", strings.TrimSuffix(block, "/64"), s.sequence))
+		}
+		sets := eni["Ipv6Sets"].(fakeObject)
+		for _, ip := range ips {
+			sets["Ipv6Set"] = append(sets["Ipv6Set"].([]fakeObject), fakeObject{"Ipv6Address": ip, "Ipv6AddressId": s.newId("ipv6")})
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.UnassignIpv6Addresses"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		eni, err := s.fakeNetworkInterfaceById(params.Get("NetworkInterfaceId"))
+		if err != nil {
+			return nil, err
+		}
+		unassigned := make(map[string]bool)
+		for _, ip := range fakeListParam(params, "Ipv6Address", "") {
+			unassigned[ip] = true
+		}
+		sets := eni["Ipv6Sets"].(fakeObject)
+		kept := []fakeObject{}
+		for _, ip := range sets["Ipv6Set"].([]fakeObject) {
+			if !unassigned[ip["Ipv6Address"].(string)] {
+				kept = append(kept, ip)
+			}
+		}
+		sets["Ipv6Set"] = kept
+		return fakeObject{}, nil
+	}
+}
+
+// fakeNetworkInterface creates an available secondary network interface with the primary IP.
+func (s *fakeApiServer) fakeNetworkInterface(vswitchId, primaryIp string, groupIds []string) fakeObject {
+	eniId := s.newId("eni")
+	vswitch := s.vswitches[vswitchId]
+	eni := fakeObject{
+		"NetworkInterfaceId":   eniId,
+		"NetworkInterfaceName": "",
+		"Description":          "",
+		"Type":                 string(NetworkInterfaceSecondary),
+		"Status":               string(Available),
+		"VpcId":                vswitch["VpcId"],
+		"VSwitchId":            vswitchId,
+		"ZoneId":               vswitch["ZoneId"],
+		"PrivateIpAddress":     primaryIp,
+		"InstanceId":           "",
+		"SecurityGroupIds":     fakeObject{"SecurityGroupId": groupIds},
+		"PrivateIpSets": fakeObject{"PrivateIpSet": []fakeObject{
+			{"PrivateIpAddress": primaryIp, "Primary": true},
+		}},
+		"Ipv6Sets":     fakeObject{"Ipv6Set": []fakeObject{}},
+		"CreationTime": "2019-01-01T00:00:00Z",
+	}
+	s.networkInterfaces[eniId] = eni
+	return eni
+}
+
+func (s *fakeApiServer) fakeNetworkInterfaceById(eniId string) (fakeObject, error) {
+	eni, ok := s.networkInterfaces[eniId]
+	if !ok {
+		return nil, fakeNotFoundError(InvalidEcsNetworkInterfaceIdNotFound, "network interface", eniId)
+	}
+	return eni, nil
+}
+
+// registerEcsDedicatedHosts registers the handlers of the ECS APIs used by the dedicated hosts and the deployment
+// sets. The dedicated hosts are available as soon as they are allocated.
+func (s *fakeApiServer) registerEcsDedicatedHosts() {
+	s.handlers["ECS.AllocateDedicatedHosts"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		hostType := params.Get("DedicatedHostType")
+		if !strings.HasPrefix(hostType, "ddh.") {
+			return nil, &fakeApiError{http.StatusBadRequest, "InvalidDedicatedHostType.NotFound", fmt.Sprintf("The specified dedicated host type %s does not exist.", hostType)}
+		}
+		zoneId := params.Get("ZoneId")
+		if zoneId == "" {
+			zoneId = fakeApiZones[0]
+		}
+		hostId := s.newId("dh")
+		host := fakeObject{
+			"DedicatedHostId":     hostId,
+			"DedicatedHostName":   hostId,
+			"DedicatedHostType":   hostType,
+			"Description":         params.Get("Description"),
+			"ZoneId":              zoneId,
+			"RegionId":            string(fakeApiRegion),
+			"Status":              string(Available),
+			"ActionOnMaintenance": string(MaintenanceMigrate),
+			"ChargeType":          params.Get("ChargeType"),
+			"Cores":               52,
+			"Sockets":             2,
+			"SupportedInstanceTypeFamilies": fakeObject{
+				"SupportedInstanceTypeFamily": []string{"ecs." + strings.TrimPrefix(hostType, "ddh.")},
+			},
+			"CreationTime": "2019-01-01T00:00:00Z",
+		}
+		if name := params.Get("DedicatedHostName"); name != "" {
+			host["DedicatedHostName"] = name
+		}
+		setIfPresent(host, params, "ActionOnMaintenance", "ActionOnMaintenance")
+		if host["ActionOnMaintenance"] == "" {
+			host["ActionOnMaintenance"] = string(MaintenanceMigrate)
+		}
+		s.dedicatedHosts[hostId] = host
+		return fakeObject{"DedicatedHostIdSets": fakeObject{"DedicatedHostId": []string{hostId}}}, nil
+	}
+
+	s.handlers["ECS.DescribeDedicatedHosts"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		hostIds := make(map[string]bool)
+		for _, id := range fakeJsonListParam(params, "DedicatedHostIds") {
+			hostIds[id] = true
+		}
+		hosts := []fakeObject{}
+		for id, host := range s.dedicatedHosts {
+			if len(hostIds) > 0 && !hostIds[id] {
+				continue
+			}
+			if zoneId := params.Get("ZoneId"); zoneId != "" && host["ZoneId"] != zoneId {
+				continue
+			}
+			if hostType := params.Get("DedicatedHostType"); hostType != "" && host["DedicatedHostType"] != hostType {
+				continue
+			}
+			if status := params.Get("Status"); status != "" && host["Status"] != status {
+				continue
+			}
+			instances := []fakeObject{}
+			for _, instanceId := range s.fakeDedicatedHostInstances(id) {
+				instances = append(instances, fakeObject{"InstanceId": instanceId})
+			}
+			host["Instances"] = fakeObject{"Instance": instances}
+			host["Tags"] = fakeObject{"Tag": s.fakeTags(id)}
+			hosts = append(hosts, host)
+		}
+		return fakeObject{"DedicatedHosts": fakeObject{"DedicatedHost": hosts}, "TotalCount": len(hosts), "PageNumber": 1, "PageSize": PageSizeLarge}, nil
+	}
+
+	s.handlers["ECS.ModifyDedicatedHostAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		hostId := params.Get("DedicatedHostId")
+		host, ok := s.dedicatedHosts[hostId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidDedicatedHostIdNotFound, "dedicated host", hostId)
+		}
+		setIfPresent(host, params, "DedicatedHostName", "DedicatedHostName")
+		setIfPresent(host, params, "Description", "Description")
+		setIfPresent(host, params, "ActionOnMaintenance", "ActionOnMaintenance")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.ReleaseDedicatedHost"] = func(request *fakeApiRequest) (interface{}, error) {
+		hostId := request.Params.Get("DedicatedHostId")
+		if _, ok := s.dedicatedHosts[hostId]; !ok {
+			return nil, fakeNotFoundError(InvalidDedicatedHostIdNotFound, "dedicated host", hostId)
+		}
+		if len(s.fakeDedicatedHostInstances(hostId)) > 0 {
+			return nil, &fakeApiError{http.StatusForbidden, IncorrectDedicatedHostStatus, "The instances on the dedicated host must be released first."}
+		}
+		delete(s.dedicatedHosts, hostId)
+		delete(s.tags, hostId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.CreateDeploymentSet"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		setId := s.newId("ds")
+		strategy := params.Get("Strategy")
+		if strategy == "" {
+			strategy = string(DeploymentSetAvailability)
+		}
+		s.deploymentSets[setId] = fakeObject{
+			"DeploymentSetId":          setId,
+			"DeploymentSetName":        params.Get("DeploymentSetName"),
+			"DeploymentSetDescription": params.Get("Description"),
+			"DeploymentStrategy":       strategy,
+			"CreationTime":             "2019-01-01T00:00:00Z",
+		}
+		return fakeObject{"DeploymentSetId": setId}, nil
+	}
+
+	s.handlers["ECS.DescribeDeploymentSets"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		setIds := make(map[string]bool)
+		for _, id := range fakeJsonListParam(params, "DeploymentSetIds") {
+			setIds[id] = true
+		}
+		sets := []fakeObject{}
+		for id, set := range s.deploymentSets {
+			if len(setIds) > 0 && !setIds[id] {
+				continue
+			}
+			if strategy := params.Get("Strategy"); strategy != "" && set["DeploymentStrategy"] != strategy {
+				continue
+			}
+			instanceIds := s.fakeDeploymentSetInstances(id)
+			set["InstanceIds"] = fakeObject{"InstanceId": instanceIds}
+			set["InstanceAmount"] = len(instanceIds)
+			sets = append(sets, set)
+		}
+		return fakeObject{"DeploymentSets": fakeObject{"DeploymentSet": sets}, "TotalCount": len(sets), "PageNumber": 1, "PageSize": PageSizeLarge}, nil
+	}
+
+	s.handlers["ECS.ModifyDeploymentSetAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		setId := params.Get("DeploymentSetId")
+		set, ok := s.deploymentSets[setId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidDeploymentSetIdNotFound, "deployment set", setId)
+		}
+		setIfPresent(set, params, "DeploymentSetName", "DeploymentSetName")
+		setIfPresent(set, params, "Description", "DeploymentSetDescription")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["ECS.DeleteDeploymentSet"] = func(request *fakeApiRequest) (interface{}, error) {
+		setId := request.Params.Get("DeploymentSetId")
+		if _, ok := s.deploymentSets[setId]; !ok {
+			return nil, fakeNotFoundError(InvalidDeploymentSetIdNotFound, "deployment set", setId)
+		}
+		if len(s.fakeDeploymentSetInstances(setId)) > 0 {
+			return nil, &fakeApiError{http.StatusForbidden, DependencyViolation, "The instances in the deployment set must be released first."}
+		}
+		delete(s.deploymentSets, setId)
+		return fakeObject{}, nil
+	}
+}
+
+// fakeDedicatedHostInstances returns the ids of the instances placed on the dedicated host.
+func (s *fakeApiServer) fakeDedicatedHostInstances(hostId string) []string {
+	instanceIds := []string{}
+	for id, instance := range s.instances {
+		if attribute, ok := instance["DedicatedHostAttribute"].(fakeObject); ok && attribute["DedicatedHostId"] == hostId {
+			instanceIds = append(instanceIds, id)
+		}
+	}
+	return instanceIds
+}
+
+// fakeDeploymentSetInstances returns the ids of the instances in the deployment set.
+func (s *fakeApiServer) fakeDeploymentSetInstances(setId string) []string {
+	instanceIds := []string{}
+	for id, instance := range s.instances {
+		if instance["DeploymentSetId"] == setId {
+			instanceIds = append(instanceIds, id)
+		}
+	}
+	return instanceIds
+}
@@ -0,0 +1,134 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// registerSlb registers the handlers of the load balancer and its tags APIs.
+func (s *fakeApiServer) registerSlb() {
+	s.handlers["SLB.CreateLoadBalancer"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		vswitchId := params.Get("VSwitchId")
+		vpcId := ""
+		if vswitchId != "" {
+			vswitch, ok := s.vswitches[vswitchId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+			}
+			vpcId = vswitch["VpcId"].(string)
+		}
+		bandwidth, _ := strconv.Atoi(params.Get("Bandwidth"))
+		loadBalancerId := s.newId("lb")
+		address := "172.16.0.100"
+		if params.Get("AddressType") == strings.ToLower(string(Internet)) {
+			address = "47.95.1.100"
+		}
+		ipVersion := params.Get("AddressIPVersion")
+		if ipVersion == string(IPVersion6) {
+			if params.Get("AddressType") != strings.ToLower(string(Internet)) {
+				return nil, &fakeApiError{http.StatusBadRequest, "InvalidParameter", "The IPv6 load balancer only supports the internet address."}
+			}
+			address = "2408:4000:1::100"
+		} else if ipVersion == "" {
+			ipVersion = string(IPVersion4)
+		}
+		s.loadBalancers[loadBalancerId] = fakeObject{
+			"LoadBalancerId":     loadBalancerId,
+			"RegionId":           string(fakeApiRegion),
+			"LoadBalancerName":   params.Get("LoadBalancerName"),
+			"LoadBalancerStatus": strings.ToLower(string(Active)),
+			"AddressType":        params.Get("AddressType"),
+			"InternetChargeType": params.Get("InternetChargeType"),
+			"Bandwidth":          bandwidth,
+			"VpcId":              vpcId,
+			"VSwitchId":          vswitchId,
+			"Address":            address,
+			"LoadBalancerSpec":   params.Get("LoadBalancerSpec"),
+			"AddressIPVersion":   ipVersion,
+		}
+		return fakeObject{"LoadBalancerId": loadBalancerId, "Address": address, "VpcId": vpcId, "VSwitchId": vswitchId}, nil
+	}
+
+	s.handlers["SLB.DescribeLoadBalancerAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		loadBalancerId := request.Params.Get("LoadBalancerId")
+		loadBalancer, ok := s.loadBalancers[loadBalancerId]
+		if !ok {
+			return nil, fakeNotFoundError(LoadBalancerNotFound, "load balancer", loadBalancerId)
+		}
+		return loadBalancer, nil
+	}
+
+	s.handlers["SLB.SetLoadBalancerName"] = func(request *fakeApiRequest) (interface{}, error) {
+		loadBalancerId := request.Params.Get("LoadBalancerId")
+		loadBalancer, ok := s.loadBalancers[loadBalancerId]
+		if !ok {
+			return nil, fakeNotFoundError(LoadBalancerNotFound, "load balancer", loadBalancerId)
+		}
+		setIfPresent(loadBalancer, request.Params, "LoadBalancerName", "LoadBalancerName")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["SLB.ModifyLoadBalancerInstanceSpec"] = func(request *fakeApiRequest) (interface{}, error) {
+		loadBalancerId := request.Params.Get("LoadBalancerId")
+		loadBalancer, ok := s.loadBalancers[loadBalancerId]
+		if !ok {
+			return nil, fakeNotFoundError(LoadBalancerNotFound, "load balancer", loadBalancerId)
+		}
+		setIfPresent(loadBalancer, request.Params, "LoadBalancerSpec", "LoadBalancerSpec")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["SLB.ModifyLoadBalancerInternetSpec"] = func(request *fakeApiRequest) (interface{}, error) {
+		loadBalancerId := request.Params.Get("LoadBalancerId")
+		loadBalancer, ok := s.loadBalancers[loadBalancerId]
+		if !ok {
+			return nil, fakeNotFoundError(LoadBalancerNotFound, "load balancer", loadBalancerId)
+		}
+		setIfPresent(loadBalancer, request.Params, "InternetChargeType", "InternetChargeType")
+		if v := request.Params.Get("Bandwidth"); v != "" {
+			loadBalancer["Bandwidth"], _ = strconv.Atoi(v)
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["SLB.DeleteLoadBalancer"] = func(request *fakeApiRequest) (interface{}, error) {
+		loadBalancerId := request.Params.Get("LoadBalancerId")
+		if _, ok := s.loadBalancers[loadBalancerId]; !ok {
+			return nil, fakeNotFoundError(LoadBalancerNotFound, "load balancer", loadBalancerId)
+		}
+		delete(s.loadBalancers, loadBalancerId)
+		delete(s.tags, loadBalancerId)
+		return fakeObject{}, nil
+	}
+
+	// The tags are paginated, and an empty page is returned after the last one.
+	s.handlers["SLB.DescribeTags"] = func(request *fakeApiRequest) (interface{}, error) {
+		pageNumber, _ := strconv.Atoi(request.Params.Get("PageNumber"))
+		tags := s.fakeTags(request.Params.Get("LoadBalancerId"))
+		if pageNumber > 1 {
+			tags = []fakeObject{}
+		}
+		return fakeObject{"TagSets": fakeObject{"TagSet": tags}, "TotalCount": len(tags), "PageNumber": pageNumber, "PageSize": tags_max_page_size}, nil
+	}
+
+	s.handlers["SLB.AddTags"] = func(request *fakeApiRequest) (interface{}, error) {
+		var tags []SlbTag
+		json.Unmarshal([]byte(request.Params.Get("Tags")), &tags)
+		for _, tag := range tags {
+			s.setTag(request.Params.Get("LoadBalancerId"), tag.TagKey, tag.TagValue)
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["SLB.RemoveTags"] = func(request *fakeApiRequest) (interface{}, error) {
+		var tags []SlbTag
+		json.Unmarshal([]byte(request.Params.Get("Tags")), &tags)
+		for _, tag := range tags {
+			delete(s.tags[request.Params.Get("LoadBalancerId")], tag.TagKey)
+		}
+		return fakeObject{}, nil
+	}
+}
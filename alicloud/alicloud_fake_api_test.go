@@ -0,0 +1,334 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/endpoints"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// The region used by the unit tests running against the fake API server
+const fakeApiRegion = connectivity.Beijing
+
+// The products served by the fake API server, keyed by the API version of their RPC requests
+var fakeApiProducts = map[string]connectivity.ServiceCode{
+	"2014-05-26": connectivity.ECSCode,
+	"2016-04-28": connectivity.VPCCode,
+	"2014-05-15": connectivity.SLBCode,
+}
+
+// fakeObject is a JSON object in the requests and responses of the fake API server.
+type fakeObject map[string]interface{}
+
+// fakeApiRequest is a request received by the fake API server.
+// The RPC requests carry their parameters in Params, and the ROA requests also carry the Body.
+type fakeApiRequest struct {
+	Method string
+	Path   string
+	Params url.Values
+	Body   []byte
+}
+
+// fakeApiHandler handles a request and returns the response object or a *fakeApiError.
+type fakeApiHandler func(request *fakeApiRequest) (interface{}, error)
+
+// fakeApiError is the error returned by the fake API server, it is sent in the same format as Alibaba Cloud.
+type fakeApiError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *fakeApiError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
+
+func fakeNotFoundError(code, resource, id string) error {
+	return &fakeApiError{http.StatusNotFound, code, fmt.Sprintf("The specified %s %s does not exist.", resource, id)}
+}
+
+// fakeApiServer is an in-process HTTPS server which fakes the RPC and ROA APIs of Alibaba Cloud.
+// The RPC handlers are keyed by "<product>.<action>", such as "ECS.DescribeInstances", and the ROA
+// handlers are keyed by "<method> <path>", such as "GET /clusters". Besides canned responses, the
+// handlers registered by registerEcs, registerVpc and registerSlb keep the resources in memory so that
+// the CRUD of the resources can run against it.
+type fakeApiServer struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	handlers map[string]fakeApiHandler
+	sequence int
+	// The keys of the handled requests in order
+	requests []string
+
+	vpcs           map[string]fakeObject
+	vswitches      map[string]fakeObject
+	securityGroups map[string]fakeObject
+	instances      map[string]fakeObject
+	loadBalancers  map[string]fakeObject
+	images         map[string]fakeObject
+	// The accounts which the images are shared to keyed by the image id
+	imageShares map[string][]string
+	// The data disks, and the system disks are kept by the instances
+	disks            map[string]fakeObject
+	snapshots        map[string]fakeObject
+	snapshotPolicies map[string]fakeObject
+	launchTemplates  map[string]fakeObject
+	// The network interfaces, including the primary ones created along with the instances in VPC
+	networkInterfaces map[string]fakeObject
+	// The versions of the launch templates keyed by the template id, and the version number is the index plus one
+	launchTemplateVersions map[string][]fakeObject
+	dedicatedHosts         map[string]fakeObject
+	deploymentSets         map[string]fakeObject
+	// The network ACLs, including their entries and the vswitches associated with them
+	networkAcls map[string]fakeObject
+	// The IPv6 gateways, including their egress-only rules
+	ipv6Gateways map[string]fakeObject
+	flowLogs     map[string]fakeObject
+	// The custom route tables, including their route entries
+	routeTables map[string]fakeObject
+	natGateways map[string]fakeObject
+	// The DNAT and SNAT entries keyed by the id of their forward or SNAT table
+	natEntries map[string][]fakeObject
+	// The tags of the resources keyed by the resource id
+	tags map[string]map[string]string
+}
+
+func newFakeApiServer() *fakeApiServer {
+	s := &fakeApiServer{
+		handlers:               make(map[string]fakeApiHandler),
+		vpcs:                   make(map[string]fakeObject),
+		vswitches:              make(map[string]fakeObject),
+		securityGroups:         make(map[string]fakeObject),
+		instances:              make(map[string]fakeObject),
+		loadBalancers:          make(map[string]fakeObject),
+		images:                 make(map[string]fakeObject),
+		imageShares:            make(map[string][]string),
+		disks:                  make(map[string]fakeObject),
+		snapshots:              make(map[string]fakeObject),
+		snapshotPolicies:       make(map[string]fakeObject),
+		launchTemplates:        make(map[string]fakeObject),
+		launchTemplateVersions: make(map[string][]fakeObject),
+		networkInterfaces:      make(map[string]fakeObject),
+		dedicatedHosts:         make(map[string]fakeObject),
+		deploymentSets:         make(map[string]fakeObject),
+		networkAcls:            make(map[string]fakeObject),
+		ipv6Gateways:           make(map[string]fakeObject),
+		flowLogs:               make(map[string]fakeObject),
+		routeTables:            make(map[string]fakeObject),
+		natGateways:            make(map[string]fakeObject),
+		natEntries:             make(map[string][]fakeObject),
+		tags:                   make(map[string]map[string]string),
+	}
+	s.registerEcs()
+	s.registerVpc()
+	s.registerSlb()
+	s.server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Handle registers the handler of a RPC action or a ROA path, and it replaces the existing one.
+func (s *fakeApiServer) Handle(key string, handler fakeApiHandler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.handlers[key] = handler
+}
+
+// HandleCanned registers a handler which always returns the response.
+func (s *fakeApiServer) HandleCanned(key string, response interface{}) {
+	s.Handle(key, func(request *fakeApiRequest) (interface{}, error) {
+		return response, nil
+	})
+}
+
+// Requests returns the keys of the handled requests in order.
+func (s *fakeApiServer) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+func (s *fakeApiServer) Endpoint() string {
+	return strings.TrimPrefix(s.server.URL, "https://")
+}
+
+func (s *fakeApiServer) Close() {
+	s.server.Close()
+}
+
+// newId returns a new resource id with the prefix, such as vpc-fake000001.
+func (s *fakeApiServer) newId(prefix string) string {
+	s.sequence++
+	return fmt.Sprintf("%s-fake%06d", prefix, s.sequence)
+}
+
+func (s *fakeApiServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.ParseForm()
+	request := &fakeApiRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Params: r.Form,
+		Body:   body,
+	}
+
+	key := fmt.Sprintf("%s %s", r.Method, r.URL.Path)
+	if action := r.Form.Get("Action"); action != "" {
+		key = fmt.Sprintf("%s.%s", fakeApiProducts[r.Form.Get("Version")], action)
+	}
+
+	// The handlers are serialized to keep the resources consistent.
+	s.mutex.Lock()
+	s.requests = append(s.requests, key)
+	handler, ok := s.handlers[key]
+	var response interface{}
+	var err error
+	if ok {
+		response, err = handler(request)
+	} else {
+		err = &fakeApiError{http.StatusBadRequest, "UnsupportedOperation", fmt.Sprintf("The fake API server does not support %s.", key)}
+	}
+	s.mutex.Unlock()
+
+	requestId := fmt.Sprintf("FAKE-%s", strings.Replace(key, " ", "-", -1))
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		e, ok := err.(*fakeApiError)
+		if !ok {
+			e = &fakeApiError{http.StatusInternalServerError, "InternalError", err.Error()}
+		}
+		w.WriteHeader(e.Status)
+		json.NewEncoder(w).Encode(fakeObject{"RequestId": requestId, "HostId": r.Host, "Code": e.Code, "Message": e.Message})
+		return
+	}
+	if object, ok := response.(fakeObject); ok {
+		if _, ok := object["RequestId"]; !ok {
+			object["RequestId"] = requestId
+		}
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+// testAccFakeApi starts a fake API server and points the endpoints of testAccProvider at it, so the
+// acceptance test cases can run as unit tests without any credentials. The returned function stops the
+// server and restores testAccProvider, and it should be deferred.
+func testAccFakeApi(t *testing.T) (*fakeApiServer, func()) {
+	s := newFakeApiServer()
+
+	// The endpoints registered by the provider are global in the SDK, so the default ones should be
+	// restored after testing. The central endpoint is used when the product can not be resolved locally.
+	defaults := make(map[connectivity.ServiceCode]string)
+	for _, code := range fakeApiProducts {
+		endpoint, err := endpoints.Resolve(&endpoints.ResolveParam{Product: string(code), RegionId: string(fakeApiRegion)})
+		if err != nil || endpoint == "" {
+			endpoint = fmt.Sprintf("%s.aliyuncs.com", strings.ToLower(string(code)))
+		}
+		defaults[code] = endpoint
+	}
+
+	// The fake API server settles the states by the next describing, so the waiters poll it without really waiting.
+	interval := waitIntervalUnit
+	waitIntervalUnit = time.Millisecond
+
+	configure := testAccProvider.ConfigureFunc
+	testAccProvider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		config := connectivity.Config{
+			AccessKey:             "fake-access-key",
+			SecretKey:             "fake-secret-key",
+			Region:                fakeApiRegion,
+			RegionId:              string(fakeApiRegion),
+			EcsEndpoint:           s.Endpoint(),
+			VpcEndpoint:           s.Endpoint(),
+			SlbEndpoint:           s.Endpoint(),
+			MaxConcurrentRequests: connectivity.DefaultMaxConcurrentRequests,
+			MaxRetries:            connectivity.DefaultMaxRetries,
+			Insecure:              true,
+		}
+		configureTags(d, &config)
+		return config.Client()
+	}
+
+	return s, func() {
+		testAccProvider.ConfigureFunc = configure
+		waitIntervalUnit = interval
+		for code, endpoint := range defaults {
+			endpoints.AddEndpointMapping(string(fakeApiRegion), string(code), endpoint)
+		}
+		s.Close()
+	}
+}
+
+// fakeTags returns the tags of the resource in the format of ECS and SLB.
+func (s *fakeApiServer) fakeTags(resourceId string) []fakeObject {
+	tags := make([]fakeObject, 0)
+	for key, value := range s.tags[resourceId] {
+		tags = append(tags, fakeObject{"TagKey": key, "TagValue": value})
+	}
+	return tags
+}
+
+// CheckTags checks the tags of the resource kept by the fake API server, including the ones not in the state.
+func (s *fakeApiServer) CheckTags(name string, expected map[string]string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if tags := s.tags[rs.Primary.ID]; !reflect.DeepEqual(tags, expected) {
+			return fmt.Errorf("expected the tags of %s to be %v, got %v", name, expected, tags)
+		}
+		return nil
+	}
+}
+
+func (s *fakeApiServer) setTag(resourceId, key, value string) {
+	if _, ok := s.tags[resourceId]; !ok {
+		s.tags[resourceId] = make(map[string]string)
+	}
+	s.tags[resourceId][key] = value
+}
+
+// fakeListParam returns the values of the repeated parameter, such as Tag.1.Key and Tag.2.Key.
+func fakeListParam(params url.Values, prefix, suffix string) []string {
+	var values []string
+	for i := 1; ; i++ {
+		key := fmt.Sprintf("%s.%d", prefix, i)
+		if suffix != "" {
+			key = fmt.Sprintf("%s.%s", key, suffix)
+		}
+		if _, ok := params[key]; !ok {
+			return values
+		}
+		values = append(values, params.Get(key))
+	}
+}
+
+// fakeJsonListParam returns the values of the parameter in the format of JSON string list, such as InstanceIds.
+func fakeJsonListParam(params url.Values, key string) []string {
+	var values []string
+	if v := params.Get(key); v != "" {
+		json.Unmarshal([]byte(v), &values)
+	}
+	return values
+}
+
+// setIfPresent sets the attribute of the object when the parameter is present in the request.
+func setIfPresent(object fakeObject, params url.Values, param, attribute string) {
+	if _, ok := params[param]; ok {
+		object[attribute] = params.Get(param)
+	}
+}
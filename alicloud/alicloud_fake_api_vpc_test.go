@@ -0,0 +1,1200 @@
+package alicloud
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// registerVpc registers the handlers of the VPC and VSwitch APIs.
+func (s *fakeApiServer) registerVpc() {
+	s.handlers["VPC.CreateVpc"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		vpcId := s.newId("vpc")
+		routerId := s.newId("vrt")
+		routeTableId := s.newId("vtb")
+		s.vpcs[vpcId] = fakeObject{
+			"VpcId":        vpcId,
+			"RegionId":     string(fakeApiRegion),
+			"Status":       string(Available),
+			"VpcName":      params.Get("VpcName"),
+			"Description":  params.Get("Description"),
+			"CidrBlock":    params.Get("CidrBlock"),
+			"VRouterId":    routerId,
+			"RouteTableId": routeTableId,
+		}
+		if params.Get("EnableIpv6") == "true" {
+			s.vpcs[vpcId]["Ipv6CidrBlock"] = s.fakeIpv6CidrBlock()
+		}
+		return fakeObject{"VpcId": vpcId, "VRouterId": routerId, "RouteTableId": routeTableId}, nil
+	}
+
+	s.handlers["VPC.DescribeVpcAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		vpcId := request.Params.Get("VpcId")
+		vpc, ok := s.vpcs[vpcId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", vpcId)
+		}
+		response := fakeObject{}
+		for k, v := range vpc {
+			response[k] = v
+		}
+		var vswitchIds []string
+		for id, vswitch := range s.vswitches {
+			if vswitch["VpcId"] == vpcId {
+				vswitchIds = append(vswitchIds, id)
+			}
+		}
+		response["VSwitchIds"] = fakeObject{"VSwitchId": vswitchIds}
+		return response, nil
+	}
+
+	s.handlers["VPC.DescribeVRouters"] = func(request *fakeApiRequest) (interface{}, error) {
+		var routers []fakeObject
+		for _, vpc := range s.vpcs {
+			if vpc["VRouterId"] == request.Params.Get("VRouterId") {
+				routers = append(routers, fakeObject{
+					"VRouterId":     vpc["VRouterId"],
+					"VpcId":         vpc["VpcId"],
+					"RegionId":      vpc["RegionId"],
+					"RouteTableIds": fakeObject{"RouteTableId": []interface{}{vpc["RouteTableId"]}},
+				})
+			}
+		}
+		return fakeObject{"VRouters": fakeObject{"VRouter": routers}, "TotalCount": len(routers)}, nil
+	}
+
+	s.handlers["VPC.ModifyVpcAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		vpcId := request.Params.Get("VpcId")
+		vpc, ok := s.vpcs[vpcId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", vpcId)
+		}
+		setIfPresent(vpc, request.Params, "VpcName", "VpcName")
+		setIfPresent(vpc, request.Params, "Description", "Description")
+		switch request.Params.Get("EnableIPv6") {
+		case "true":
+			if vpc["Ipv6CidrBlock"] == nil {
+				vpc["Ipv6CidrBlock"] = s.fakeIpv6CidrBlock()
+			}
+		case "false":
+			for _, vswitch := range s.vswitches {
+				if vswitch["VpcId"] == vpcId && vswitch["Ipv6CidrBlock"] != nil {
+					return nil, &fakeApiError{http.StatusBadRequest, "DependencyViolation.Ipv6VSwitch", "The specified VPC has vswitches with IPv6 enabled."}
+				}
+			}
+			delete(vpc, "Ipv6CidrBlock")
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteVpc"] = func(request *fakeApiRequest) (interface{}, error) {
+		vpcId := request.Params.Get("VpcId")
+		if _, ok := s.vpcs[vpcId]; !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", vpcId)
+		}
+		for _, vswitch := range s.vswitches {
+			if vswitch["VpcId"] == vpcId {
+				return nil, &fakeApiError{http.StatusBadRequest, "DependencyViolation.VSwitch", "The specified VPC has vswitches."}
+			}
+		}
+		delete(s.vpcs, vpcId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.CreateVSwitch"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		if _, ok := s.vpcs[params.Get("VpcId")]; !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", params.Get("VpcId"))
+		}
+		vswitchId := s.newId("vsw")
+		s.vswitches[vswitchId] = fakeObject{
+			"VSwitchId":               vswitchId,
+			"VpcId":                   params.Get("VpcId"),
+			"Status":                  string(Available),
+			"CidrBlock":               params.Get("CidrBlock"),
+			"ZoneId":                  params.Get("ZoneId"),
+			"VSwitchName":             params.Get("VSwitchName"),
+			"Description":             params.Get("Description"),
+			"AvailableIpAddressCount": 252,
+		}
+		if mask := params.Get("Ipv6CidrBlock"); mask != "" {
+			if err := s.fakeVSwitchIpv6CidrBlock(s.vswitches[vswitchId], mask); err != nil {
+				delete(s.vswitches, vswitchId)
+				return nil, err
+			}
+		}
+		return fakeObject{"VSwitchId": vswitchId}, nil
+	}
+
+	s.handlers["VPC.DescribeVSwitchAttributes"] = func(request *fakeApiRequest) (interface{}, error) {
+		vswitchId := request.Params.Get("VSwitchId")
+		vswitch, ok := s.vswitches[vswitchId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+		}
+		return vswitch, nil
+	}
+
+	s.handlers["VPC.ModifyVSwitchAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		vswitchId := request.Params.Get("VSwitchId")
+		vswitch, ok := s.vswitches[vswitchId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+		}
+		setIfPresent(vswitch, request.Params, "VSwitchName", "VSwitchName")
+		setIfPresent(vswitch, request.Params, "Description", "Description")
+		switch request.Params.Get("EnableIPv6") {
+		case "true":
+			if err := s.fakeVSwitchIpv6CidrBlock(vswitch, request.Params.Get("Ipv6CidrBlock")); err != nil {
+				return nil, err
+			}
+		case "false":
+			delete(vswitch, "Ipv6CidrBlock")
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteVSwitch"] = func(request *fakeApiRequest) (interface{}, error) {
+		vswitchId := request.Params.Get("VSwitchId")
+		if _, ok := s.vswitches[vswitchId]; !ok {
+			return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+		}
+		for _, instance := range s.instances {
+			if instance["VpcAttributes"].(fakeObject)["VSwitchId"] == vswitchId {
+				return nil, &fakeApiError{http.StatusBadRequest, "DependencyViolation", "The specified vswitch has instances."}
+			}
+		}
+		delete(s.vswitches, vswitchId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.TagResources"] = func(request *fakeApiRequest) (interface{}, error) {
+		keys := fakeListParam(request.Params, "Tag", "Key")
+		values := fakeListParam(request.Params, "Tag", "Value")
+		for _, resourceId := range fakeListParam(request.Params, "ResourceId", "") {
+			for i, key := range keys {
+				s.setTag(resourceId, key, values[i])
+			}
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.UntagResources"] = func(request *fakeApiRequest) (interface{}, error) {
+		for _, resourceId := range fakeListParam(request.Params, "ResourceId", "") {
+			for _, key := range fakeListParam(request.Params, "TagKey", "") {
+				delete(s.tags[resourceId], key)
+			}
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.ListTagResources"] = func(request *fakeApiRequest) (interface{}, error) {
+		resourceIds := fakeListParam(request.Params, "ResourceId", "")
+		if len(resourceIds) < 1 {
+			for resourceId := range s.tags {
+				resourceIds = append(resourceIds, resourceId)
+			}
+		}
+		keys := fakeListParam(request.Params, "Tag", "Key")
+		values := fakeListParam(request.Params, "Tag", "Value")
+		resources := make([]fakeObject, 0)
+		for _, resourceId := range resourceIds {
+			for key, value := range s.tags[resourceId] {
+				matched := len(keys) < 1
+				for i := range keys {
+					if keys[i] == key && values[i] == value {
+						matched = true
+					}
+				}
+				if matched {
+					resources = append(resources, fakeObject{
+						"ResourceId":   resourceId,
+						"ResourceType": request.Params.Get("ResourceType"),
+						"TagKey":       key,
+						"TagValue":     value,
+					})
+				}
+			}
+		}
+		return fakeObject{"TagResources": fakeObject{"TagResource": resources}}, nil
+	}
+
+	s.registerVpcNetworkAcls()
+	s.registerVpcIpv6Gateways()
+	s.registerVpcFlowLogs()
+	s.registerVpcRouteTables()
+	s.registerVpcNatGateways()
+}
+
+// fakeIpv6CidrBlock allocates a /56 IPv6 CIDR block for a VPC.
+func (s *fakeApiServer) fakeIpv6CidrBlock() string {
+	s.sequence++
+	return fmt.Sprintf("2408:4000:%x::/56", s.sequence)
+}
+
+// fakeVSwitchIpv6CidrBlock sets the /64 IPv6 CIDR block of the vswitch, whose last 8 bits are the mask following the
+// IPv6 CIDR block of the VPC.
+func (s *fakeApiServer) fakeVSwitchIpv6CidrBlock(vswitch fakeObject, mask string) error {
+	n, err := strconv.Atoi(mask)
+	if err != nil || n < 0 || n > 255 {
+		return &fakeApiError{http.StatusBadRequest, "InvalidParameter", fmt.Sprintf("The specified Ipv6CidrBlock %s is invalid.", mask)}
+	}
+	block, ok := s.vpcs[vswitch["VpcId"].(string)]["Ipv6CidrBlock"].(string)
+	if !ok {
+		return &fakeApiError{http.StatusBadRequest, "IncorrectStatus.Ipv6CidrBlock", "The VPC of the vswitch has not enabled IPv6."}
+	}
+	vswitch["Ipv6CidrBlock"] = fmt.Sprintf("%s%x::/64", strings.TrimSuffix(block, ":/56"), n)
+	return nil
+}
+
+// registerVpcNetworkAcls registers the handlers of the network ACL APIs. The network ACLs are Modifying and the vswitches
+// are BINDING or UNBINDING after they are changed, and they are settled by the next describing, so that the waiters run.
+func (s *fakeApiServer) registerVpcNetworkAcls() {
+	notFound := func(aclId string) error {
+		return fakeNotFoundError("InvalidNetworkAcl.NotFound", "network ACL", aclId)
+	}
+	entries := func(request *fakeApiRequest, prefix, peer string) []fakeObject {
+		result := make([]fakeObject, 0)
+		for i := 1; fakeHasListItem(request.Params, prefix, i); i++ {
+			key := func(name string) string {
+				return request.Params.Get(fmt.Sprintf("%s.%d.%s", prefix, i, name))
+			}
+			result = append(result, fakeObject{
+				"NetworkAclEntryId":   s.newId("nae"),
+				"NetworkAclEntryName": key("NetworkAclEntryName"),
+				"Description":         key("Description"),
+				"Policy":              key("Policy"),
+				"Protocol":            key("Protocol"),
+				"Port":                key("Port"),
+				peer:                  key(peer),
+				"EntryType":           NetworkAclEntryCustom,
+			})
+		}
+		// The system entry which allows all of the traffic is always the last one
+		return append(result, fakeObject{
+			"NetworkAclEntryId": s.newId("nae"),
+			"Policy":            string(NetworkAclAccept),
+			"Protocol":          string(All),
+			"Port":              AllPortRange,
+			peer:                "0.0.0.0/0",
+			"EntryType":         "system",
+		})
+	}
+
+	s.handlers["VPC.CreateNetworkAcl"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		if _, ok := s.vpcs[params.Get("VpcId")]; !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", params.Get("VpcId"))
+		}
+		aclId := s.newId("nacl")
+		s.networkAcls[aclId] = fakeObject{
+			"NetworkAclId":      aclId,
+			"VpcId":             params.Get("VpcId"),
+			"RegionId":          string(fakeApiRegion),
+			"Status":            string(Modifying),
+			"NetworkAclName":    params.Get("NetworkAclName"),
+			"Description":       params.Get("Description"),
+			"IngressAclEntries": fakeObject{"IngressAclEntry": entries(request, "IngressAclEntries", "SourceCidrIp")},
+			"EgressAclEntries":  fakeObject{"EgressAclEntry": entries(request, "EgressAclEntries", "DestinationCidrIp")},
+			"Resources":         fakeObject{"Resource": make([]fakeObject, 0)},
+		}
+		return fakeObject{"NetworkAclId": aclId}, nil
+	}
+
+	s.handlers["VPC.DescribeNetworkAcls"] = func(request *fakeApiRequest) (interface{}, error) {
+		acls := make([]fakeObject, 0)
+		for aclId, acl := range s.networkAcls {
+			if id := request.Params.Get("NetworkAclId"); id != "" && id != aclId {
+				continue
+			}
+			resources := make([]fakeObject, 0)
+			for _, r := range acl["Resources"].(fakeObject)["Resource"].([]fakeObject) {
+				resources = append(resources, fakeObject{"ResourceId": r["ResourceId"], "ResourceType": r["ResourceType"], "Status": r["Status"]})
+			}
+			response := fakeObject{}
+			for k, v := range acl {
+				response[k] = v
+			}
+			response["Resources"] = fakeObject{"Resource": resources}
+			acls = append(acls, response)
+
+			acl["Status"] = string(Available)
+			settled := make([]fakeObject, 0)
+			for _, r := range acl["Resources"].(fakeObject)["Resource"].([]fakeObject) {
+				if r["Status"] != string(NetworkAclResourceUnbinding) {
+					r["Status"] = string(NetworkAclResourceBinded)
+					settled = append(settled, r)
+				}
+			}
+			acl["Resources"] = fakeObject{"Resource": settled}
+		}
+		return fakeObject{"NetworkAcls": fakeObject{"NetworkAcl": acls}, "TotalCount": len(acls)}, nil
+	}
+
+	s.handlers["VPC.ModifyNetworkAclAttributes"] = func(request *fakeApiRequest) (interface{}, error) {
+		aclId := request.Params.Get("NetworkAclId")
+		acl, ok := s.networkAcls[aclId]
+		if !ok {
+			return nil, notFound(aclId)
+		}
+		setIfPresent(acl, request.Params, "NetworkAclName", "NetworkAclName")
+		setIfPresent(acl, request.Params, "Description", "Description")
+		acl["Status"] = string(Modifying)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.UpdateNetworkAclEntries"] = func(request *fakeApiRequest) (interface{}, error) {
+		aclId := request.Params.Get("NetworkAclId")
+		acl, ok := s.networkAcls[aclId]
+		if !ok {
+			return nil, notFound(aclId)
+		}
+		if acl["Status"] != string(Available) {
+			return nil, &fakeApiError{http.StatusBadRequest, "IncorrectStatus.NetworkAcl", "The status of the network ACL is incorrect."}
+		}
+		if request.Params.Get("UpdateIngressAclEntries") == "true" {
+			acl["IngressAclEntries"] = fakeObject{"IngressAclEntry": entries(request, "IngressAclEntries", "SourceCidrIp")}
+		}
+		if request.Params.Get("UpdateEgressAclEntries") == "true" {
+			acl["EgressAclEntries"] = fakeObject{"EgressAclEntry": entries(request, "EgressAclEntries", "DestinationCidrIp")}
+		}
+		acl["Status"] = string(Modifying)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.AssociateNetworkAcl"] = func(request *fakeApiRequest) (interface{}, error) {
+		aclId := request.Params.Get("NetworkAclId")
+		acl, ok := s.networkAcls[aclId]
+		if !ok {
+			return nil, notFound(aclId)
+		}
+		resources := acl["Resources"].(fakeObject)["Resource"].([]fakeObject)
+		for i := 1; fakeHasListItem(request.Params, "Resource", i); i++ {
+			vswitchId := request.Params.Get(fmt.Sprintf("Resource.%d.ResourceId", i))
+			vswitch, ok := s.vswitches[vswitchId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+			}
+			if vswitch["VpcId"] != acl["VpcId"] {
+				return nil, &fakeApiError{http.StatusBadRequest, "InvalidParameter", "The vswitch and the network ACL are not in the same VPC."}
+			}
+			for _, other := range s.networkAcls {
+				for _, r := range other["Resources"].(fakeObject)["Resource"].([]fakeObject) {
+					if r["ResourceId"] == vswitchId {
+						return nil, &fakeApiError{http.StatusBadRequest, "IncorrectStatus.Vswitch", "The vswitch has been associated with a network ACL."}
+					}
+				}
+			}
+			resources = append(resources, fakeObject{
+				"ResourceId":   vswitchId,
+				"ResourceType": request.Params.Get(fmt.Sprintf("Resource.%d.ResourceType", i)),
+				"Status":       string(NetworkAclResourceBinding),
+			})
+		}
+		acl["Resources"] = fakeObject{"Resource": resources}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.UnassociateNetworkAcl"] = func(request *fakeApiRequest) (interface{}, error) {
+		aclId := request.Params.Get("NetworkAclId")
+		acl, ok := s.networkAcls[aclId]
+		if !ok {
+			return nil, notFound(aclId)
+		}
+		for i := 1; fakeHasListItem(request.Params, "Resource", i); i++ {
+			vswitchId := request.Params.Get(fmt.Sprintf("Resource.%d.ResourceId", i))
+			for _, r := range acl["Resources"].(fakeObject)["Resource"].([]fakeObject) {
+				if r["ResourceId"] == vswitchId {
+					r["Status"] = string(NetworkAclResourceUnbinding)
+				}
+			}
+		}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteNetworkAcl"] = func(request *fakeApiRequest) (interface{}, error) {
+		aclId := request.Params.Get("NetworkAclId")
+		acl, ok := s.networkAcls[aclId]
+		if !ok {
+			return nil, notFound(aclId)
+		}
+		if len(acl["Resources"].(fakeObject)["Resource"].([]fakeObject)) > 0 {
+			return nil, &fakeApiError{http.StatusBadRequest, DependencyViolation, "The network ACL has been associated with vswitches."}
+		}
+		delete(s.networkAcls, aclId)
+		return fakeObject{}, nil
+	}
+}
+
+// registerVpcIpv6Gateways registers the handlers of the IPv6 gateway and IPv6 address APIs. The IPv6 addresses are the
+// ones assigned to the network interfaces. The IPv6 gateways are Creating and the egress-only rules are Pending after
+// they are changed, and they are settled by the next describing, so that the waiters run.
+func (s *fakeApiServer) registerVpcIpv6Gateways() {
+	notFound := func(gatewayId string) error {
+		return fakeNotFoundError(InvalidIpv6GatewayIdNotFound, "IPv6 gateway", gatewayId)
+	}
+
+	s.handlers["VPC.CreateIpv6Gateway"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		vpc, ok := s.vpcs[params.Get("VpcId")]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", params.Get("VpcId"))
+		}
+		if vpc["Ipv6CidrBlock"] == nil {
+			return nil, &fakeApiError{http.StatusBadRequest, "IncorrectStatus.Vpc", "The VPC has not enabled IPv6."}
+		}
+		for _, gateway := range s.ipv6Gateways {
+			if gateway["VpcId"] == params.Get("VpcId") {
+				return nil, &fakeApiError{http.StatusBadRequest, "QuotaExceeded.Ipv6Gateway", "The VPC already has an IPv6 gateway."}
+			}
+		}
+		gatewayId := s.newId("ipv6gw")
+		s.ipv6Gateways[gatewayId] = fakeObject{
+			"Ipv6GatewayId": gatewayId,
+			"VpcId":         params.Get("VpcId"),
+			"RegionId":      string(fakeApiRegion),
+			"Status":        string(Creating),
+			"Spec":          params.Get("Spec"),
+			"Name":          params.Get("Name"),
+			"Description":   params.Get("Description"),
+			"EgressOnlyRules": fakeObject{
+				"Ipv6EgressOnlyRule": make([]fakeObject, 0),
+			},
+		}
+		return fakeObject{"Ipv6GatewayId": gatewayId}, nil
+	}
+
+	s.handlers["VPC.DescribeIpv6Gateways"] = func(request *fakeApiRequest) (interface{}, error) {
+		gateways := make([]fakeObject, 0)
+		for gatewayId, gateway := range s.ipv6Gateways {
+			if id := request.Params.Get("Ipv6GatewayId"); id != "" && id != gatewayId {
+				continue
+			}
+			response := fakeObject{}
+			for k, v := range gateway {
+				if k != "EgressOnlyRules" {
+					response[k] = v
+				}
+			}
+			gateways = append(gateways, response)
+			gateway["Status"] = string(Available)
+		}
+		return fakeObject{"Ipv6Gateways": fakeObject{"Ipv6Gateway": gateways}, "TotalCount": len(gateways)}, nil
+	}
+
+	s.handlers["VPC.ModifyIpv6GatewayAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		gatewayId := request.Params.Get("Ipv6GatewayId")
+		gateway, ok := s.ipv6Gateways[gatewayId]
+		if !ok {
+			return nil, notFound(gatewayId)
+		}
+		setIfPresent(gateway, request.Params, "Name", "Name")
+		setIfPresent(gateway, request.Params, "Description", "Description")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.ModifyIpv6GatewaySpec"] = func(request *fakeApiRequest) (interface{}, error) {
+		gatewayId := request.Params.Get("Ipv6GatewayId")
+		gateway, ok := s.ipv6Gateways[gatewayId]
+		if !ok {
+			return nil, notFound(gatewayId)
+		}
+		if gateway["Status"] != string(Available) {
+			return nil, &fakeApiError{http.StatusBadRequest, "IncorrectStatus.Ipv6Gateway", "The status of the IPv6 gateway is incorrect."}
+		}
+		setIfPresent(gateway, request.Params, "Spec", "Spec")
+		gateway["Status"] = string(Creating)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteIpv6Gateway"] = func(request *fakeApiRequest) (interface{}, error) {
+		gatewayId := request.Params.Get("Ipv6GatewayId")
+		gateway, ok := s.ipv6Gateways[gatewayId]
+		if !ok {
+			return nil, notFound(gatewayId)
+		}
+		if len(gateway["EgressOnlyRules"].(fakeObject)["Ipv6EgressOnlyRule"].([]fakeObject)) > 0 {
+			return nil, &fakeApiError{http.StatusBadRequest, DependencyViolation, "The IPv6 gateway has egress-only rules."}
+		}
+		delete(s.ipv6Gateways, gatewayId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.CreateIpv6EgressOnlyRule"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		gatewayId := params.Get("Ipv6GatewayId")
+		gateway, ok := s.ipv6Gateways[gatewayId]
+		if !ok {
+			return nil, notFound(gatewayId)
+		}
+		if params.Get("InstanceType") != Ipv6EgressRuleInstanceType {
+			return nil, &fakeApiError{http.StatusBadRequest, "InvalidParameter", "The specified InstanceType is invalid."}
+		}
+		address := s.fakeIpv6Address("", params.Get("InstanceId"))
+		if address == nil {
+			return nil, fakeNotFoundError("InvalidIpv6AddressId.NotFound", "IPv6 address", params.Get("InstanceId"))
+		}
+		if address["VpcId"] != gateway["VpcId"] {
+			return nil, &fakeApiError{http.StatusBadRequest, "InvalidParameter", "The IPv6 address and the IPv6 gateway are not in the same VPC."}
+		}
+		ruleId := s.newId("ipv6py")
+		rules := gateway["EgressOnlyRules"].(fakeObject)
+		rules["Ipv6EgressOnlyRule"] = append(rules["Ipv6EgressOnlyRule"].([]fakeObject), fakeObject{
+			"Ipv6EgressOnlyRuleId": ruleId,
+			"Name":                 params.Get("Name"),
+			"Description":          params.Get("Description"),
+			"InstanceId":           params.Get("InstanceId"),
+			"InstanceType":         Ipv6EgressRuleInstanceType,
+			"Status":               string(Pending),
+		})
+		return fakeObject{"Ipv6EgressOnlyRuleId": ruleId}, nil
+	}
+
+	s.handlers["VPC.DescribeIpv6EgressOnlyRules"] = func(request *fakeApiRequest) (interface{}, error) {
+		gatewayId := request.Params.Get("Ipv6GatewayId")
+		gateway, ok := s.ipv6Gateways[gatewayId]
+		if !ok {
+			return nil, notFound(gatewayId)
+		}
+		rules := make([]fakeObject, 0)
+		for _, rule := range gateway["EgressOnlyRules"].(fakeObject)["Ipv6EgressOnlyRule"].([]fakeObject) {
+			if id := request.Params.Get("Ipv6EgressOnlyRuleId"); id != "" && id != rule["Ipv6EgressOnlyRuleId"] {
+				continue
+			}
+			response := fakeObject{}
+			for k, v := range rule {
+				response[k] = v
+			}
+			rules = append(rules, response)
+			rule["Status"] = string(Available)
+		}
+		return fakeObject{"Ipv6EgressOnlyRules": fakeObject{"Ipv6EgressOnlyRule": rules}, "TotalCount": len(rules)}, nil
+	}
+
+	s.handlers["VPC.DeleteIpv6EgressOnlyRule"] = func(request *fakeApiRequest) (interface{}, error) {
+		ruleId := request.Params.Get("Ipv6EgressOnlyRuleId")
+		for _, gateway := range s.ipv6Gateways {
+			rules := gateway["EgressOnlyRules"].(fakeObject)
+			kept := make([]fakeObject, 0)
+			for _, rule := range rules["Ipv6EgressOnlyRule"].([]fakeObject) {
+				if rule["Ipv6EgressOnlyRuleId"] != ruleId {
+					kept = append(kept, rule)
+				}
+			}
+			if len(kept) < len(rules["Ipv6EgressOnlyRule"].([]fakeObject)) {
+				rules["Ipv6EgressOnlyRule"] = kept
+				return fakeObject{}, nil
+			}
+		}
+		return nil, fakeNotFoundError(InvalidIpv6EgressOnlyRuleIdNotFound, "IPv6 egress-only rule", ruleId)
+	}
+
+	s.handlers["VPC.DescribeIpv6Addresses"] = func(request *fakeApiRequest) (interface{}, error) {
+		addresses := make([]fakeObject, 0)
+		if address := s.fakeIpv6Address(request.Params.Get("Ipv6Address"), request.Params.Get("Ipv6AddressId")); address != nil {
+			addresses = append(addresses, address)
+		}
+		return fakeObject{"Ipv6Addresses": fakeObject{"Ipv6Address": addresses}, "TotalCount": len(addresses)}, nil
+	}
+}
+
+// fakeIpv6Address returns the IPv6 address assigned to a network interface by the address or by its ID, and it is nil
+// when the address is not found.
+func (s *fakeApiServer) fakeIpv6Address(address, addressId string) fakeObject {
+	for _, eni := range s.networkInterfaces {
+		for _, ip := range eni["Ipv6Sets"].(fakeObject)["Ipv6Set"].([]fakeObject) {
+			if (address != "" && ip["Ipv6Address"] == address) || (addressId != "" && ip["Ipv6AddressId"] == addressId) {
+				return fakeObject{
+					"Ipv6AddressId":        ip["Ipv6AddressId"],
+					"Ipv6Address":          ip["Ipv6Address"],
+					"VpcId":                eni["VpcId"],
+					"VSwitchId":            eni["VSwitchId"],
+					"AssociatedInstanceId": eni["NetworkInterfaceId"],
+					"Status":               string(Available),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// registerVpcFlowLogs registers the handlers of the flow log APIs. The flow logs are Activating after they are created,
+// activated or deactivated, and they are settled to the target status by the next describing, so that the waiters run.
+func (s *fakeApiServer) registerVpcFlowLogs() {
+	notFound := func(flowLogId string) error {
+		return fakeNotFoundError("InvalidFlowLogId.NotFound", "flow log", flowLogId)
+	}
+	settle := func(flowLogId string, target Status) (interface{}, error) {
+		flowLog, ok := s.flowLogs[flowLogId]
+		if !ok {
+			return nil, notFound(flowLogId)
+		}
+		if flowLog["Status"] == "Activating" {
+			return nil, &fakeApiError{http.StatusBadRequest, "IncorrectStatus.FlowLog", "The status of the flow log is incorrect."}
+		}
+		flowLog["Status"] = "Activating"
+		flowLog["TargetStatus"] = string(target)
+		return fakeObject{"Success": "true"}, nil
+	}
+
+	s.handlers["VPC.CreateFlowLog"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		resourceId := params.Get("ResourceId")
+		var exists bool
+		switch FlowLogResourceType(params.Get("ResourceType")) {
+		case FlowLogVpc:
+			_, exists = s.vpcs[resourceId]
+		case FlowLogVSwitch:
+			_, exists = s.vswitches[resourceId]
+		case FlowLogNetworkInterface:
+			_, exists = s.networkInterfaces[resourceId]
+		default:
+			return nil, &fakeApiError{http.StatusBadRequest, "InvalidParameter", "The specified ResourceType is invalid."}
+		}
+		if !exists {
+			return nil, fakeNotFoundError("InvalidResourceId.NotFound", "resource", resourceId)
+		}
+		flowLogId := s.newId("fl")
+		s.flowLogs[flowLogId] = fakeObject{
+			"FlowLogId":    flowLogId,
+			"FlowLogName":  params.Get("FlowLogName"),
+			"Description":  params.Get("Description"),
+			"ResourceType": params.Get("ResourceType"),
+			"ResourceId":   resourceId,
+			"TrafficType":  params.Get("TrafficType"),
+			"ProjectName":  params.Get("ProjectName"),
+			"LogStoreName": params.Get("LogStoreName"),
+			"Status":       "Activating",
+			"TargetStatus": string(Active),
+		}
+		return fakeObject{"FlowLogId": flowLogId, "Success": "true"}, nil
+	}
+
+	s.handlers["VPC.DescribeFlowLogs"] = func(request *fakeApiRequest) (interface{}, error) {
+		flowLogs := make([]fakeObject, 0)
+		for flowLogId, flowLog := range s.flowLogs {
+			if id := request.Params.Get("FlowLogId"); id != "" && id != flowLogId {
+				continue
+			}
+			response := fakeObject{}
+			for k, v := range flowLog {
+				if k != "TargetStatus" {
+					response[k] = v
+				}
+			}
+			flowLogs = append(flowLogs, response)
+			flowLog["Status"] = flowLog["TargetStatus"]
+		}
+		return fakeObject{"FlowLogs": fakeObject{"FlowLog": flowLogs}, "TotalCount": strconv.Itoa(len(flowLogs))}, nil
+	}
+
+	s.handlers["VPC.ModifyFlowLogAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		flowLogId := request.Params.Get("FlowLogId")
+		flowLog, ok := s.flowLogs[flowLogId]
+		if !ok {
+			return nil, notFound(flowLogId)
+		}
+		setIfPresent(flowLog, request.Params, "FlowLogName", "FlowLogName")
+		setIfPresent(flowLog, request.Params, "Description", "Description")
+		return fakeObject{"Success": "true"}, nil
+	}
+
+	s.handlers["VPC.ActiveFlowLog"] = func(request *fakeApiRequest) (interface{}, error) {
+		return settle(request.Params.Get("FlowLogId"), Active)
+	}
+
+	s.handlers["VPC.DeactiveFlowLog"] = func(request *fakeApiRequest) (interface{}, error) {
+		return settle(request.Params.Get("FlowLogId"), Inactive)
+	}
+
+	s.handlers["VPC.DeleteFlowLog"] = func(request *fakeApiRequest) (interface{}, error) {
+		flowLogId := request.Params.Get("FlowLogId")
+		if _, ok := s.flowLogs[flowLogId]; !ok {
+			return nil, notFound(flowLogId)
+		}
+		delete(s.flowLogs, flowLogId)
+		return fakeObject{"Success": "true"}, nil
+	}
+}
+
+// registerVpcRouteTables registers the handlers of the custom route tables and their route entries. The route entries are
+// Pending after they are created and Deleting after they are deleted, and they are settled by the next describing. Like
+// the cloud, no route entry of the router can be changed until all of its entries are Available.
+func (s *fakeApiServer) registerVpcRouteTables() {
+	notFound := func(routeTableId string) error {
+		return fakeNotFoundError("InvalidRouteTableId.NotFound", "route table", routeTableId)
+	}
+	entries := func(table fakeObject) []fakeObject {
+		return table["RouteEntrys"].(fakeObject)["RouteEntry"].([]fakeObject)
+	}
+	// routerBusy reports whether any route entry of the router is being changed
+	routerBusy := func(routerId interface{}) bool {
+		for _, table := range s.routeTables {
+			if table["RouterId"] != routerId {
+				continue
+			}
+			for _, entry := range entries(table) {
+				if entry["Status"] != string(Available) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	busyError := &fakeApiError{http.StatusBadRequest, IncorrectRouteEntryStatus, "Some route entries of the router are being changed."}
+
+	s.handlers["VPC.CreateRouteTable"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		vpc, ok := s.vpcs[params.Get("VpcId")]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", params.Get("VpcId"))
+		}
+		routeTableId := s.newId("vtb")
+		s.routeTables[routeTableId] = fakeObject{
+			"RouteTableId":   routeTableId,
+			"VpcId":          vpc["VpcId"],
+			"RouterId":       vpc["VRouterId"],
+			"RouterType":     string(VRouter),
+			"RouteTableType": RouteEntryCustom,
+			"RouteTableName": params.Get("RouteTableName"),
+			"Description":    params.Get("Description"),
+			"VSwitchIds":     fakeObject{"VSwitchId": make([]string, 0)},
+			"RouteEntrys": fakeObject{"RouteEntry": []fakeObject{{
+				"RouteTableId":         routeTableId,
+				"DestinationCidrBlock": "100.64.0.0/10",
+				"Type":                 "System",
+				"Status":               string(Available),
+				"NextHopType":          "local",
+				"NextHops":             fakeObject{"NextHop": make([]fakeObject, 0)},
+			}}},
+		}
+		return fakeObject{"RouteTableId": routeTableId}, nil
+	}
+
+	s.handlers["VPC.DescribeRouteTableList"] = func(request *fakeApiRequest) (interface{}, error) {
+		tables := make([]fakeObject, 0)
+		for routeTableId, table := range s.routeTables {
+			if id := request.Params.Get("RouteTableId"); id != "" && id != routeTableId {
+				continue
+			}
+			if id := request.Params.Get("VpcId"); id != "" && id != table["VpcId"] {
+				continue
+			}
+			response := fakeObject{}
+			for k, v := range table {
+				if k != "RouteEntrys" {
+					response[k] = v
+				}
+			}
+			tables = append(tables, response)
+		}
+		return fakeObject{"RouterTableList": fakeObject{"RouterTableListType": tables}, "TotalCount": len(tables)}, nil
+	}
+
+	s.handlers["VPC.DescribeRouteTables"] = func(request *fakeApiRequest) (interface{}, error) {
+		tables := make([]fakeObject, 0)
+		for routeTableId, table := range s.routeTables {
+			if id := request.Params.Get("RouteTableId"); id != "" && id != routeTableId {
+				continue
+			}
+			var routes []fakeObject
+			settled := make([]fakeObject, 0)
+			for _, entry := range entries(table) {
+				response := fakeObject{}
+				for k, v := range entry {
+					response[k] = v
+				}
+				routes = append(routes, response)
+				switch entry["Status"] {
+				case string(Deleting):
+				case string(Pending):
+					entry["Status"] = string(Available)
+					settled = append(settled, entry)
+				default:
+					settled = append(settled, entry)
+				}
+			}
+			table["RouteEntrys"] = fakeObject{"RouteEntry": settled}
+			tables = append(tables, fakeObject{
+				"RouteTableId":   routeTableId,
+				"VRouterId":      table["RouterId"],
+				"RouteTableType": table["RouteTableType"],
+				"VSwitchIds":     table["VSwitchIds"],
+				"RouteEntrys":    fakeObject{"RouteEntry": routes},
+			})
+		}
+		return fakeObject{"RouteTables": fakeObject{"RouteTable": tables}, "TotalCount": len(tables)}, nil
+	}
+
+	s.handlers["VPC.ModifyRouteTableAttributes"] = func(request *fakeApiRequest) (interface{}, error) {
+		routeTableId := request.Params.Get("RouteTableId")
+		table, ok := s.routeTables[routeTableId]
+		if !ok {
+			return nil, notFound(routeTableId)
+		}
+		setIfPresent(table, request.Params, "RouteTableName", "RouteTableName")
+		setIfPresent(table, request.Params, "Description", "Description")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteRouteTable"] = func(request *fakeApiRequest) (interface{}, error) {
+		routeTableId := request.Params.Get("RouteTableId")
+		table, ok := s.routeTables[routeTableId]
+		if !ok {
+			return nil, notFound(routeTableId)
+		}
+		for _, entry := range entries(table) {
+			if entry["Type"] == RouteEntryCustom {
+				return nil, &fakeApiError{http.StatusBadRequest, DependencyViolation, "The route table has custom route entries."}
+			}
+		}
+		delete(s.routeTables, routeTableId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.CreateRouteEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		routeTableId := params.Get("RouteTableId")
+		table, ok := s.routeTables[routeTableId]
+		if !ok {
+			return nil, notFound(routeTableId)
+		}
+		if routerBusy(table["RouterId"]) {
+			return nil, busyError
+		}
+		cidr := params.Get("DestinationCidrBlock")
+		for _, entry := range entries(table) {
+			if entry["DestinationCidrBlock"] == cidr {
+				return nil, &fakeApiError{http.StatusBadRequest, RouterEntryConflictDuplicated, "The route entry already exists."}
+			}
+		}
+		entry := fakeObject{
+			"RouteTableId":         routeTableId,
+			"DestinationCidrBlock": cidr,
+			"Type":                 RouteEntryCustom,
+			"Status":               string(Pending),
+		}
+		nextHops := make([]fakeObject, 0)
+		for i := 1; fakeHasListItem(params, "NextHopList", i); i++ {
+			key := func(name string) string {
+				return params.Get(fmt.Sprintf("NextHopList.%d.%s", i, name))
+			}
+			weight, _ := strconv.Atoi(key("Weight"))
+			nextHops = append(nextHops, fakeObject{"NextHopType": key("NextHopType"), "NextHopId": key("NextHopId"), "Weight": weight, "Enabled": 1})
+		}
+		switch {
+		case len(nextHops) > 1:
+			entry["NextHopType"] = "ECMP"
+		case params.Get("NextHopId") != "":
+			entry["NextHopType"] = params.Get("NextHopType")
+			entry["InstanceId"] = params.Get("NextHopId")
+			nextHops = append(nextHops, fakeObject{"NextHopType": params.Get("NextHopType"), "NextHopId": params.Get("NextHopId"), "Enabled": 1})
+		default:
+			return nil, &fakeApiError{http.StatusBadRequest, "InvalidParameter", "The next hop of the route entry is required."}
+		}
+		entry["NextHops"] = fakeObject{"NextHop": nextHops}
+		table["RouteEntrys"] = fakeObject{"RouteEntry": append(entries(table), entry)}
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteRouteEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		routeTableId := params.Get("RouteTableId")
+		table, ok := s.routeTables[routeTableId]
+		if !ok {
+			return nil, notFound(routeTableId)
+		}
+		if routerBusy(table["RouterId"]) {
+			return nil, busyError
+		}
+		for _, entry := range entries(table) {
+			if entry["Type"] != RouteEntryCustom || entry["DestinationCidrBlock"] != params.Get("DestinationCidrBlock") {
+				continue
+			}
+			if id := params.Get("NextHopId"); id != "" && id != entry["InstanceId"] {
+				continue
+			}
+			entry["Status"] = string(Deleting)
+			return fakeObject{}, nil
+		}
+		return nil, fakeNotFoundError(InvalidRouteEntryNotFound, "route entry", params.Get("DestinationCidrBlock"))
+	}
+}
+
+// registerVpcNatGateways registers the handlers of the NAT gateways and their DNAT and SNAT entries. The entries are
+// Pending after they are created or modified and Deleting after they are deleted, and they are settled when they are
+// described. The EIPs are not served, so any external IP or SNAT IP is accepted.
+func (s *fakeApiServer) registerVpcNatGateways() {
+	notFound := func(natGatewayId string) error {
+		return fakeNotFoundError(InvalidNatGatewayIdNotFound, "NAT gateway", natGatewayId)
+	}
+	// findEntry returns the index of the entry in its table, or -1 when the table has no such entry
+	findEntry := func(tableId, idKey, id string) int {
+		for i, entry := range s.natEntries[tableId] {
+			if entry[idKey] == id && entry["Status"] != string(Deleting) {
+				return i
+			}
+		}
+		return -1
+	}
+	// describeEntries returns a page of the entries of the table and settles them
+	describeEntries := func(params url.Values, tableId, idKey string) ([]fakeObject, int) {
+		var matched []fakeObject
+		for _, entry := range s.natEntries[tableId] {
+			if id := params.Get(idKey); id == "" || id == entry[idKey] {
+				matched = append(matched, entry)
+			}
+		}
+		pageSize, _ := strconv.Atoi(params.Get("PageSize"))
+		if pageSize <= 0 {
+			pageSize = 10
+		}
+		pageNumber, _ := strconv.Atoi(params.Get("PageNumber"))
+		if pageNumber <= 0 {
+			pageNumber = 1
+		}
+		page := make([]fakeObject, 0)
+		deleted := make(map[string]bool)
+		for i := (pageNumber - 1) * pageSize; i < len(matched) && i < pageNumber*pageSize; i++ {
+			response := fakeObject{}
+			for k, v := range matched[i] {
+				response[k] = v
+			}
+			page = append(page, response)
+			switch matched[i]["Status"] {
+			case string(Pending):
+				matched[i]["Status"] = string(Available)
+			case string(Deleting):
+				deleted[matched[i][idKey].(string)] = true
+			}
+		}
+		settled := make([]fakeObject, 0)
+		for _, entry := range s.natEntries[tableId] {
+			if !deleted[entry[idKey].(string)] {
+				settled = append(settled, entry)
+			}
+		}
+		s.natEntries[tableId] = settled
+		return page, len(matched)
+	}
+
+	s.handlers["VPC.CreateNatGateway"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		if _, ok := s.vpcs[params.Get("VpcId")]; !ok {
+			return nil, fakeNotFoundError(InvalidVpcIDNotFound, "VPC", params.Get("VpcId"))
+		}
+		natGatewayId := s.newId("ngw")
+		forwardTableId := s.newId("ftb")
+		snatTableId := s.newId("stb")
+		s.natGateways[natGatewayId] = fakeObject{
+			"NatGatewayId":        natGatewayId,
+			"RegionId":            string(fakeApiRegion),
+			"VpcId":               params.Get("VpcId"),
+			"Spec":                params.Get("Spec"),
+			"Name":                params.Get("Name"),
+			"Description":         params.Get("Description"),
+			"Status":              string(Available),
+			"ForwardTableIds":     fakeObject{"ForwardTableId": []string{forwardTableId}},
+			"SnatTableIds":        fakeObject{"SnatTableId": []string{snatTableId}},
+			"BandwidthPackageIds": fakeObject{"BandwidthPackageId": make([]string, 0)},
+		}
+		s.natEntries[forwardTableId] = make([]fakeObject, 0)
+		s.natEntries[snatTableId] = make([]fakeObject, 0)
+		return fakeObject{
+			"NatGatewayId":        natGatewayId,
+			"ForwardTableIds":     fakeObject{"ForwardTableId": []string{forwardTableId}},
+			"SnatTableIds":        fakeObject{"SnatTableId": []string{snatTableId}},
+			"BandwidthPackageIds": fakeObject{"BandwidthPackageId": make([]string, 0)},
+		}, nil
+	}
+
+	s.handlers["VPC.DescribeNatGateways"] = func(request *fakeApiRequest) (interface{}, error) {
+		gateways := make([]fakeObject, 0)
+		for natGatewayId, gateway := range s.natGateways {
+			if id := request.Params.Get("NatGatewayId"); id != "" && id != natGatewayId {
+				continue
+			}
+			gateways = append(gateways, gateway)
+		}
+		return fakeObject{"NatGateways": fakeObject{"NatGateway": gateways}, "TotalCount": len(gateways)}, nil
+	}
+
+	s.handlers["VPC.ModifyNatGatewayAttribute"] = func(request *fakeApiRequest) (interface{}, error) {
+		natGatewayId := request.Params.Get("NatGatewayId")
+		gateway, ok := s.natGateways[natGatewayId]
+		if !ok {
+			return nil, notFound(natGatewayId)
+		}
+		setIfPresent(gateway, request.Params, "Name", "Name")
+		setIfPresent(gateway, request.Params, "Description", "Description")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.ModifyNatGatewaySpec"] = func(request *fakeApiRequest) (interface{}, error) {
+		natGatewayId := request.Params.Get("NatGatewayId")
+		gateway, ok := s.natGateways[natGatewayId]
+		if !ok {
+			return nil, notFound(natGatewayId)
+		}
+		setIfPresent(gateway, request.Params, "Spec", "Spec")
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DescribeBandwidthPackages"] = func(request *fakeApiRequest) (interface{}, error) {
+		return fakeObject{"BandwidthPackages": fakeObject{"BandwidthPackage": make([]fakeObject, 0)}, "TotalCount": 0}, nil
+	}
+
+	s.handlers["VPC.DeleteNatGateway"] = func(request *fakeApiRequest) (interface{}, error) {
+		natGatewayId := request.Params.Get("NatGatewayId")
+		gateway, ok := s.natGateways[natGatewayId]
+		if !ok {
+			return nil, notFound(natGatewayId)
+		}
+		tableIds := append(gateway["ForwardTableIds"].(fakeObject)["ForwardTableId"].([]string), gateway["SnatTableIds"].(fakeObject)["SnatTableId"].([]string)...)
+		for _, tableId := range tableIds {
+			if len(s.natEntries[tableId]) > 0 {
+				return nil, &fakeApiError{http.StatusBadRequest, DependencyViolation, "The NAT gateway has DNAT or SNAT entries."}
+			}
+		}
+		for _, tableId := range tableIds {
+			delete(s.natEntries, tableId)
+		}
+		delete(s.natGateways, natGatewayId)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.CreateForwardEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		tableId := params.Get("ForwardTableId")
+		entries, ok := s.natEntries[tableId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidForwardTableIdNotFound, "forward table", tableId)
+		}
+		for _, entry := range entries {
+			if entry["ExternalIp"] == params.Get("ExternalIp") && entry["ExternalPort"] == params.Get("ExternalPort") &&
+				entry["IpProtocol"] == params.Get("IpProtocol") && entry["Status"] != string(Deleting) {
+				return nil, &fakeApiError{http.StatusBadRequest, "Forbidden.PortOccupied", "The external port is occupied."}
+			}
+		}
+		forwardEntryId := s.newId("fwd")
+		s.natEntries[tableId] = append(entries, fakeObject{
+			"ForwardTableId":   tableId,
+			"ForwardEntryId":   forwardEntryId,
+			"ForwardEntryName": params.Get("ForwardEntryName"),
+			"ExternalIp":       params.Get("ExternalIp"),
+			"ExternalPort":     params.Get("ExternalPort"),
+			"IpProtocol":       params.Get("IpProtocol"),
+			"InternalIp":       params.Get("InternalIp"),
+			"InternalPort":     params.Get("InternalPort"),
+			"Status":           string(Pending),
+		})
+		return fakeObject{"ForwardEntryId": forwardEntryId}, nil
+	}
+
+	s.handlers["VPC.DescribeForwardTableEntries"] = func(request *fakeApiRequest) (interface{}, error) {
+		tableId := request.Params.Get("ForwardTableId")
+		if _, ok := s.natEntries[tableId]; !ok {
+			return nil, fakeNotFoundError(InvalidForwardTableIdNotFound, "forward table", tableId)
+		}
+		entries, total := describeEntries(request.Params, tableId, "ForwardEntryId")
+		return fakeObject{"ForwardTableEntries": fakeObject{"ForwardTableEntry": entries}, "TotalCount": total}, nil
+	}
+
+	s.handlers["VPC.ModifyForwardEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		tableId := params.Get("ForwardTableId")
+		i := findEntry(tableId, "ForwardEntryId", params.Get("ForwardEntryId"))
+		if i < 0 {
+			return nil, fakeNotFoundError(InvalidForwardEntryIdNotFound, "forward entry", params.Get("ForwardEntryId"))
+		}
+		entry := s.natEntries[tableId][i]
+		for _, key := range []string{"ForwardEntryName", "ExternalIp", "ExternalPort", "IpProtocol", "InternalIp", "InternalPort"} {
+			setIfPresent(entry, params, key, key)
+		}
+		entry["Status"] = string(Pending)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteForwardEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		tableId := params.Get("ForwardTableId")
+		i := findEntry(tableId, "ForwardEntryId", params.Get("ForwardEntryId"))
+		if i < 0 {
+			return nil, fakeNotFoundError(InvalidForwardEntryIdNotFound, "forward entry", params.Get("ForwardEntryId"))
+		}
+		s.natEntries[tableId][i]["Status"] = string(Deleting)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.CreateSnatEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		tableId := params.Get("SnatTableId")
+		entries, ok := s.natEntries[tableId]
+		if !ok {
+			return nil, fakeNotFoundError(InvalidSnatTableIdNotFound, "SNAT table", tableId)
+		}
+		cidr := params.Get("SourceCIDR")
+		if vswitchId := params.Get("SourceVSwitchId"); vswitchId != "" {
+			vswitch, ok := s.vswitches[vswitchId]
+			if !ok {
+				return nil, fakeNotFoundError(InvalidVswitchIDNotFound, "VSwitch", vswitchId)
+			}
+			cidr = vswitch["CidrBlock"].(string)
+		} else if cidr == "" {
+			return nil, &fakeApiError{http.StatusBadRequest, "MissingParameter", "The source of the SNAT entry is required."}
+		}
+		for _, entry := range entries {
+			if entry["SourceCIDR"] == cidr && entry["Status"] != string(Deleting) {
+				return nil, &fakeApiError{http.StatusBadRequest, "Forbidden.SourceOccupied", "The source of the SNAT entry is occupied."}
+			}
+		}
+		snatEntryId := s.newId("snat")
+		s.natEntries[tableId] = append(entries, fakeObject{
+			"SnatTableId":     tableId,
+			"SnatEntryId":     snatEntryId,
+			"SnatEntryName":   params.Get("SnatEntryName"),
+			"SourceVSwitchId": params.Get("SourceVSwitchId"),
+			"SourceCIDR":      cidr,
+			"SnatIp":          params.Get("SnatIp"),
+			"Status":          string(Pending),
+		})
+		return fakeObject{"SnatEntryId": snatEntryId}, nil
+	}
+
+	s.handlers["VPC.DescribeSnatTableEntries"] = func(request *fakeApiRequest) (interface{}, error) {
+		tableId := request.Params.Get("SnatTableId")
+		if _, ok := s.natEntries[tableId]; !ok {
+			return nil, fakeNotFoundError(InvalidSnatTableIdNotFound, "SNAT table", tableId)
+		}
+		entries, total := describeEntries(request.Params, tableId, "SnatEntryId")
+		return fakeObject{"SnatTableEntries": fakeObject{"SnatTableEntry": entries}, "TotalCount": total}, nil
+	}
+
+	s.handlers["VPC.ModifySnatEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		tableId := params.Get("SnatTableId")
+		i := findEntry(tableId, "SnatEntryId", params.Get("SnatEntryId"))
+		if i < 0 {
+			return nil, fakeNotFoundError(InvalidSnatEntryIdNotFound, "SNAT entry", params.Get("SnatEntryId"))
+		}
+		entry := s.natEntries[tableId][i]
+		setIfPresent(entry, params, "SnatEntryName", "SnatEntryName")
+		setIfPresent(entry, params, "SnatIp", "SnatIp")
+		entry["Status"] = string(Pending)
+		return fakeObject{}, nil
+	}
+
+	s.handlers["VPC.DeleteSnatEntry"] = func(request *fakeApiRequest) (interface{}, error) {
+		params := request.Params
+		tableId := params.Get("SnatTableId")
+		i := findEntry(tableId, "SnatEntryId", params.Get("SnatEntryId"))
+		if i < 0 {
+			return nil, fakeNotFoundError(InvalidSnatEntryIdNotFound, "SNAT entry", params.Get("SnatEntryId"))
+		}
+		s.natEntries[tableId][i]["Status"] = string(Deleting)
+		return fakeObject{}, nil
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/denverdino/aliyungo/common"
 	"github.com/google/uuid"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
 type InstanceNetWork string
@@ -74,6 +75,7 @@ const (
 	Unavailable = Status("Unavailable")
 	Modifying   = Status("Modifying")
 	Deleting    = Status("Deleting")
+	Deleted     = Status("Deleted")
 	Starting    = Status("Starting")
 	Stopping    = Status("Stopping")
 	Stopped     = Status("Stopped")
@@ -114,6 +116,34 @@ const (
 	Public  = IPType("Public")
 )
 
+type NetworkInterfaceType string
+
+const (
+	NetworkInterfacePrimary   = NetworkInterfaceType("Primary")
+	NetworkInterfaceSecondary = NetworkInterfaceType("Secondary")
+)
+
+// The action taken on the instances when the dedicated host is under maintenance
+type ActionOnMaintenance string
+
+const (
+	MaintenanceMigrate = ActionOnMaintenance("Migrate")
+	MaintenanceStop    = ActionOnMaintenance("Stop")
+)
+
+type StoppedMode string
+
+const (
+	KeepCharging = StoppedMode("KeepCharging")
+	StopCharging = StoppedMode("StopCharging")
+)
+
+type DeploymentSetStrategy string
+
+const (
+	DeploymentSetAvailability = DeploymentSetStrategy("Availability")
+)
+
 type ResourceType string
 
 const (
@@ -158,6 +188,10 @@ const DefaultIntervalMedium = 10
 
 const DefaultIntervalLong = 20
 
+// waitIntervalUnit is the unit of the intervals which the waiters sleep between polls. It is a variable so that the unit
+// tests against the fake API server can poll without really waiting.
+var waitIntervalUnit = time.Second
+
 const (
 	PageSizeSmall  = 10
 	PageSizeMedium = 20
@@ -284,6 +318,15 @@ const (
 	TagResourceDisk          = TagResourceType("disk")
 	TagResourceSecurityGroup = TagResourceType("securitygroup")
 	TagResourceEni           = TagResourceType("eni")
+	TagResourceDedicatedHost = TagResourceType("ddh")
+
+	// The resource types of TagResources, UntagResources and ListTagResources
+	TagResourceVpc             = TagResourceType("VPC")
+	TagResourceVSwitch         = TagResourceType("VSWITCH")
+	TagResourceEip             = TagResourceType("EIP")
+	TagResourceNatGateway      = TagResourceType("NATGATEWAY")
+	TagResourceDBInstance      = TagResourceType("INSTANCE")
+	TagResourceKVStoreInstance = TagResourceType("INSTANCE")
 )
 
 type KubernetesNodeType string
@@ -397,26 +440,33 @@ func (a *Invoker) AddCatcher(catcher Catcher) {
 	a.catchers = append(a.catchers, &catcher)
 }
 
+// Run invokes f and retries it with exponential backoff and jitter when it failed with the error of a catcher.
+// The RetryWaitSeconds of the catcher is the base delay, and RetryCount limits the number of attempts.
 func (a *Invoker) Run(f func() error) error {
-	err := f()
-
-	if err == nil {
-		return nil
-	}
-
-	for _, catcher := range a.catchers {
-		if IsExceptedErrors(err, []string{catcher.Reason}) {
-			catcher.RetryCount--
+	attempts := make(map[*Catcher]int)
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
 
-			if catcher.RetryCount <= 0 {
-				return fmt.Errorf("Retry timeout and got an error: %#v.", err)
-			} else {
-				time.Sleep(time.Duration(catcher.RetryWaitSeconds) * time.Second)
-				return a.Run(f)
+		var catcher *Catcher
+		for _, c := range a.catchers {
+			if IsExceptedErrors(err, []string{c.Reason}) {
+				catcher = c
+				break
 			}
 		}
+		if catcher == nil {
+			return err
+		}
+
+		attempts[catcher]++
+		if attempts[catcher] >= catcher.RetryCount {
+			return fmt.Errorf("Retry timeout and got an error: %#v.", err)
+		}
+		time.Sleep(connectivity.RetryDelay(time.Duration(catcher.RetryWaitSeconds)*time.Second, connectivity.DefaultRetryMaxDelay, attempts[catcher]-1))
 	}
-	return err
 }
 
 func buildClientToken(prefix string) string {
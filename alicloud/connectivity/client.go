@@ -2,6 +2,7 @@ package connectivity
 
 import (
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/endpoints"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/resource"
@@ -34,7 +35,9 @@ import (
 	"github.com/denverdino/aliyungo/cs"
 	"github.com/dxh031/ali_mns"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/valyala/fasthttp"
 
+	"crypto/tls"
 	"fmt"
 	"log"
 	"net/http"
@@ -52,40 +55,39 @@ type AliyunClient struct {
 	Region   Region
 	RegionId string
 	//In order to build ots table client, add accesskey and secretkey in aliyunclient temporarily.
-	AccessKey                    string
-	SecretKey                    string
-	SecurityToken                string
-	OtsInstanceName              string
-	accountIdMutex               sync.RWMutex
-	config                       *Config
-	accountId                    string
-	ecsconn                      *ecs.Client
-	essconn                      *ess.Client
-	rdsconn                      *rds.Client
-	vpcconn                      *vpc.Client
-	slbconn                      *slb.Client
-	ossconn                      *oss.Client
-	dnsconn                      *alidns.Client
-	ramconn                      *ram.Client
-	csconn                       *cs.Client
-	cdnconn                      *cdn.CdnClient
-	kmsconn                      *kms.Client
-	otsconn                      *ots.Client
-	cmsconn                      *cms.Client
-	logconn                      *sls.Client
-	fcconn                       *fc.Client
-	cenconn                      *cbn.Client
-	pvtzconn                     *pvtz.Client
-	ddsconn                      *dds.Client
-	stsconn                      *sts.Client
-	rkvconn                      *r_kvstore.Client
-	dhconn                       *datahub.DataHub
-	mnsconn                      *ali_mns.MNSClient
-	cloudapiconn                 *cloudapi.Client
-	tablestoreconnByInstanceName map[string]*tablestore.TableStoreClient
-	csprojectconnByKey           map[string]*cs.ProjectClient
-	drdsconn                     *drds.Client
-	elasticsearchconn            *elasticsearch.Client
+	AccessKey       string
+	SecretKey       string
+	SecurityToken   string
+	OtsInstanceName string
+	accountIdMutex  sync.RWMutex
+	config          *Config
+	accountId       string
+
+	// productClients holds the lazily initialized clients keyed by product or by product and instance.
+	productClients      map[string]*productClient
+	productLimiters     map[ServiceCode]chan struct{}
+	productClientsMutex sync.Mutex
+
+	// credentialMutex guards the assumed role credential and its version.
+	credentialMutex      sync.RWMutex
+	credentialVersion    int
+	assumeRoleExpiration time.Time
+
+	// The tags applied to every taggable resource, and the tags managed outside of Terraform which are ignored.
+	DefaultTags          map[string]string
+	IgnoreTagKeys        []string
+	IgnoreTagKeyPrefixes []string
+
+	// vcr records or replays the API requests when the config enables VcrMode.
+	vcr *vcrRecorder
+}
+
+// productClient holds a product client which is initialized at the first calling.
+// The client is rebuilt when the credential it was built with has been refreshed.
+type productClient struct {
+	mutex             sync.Mutex
+	conn              interface{}
+	credentialVersion int
 }
 
 type ApiVersion string
@@ -94,6 +96,9 @@ const (
 	ApiVersion20140526 = ApiVersion("2014-05-26")
 	ApiVersion20160815 = ApiVersion("2016-08-15")
 	ApiVersion20140515 = ApiVersion("2014-05-15")
+	ApiVersion20160428 = ApiVersion("2016-04-28")
+	ApiVersion20140815 = ApiVersion("2014-08-15")
+	ApiVersion20150101 = ApiVersion("2015-01-01")
 )
 
 const businessInfoKey = "Terraform"
@@ -103,7 +108,15 @@ const DefaultClientRetryCountMedium = 10
 const DefaultClientRetryCountLarge = 15
 const Terraform = "HashiCorp-Terraform"
 
-var goSdkMutex = sync.RWMutex{} // The Go SDK is not thread-safe
+// The default maximum number of in-flight requests for each product
+const DefaultMaxConcurrentRequests = 10
+
+const DefaultAssumeRoleSessionName = "terraform"
+const DefaultAssumeRoleSessionExpiration = 3600
+
+// The assumed role credential will be refreshed when it expires within the following duration.
+const assumeRoleRefreshAdvance = time.Duration(5) * time.Minute
+
 var version = strings.TrimSuffix(terraform.VersionString(), "-dev")
 
 // Client for AliyunClient
@@ -113,34 +126,44 @@ func (c *Config) Client() (*AliyunClient, error) {
 		return nil, err
 	}
 
-	return &AliyunClient{
-		config:                       c,
-		Region:                       c.Region,
-		RegionId:                     c.RegionId,
-		AccessKey:                    c.AccessKey,
-		SecretKey:                    c.SecretKey,
-		SecurityToken:                c.SecurityToken,
-		OtsInstanceName:              c.OtsInstanceName,
-		accountId:                    c.AccountId,
-		tablestoreconnByInstanceName: make(map[string]*tablestore.TableStoreClient),
-		csprojectconnByKey:           make(map[string]*cs.ProjectClient),
-	}, nil
-}
+	client := &AliyunClient{
+		config:          c,
+		Region:          c.Region,
+		RegionId:        c.RegionId,
+		AccessKey:       c.AccessKey,
+		SecretKey:       c.SecretKey,
+		SecurityToken:   c.SecurityToken,
+		OtsInstanceName: c.OtsInstanceName,
+		accountId:       c.AccountId,
+		productClients:  make(map[string]*productClient),
+		productLimiters: make(map[ServiceCode]chan struct{}),
+
+		DefaultTags:          c.DefaultTags,
+		IgnoreTagKeys:        c.IgnoreTagKeys,
+		IgnoreTagKeyPrefixes: c.IgnoreTagKeyPrefixes,
+	}
 
-func (client *AliyunClient) WithEcsClient(do func(*ecs.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	client.addEndpointMappings()
 
-	// Initialize the ECS client if necessary
-	if client.ecsconn == nil {
-		endpoint := client.config.EcsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ECSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ECSCode), endpoint)
+	if c.VcrMode != "" {
+		vcr, err := startVcr(c.VcrMode, c.VcrCassette, c.Insecure, c.AccessKey, c.SecretKey, c.SecurityToken)
+		if err != nil {
+			return nil, err
 		}
-		ecsconn, err := ecs.NewClientWithOptions(client.config.RegionId, client.getSdkConfig().WithTimeout(time.Duration(60)*time.Second), client.config.getAuthCredential(true))
+		client.vcr = vcr
+	}
+
+	if err := client.refreshAssumeRoleCredential(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (client *AliyunClient) WithEcsClient(do func(*ecs.Client) (interface{}, error)) (interface{}, error) {
+	// Initialize the ECS client at the first calling
+	return client.withProductClient(ECSCode, string(ECSCode), func() (interface{}, error) {
+		ecsconn, err := ecs.NewClientWithOptions(client.config.RegionId, client.getSdkConfig().WithTimeout(time.Duration(60)*time.Second), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the ECS client: %#v", err)
 		}
@@ -149,143 +172,90 @@ func (client *AliyunClient) WithEcsClient(do func(*ecs.Client) (interface{}, err
 			return nil, err
 		}
 		ecsconn.AppendUserAgent(Terraform, version)
-		client.ecsconn = ecsconn
-	}
-
-	return do(client.ecsconn)
+		return ecsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*ecs.Client))
+	})
 }
 
 func (client *AliyunClient) WithRdsClient(do func(*rds.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the RDS client if necessary
-	if client.rdsconn == nil {
-		endpoint := client.config.RdsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, RDSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(RDSCode), endpoint)
-		}
-		rdsconn, err := rds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the RDS client at the first calling
+	return client.withProductClient(RDSCode, string(RDSCode), func() (interface{}, error) {
+		rdsconn, err := rds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the RDS client: %#v", err)
 		}
 
 		rdsconn.AppendUserAgent(Terraform, version)
-		client.rdsconn = rdsconn
-	}
-
-	return do(client.rdsconn)
+		return rdsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*rds.Client))
+	})
 }
 
 func (client *AliyunClient) WithSlbClient(do func(*slb.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the SLB client if necessary
-	if client.slbconn == nil {
-		endpoint := client.config.SlbEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, SLBCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(SLBCode), endpoint)
-		}
-		slbconn, err := slb.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the SLB client at the first calling
+	return client.withProductClient(SLBCode, string(SLBCode), func() (interface{}, error) {
+		slbconn, err := slb.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the SLB client: %#v", err)
 		}
 
 		slbconn.AppendUserAgent(Terraform, version)
-		client.slbconn = slbconn
-	}
-
-	return do(client.slbconn)
+		return slbconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*slb.Client))
+	})
 }
 
 func (client *AliyunClient) WithVpcClient(do func(*vpc.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the VPC client if necessary
-	if client.vpcconn == nil {
-		endpoint := client.config.VpcEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, VPCCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(VPCCode), endpoint)
-		}
-		vpcconn, err := vpc.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the VPC client at the first calling
+	return client.withProductClient(VPCCode, string(VPCCode), func() (interface{}, error) {
+		vpcconn, err := vpc.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the VPC client: %#v", err)
 		}
 
 		vpcconn.AppendUserAgent(Terraform, version)
-		client.vpcconn = vpcconn
-	}
-
-	return do(client.vpcconn)
+		return vpcconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*vpc.Client))
+	})
 }
 
 func (client *AliyunClient) WithCenClient(do func(*cbn.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the CEN client if necessary
-	if client.cenconn == nil {
-		endpoint := client.config.CenEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, CENCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(CENCode), endpoint)
-		}
-		cenconn, err := cbn.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the CEN client at the first calling
+	return client.withProductClient(CENCode, string(CENCode), func() (interface{}, error) {
+		cenconn, err := cbn.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the CEN client: %#v", err)
 		}
 
 		cenconn.AppendUserAgent(Terraform, version)
-		client.cenconn = cenconn
-	}
-
-	return do(client.cenconn)
+		return cenconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*cbn.Client))
+	})
 }
 
 func (client *AliyunClient) WithEssClient(do func(*ess.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the ESS client if necessary
-	if client.essconn == nil {
-		endpoint := client.config.EssEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ESSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ESSCode), endpoint)
-		}
-		essconn, err := ess.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the ESS client at the first calling
+	return client.withProductClient(ESSCode, string(ESSCode), func() (interface{}, error) {
+		essconn, err := ess.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the ESS client: %#v", err)
 		}
 
 		essconn.AppendUserAgent(Terraform, version)
-		client.essconn = essconn
-	}
-
-	return do(client.essconn)
+		return essconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*ess.Client))
+	})
 }
 
 func (client *AliyunClient) WithOssClient(do func(*oss.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the OSS client if necessary
-	if client.ossconn == nil {
+	// Initialize the OSS client at the first calling
+	return client.withProductClient(OSSCode, string(OSSCode), func() (interface{}, error) {
 		schma := "https"
 		endpoint := client.config.OssEndpoint
 		if endpoint == "" {
@@ -314,14 +284,18 @@ func (client *AliyunClient) WithOssClient(do func(*oss.Client) (interface{}, err
 		}
 
 		log.Printf("[DEBUG] Instantiate OSS client using endpoint: %#v", endpoint)
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
 		if err != nil {
 			return nil, err
 		}
 		clientOptions := []oss.ClientOption{oss.UserAgent(client.getUserAgent()),
 			oss.SecurityToken(securityToken)}
 		proxyUrl := client.getHttpProxyUrl()
-		if proxyUrl != nil {
+		if client.vcr != nil {
+			// The OSS client does not support a custom transport, so its requests are sent to the recorder as a proxy.
+			endpoint = fmt.Sprintf("http://%s", strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://"))
+			clientOptions = append(clientOptions, oss.Proxy(client.vcr.ProxyURL()))
+		} else if proxyUrl != nil {
 			clientOptions = append(clientOptions, oss.Proxy(proxyUrl.String()))
 		}
 
@@ -330,15 +304,15 @@ func (client *AliyunClient) WithOssClient(do func(*oss.Client) (interface{}, err
 			return nil, fmt.Errorf("unable to initialize the OSS client: %#v", err)
 		}
 
-		client.ossconn = ossconn
-	}
-
-	return do(client.ossconn)
+		return ossconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*oss.Client))
+	})
 }
 
 func (client *AliyunClient) WithOssBucketByName(bucketName string, do func(*oss.Bucket) (interface{}, error)) (interface{}, error) {
 	return client.WithOssClient(func(ossClient *oss.Client) (interface{}, error) {
-		bucket, err := client.ossconn.Bucket(bucketName)
+		bucket, err := ossClient.Bucket(bucketName)
 		if err != nil {
 			return nil, fmt.Errorf("unable to get the bucket %s: %#v", bucketName, err)
 		}
@@ -347,65 +321,39 @@ func (client *AliyunClient) WithOssBucketByName(bucketName string, do func(*oss.
 }
 
 func (client *AliyunClient) WithDnsClient(do func(*alidns.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	// Initialize the DNS client at the first calling
+	return client.withProductClient(DNSCode, string(DNSCode), func() (interface{}, error) {
 
-	// Initialize the DNS client if necessary
-	if client.dnsconn == nil {
-		endpoint := client.config.DnsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, DNSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(DNSCode), endpoint)
-		}
-
-		dnsconn, err := alidns.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+		dnsconn, err := alidns.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the DNS client: %#v", err)
 		}
 		dnsconn.AppendUserAgent(Terraform, version)
-		client.dnsconn = dnsconn
-	}
-
-	return do(client.dnsconn)
+		return dnsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*alidns.Client))
+	})
 }
 
 func (client *AliyunClient) WithRamClient(do func(*ram.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	// Initialize the RAM client at the first calling
+	return client.withProductClient(RAMCode, string(RAMCode), func() (interface{}, error) {
 
-	// Initialize the RAM client if necessary
-	if client.ramconn == nil {
-		endpoint := client.config.RamEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, RAMCode)
-		}
-		if strings.HasPrefix(endpoint, "http") {
-			endpoint = fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "http://"))
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(RAMCode), endpoint)
-		}
-
-		ramconn, err := ram.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+		ramconn, err := ram.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the RAM client: %#v", err)
 		}
 		ramconn.AppendUserAgent(Terraform, version)
-		client.ramconn = ramconn
-	}
-
-	return do(client.ramconn)
+		return ramconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*ram.Client))
+	})
 }
 
 func (client *AliyunClient) WithCsClient(do func(*cs.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the CS client if necessary
-	if client.csconn == nil {
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+	// Initialize the CS client at the first calling
+	return client.withProductClient(CONTAINCode, string(CONTAINCode), func() (interface{}, error) {
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
 		if err != nil {
 			return nil, err
 		}
@@ -421,19 +369,16 @@ func (client *AliyunClient) WithCsClient(do func(*cs.Client) (interface{}, error
 			}
 			csconn.SetEndpoint(endpoint)
 		}
-		client.csconn = csconn
-	}
-
-	return do(client.csconn)
+		return csconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*cs.Client))
+	})
 }
 
 func (client *AliyunClient) WithCdnClient(do func(*cdn.CdnClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the CDN client if necessary
-	if client.cdnconn == nil {
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+	// Initialize the CDN client at the first calling
+	return client.withProductClient(CDNCode, string(CDNCode), func() (interface{}, error) {
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
 		if err != nil {
 			return nil, err
 		}
@@ -448,137 +393,280 @@ func (client *AliyunClient) WithCdnClient(do func(*cdn.CdnClient) (interface{},
 		if endpoint != "" && !strings.HasPrefix(endpoint, "http") {
 			cdnconn.SetEndpoint(fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "://")))
 		}
-		client.cdnconn = cdnconn
-	}
-
-	return do(client.cdnconn)
+		return cdnconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*cdn.CdnClient))
+	})
 }
 
 func (client *AliyunClient) WithKmsClient(do func(*kms.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the KMS client if necessary
-	if client.kmsconn == nil {
-
-		endpoint := client.config.KmsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, KMSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(KMSCode), endpoint)
-		}
-		kmsconn, err := kms.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the KMS client at the first calling
+	return client.withProductClient(KMSCode, string(KMSCode), func() (interface{}, error) {
+		kmsconn, err := kms.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the kms client: %#v", err)
 		}
 		kmsconn.AppendUserAgent(Terraform, version)
-		client.kmsconn = kmsconn
-	}
-	return do(client.kmsconn)
+		return kmsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*kms.Client))
+	})
 }
 
 func (client *AliyunClient) WithOtsClient(do func(*ots.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the OTS client if necessary
-	if client.otsconn == nil {
-		endpoint := client.config.OtsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, OTSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(OTSCode), endpoint)
-		}
-		otsconn, err := ots.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the OTS client at the first calling
+	return client.withProductClient(OTSCode, string(OTSCode), func() (interface{}, error) {
+		otsconn, err := ots.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the OTS client: %#v", err)
 		}
 
 		otsconn.AppendUserAgent(Terraform, version)
-		client.otsconn = otsconn
-	}
-
-	return do(client.otsconn)
+		return otsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*ots.Client))
+	})
 }
 
 func (client *AliyunClient) WithCmsClient(do func(*cms.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the CMS client if necessary
-	if client.cmsconn == nil {
-		cmsconn, err := cms.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(false))
+	// Initialize the CMS client at the first calling
+	return client.withProductClient(CMSCode, string(CMSCode), func() (interface{}, error) {
+		cmsconn, err := cms.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(false))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the CMS client: %#v", err)
 		}
 
 		cmsconn.AppendUserAgent(Terraform, version)
-		client.cmsconn = cmsconn
-	}
-
-	return do(client.cmsconn)
+		return cmsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*cms.Client))
+	})
 }
 
 func (client *AliyunClient) WithPvtzClient(do func(*pvtz.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+	// Initialize the PVTZ client at the first calling
+	return client.withProductClient(PVTZCode, string(PVTZCode), func() (interface{}, error) {
+		pvtzconn, err := pvtz.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize the PVTZ client: %#v", err)
+		}
 
-	// Initialize the PVTZ client if necessary
-	if client.pvtzconn == nil {
-		endpoint := client.config.PvtzEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, PVTZCode)
+		pvtzconn.AppendUserAgent(Terraform, version)
+		return pvtzconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*pvtz.Client))
+	})
+}
+
+func (client *AliyunClient) WithStsClient(do func(*sts.Client) (interface{}, error)) (interface{}, error) {
+	// Initialize the STS client at the first calling
+	// The STS client always uses the credential configured in the provider block because it is used to assume role.
+	return client.withProductClient(STSCode, string(STSCode), func() (interface{}, error) {
+		stsconn, err := sts.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getSourceAuthCredential(true))
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize the STS client: %#v", err)
 		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(PVTZCode), endpoint)
-		} else {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(PVTZCode), "pvtz.aliyuncs.com")
+
+		stsconn.AppendUserAgent(Terraform, version)
+		return stsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*sts.Client))
+	})
+}
+
+// withProductClient invokes do with the client identified by key, and the client is built by build if necessary.
+// The product clients can be used concurrently, and the number of in-flight requests of each product is
+// limited by the max concurrent requests settings. The requests throttled by the product are retried with backoff.
+func (client *AliyunClient) withProductClient(code ServiceCode, key string, build func() (interface{}, error), do func(interface{}) (interface{}, error)) (interface{}, error) {
+	if code != STSCode {
+		if err := client.refreshAssumeRoleCredential(); err != nil {
+			return nil, err
 		}
-		pvtzconn, err := pvtz.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	}
+	client.credentialMutex.RLock()
+	credentialVersion := client.credentialVersion
+	client.credentialMutex.RUnlock()
+
+	holder, limiter := client.getProductClient(code, key)
+	holder.mutex.Lock()
+	if holder.conn == nil || holder.credentialVersion != credentialVersion {
+		conn, err := build()
 		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the PVTZ client: %#v", err)
+			holder.mutex.Unlock()
+			return nil, err
 		}
+		holder.conn = conn
+		holder.credentialVersion = credentialVersion
+	}
+	conn := holder.conn
+	holder.mutex.Unlock()
 
-		pvtzconn.AppendUserAgent(Terraform, version)
-		client.pvtzconn = pvtzconn
+	return client.withRetry(code, func() (interface{}, error) {
+		if limiter != nil {
+			limiter <- struct{}{}
+			defer func() { <-limiter }()
+		}
+		return do(conn)
+	})
+}
+
+func (client *AliyunClient) getProductClient(code ServiceCode, key string) (*productClient, chan struct{}) {
+	client.productClientsMutex.Lock()
+	defer client.productClientsMutex.Unlock()
+
+	holder, ok := client.productClients[key]
+	if !ok {
+		holder = &productClient{}
+		client.productClients[key] = holder
+	}
+	limiter, ok := client.productLimiters[code]
+	if !ok {
+		limit := client.config.MaxConcurrentRequests
+		if v, ok := client.config.MaxConcurrentRequestsByProduct[strings.ToLower(string(code))]; ok {
+			limit = v
+		}
+		// A non-positive limit means the requests are not limited.
+		if limit > 0 {
+			limiter = make(chan struct{}, limit)
+		}
+		client.productLimiters[code] = limiter
 	}
+	return holder, limiter
+}
 
-	return do(client.pvtzconn)
+func (client *AliyunClient) getAuthCredential(stsSupported bool) auth.Credential {
+	client.credentialMutex.RLock()
+	defer client.credentialMutex.RUnlock()
+	return client.config.getAuthCredential(stsSupported)
 }
 
-func (client *AliyunClient) WithStsClient(do func(*sts.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+func (client *AliyunClient) getAuthCredentialByEcsRoleName() (accessKey, secretKey, token string, err error) {
+	client.credentialMutex.RLock()
+	defer client.credentialMutex.RUnlock()
+	return client.config.getAuthCredentialByEcsRoleName()
+}
+
+// refreshAssumeRoleCredential assumes the role specified by RamRoleArn when there is no temporary credential
+// or the current one is about to expire. The product clients built with the old credential will be rebuilt
+// with the new one when they are used next time.
+func (client *AliyunClient) refreshAssumeRoleCredential() error {
+	if client.config.RamRoleArn == "" {
+		return nil
+	}
+	client.credentialMutex.RLock()
+	valid := client.config.assumeRoleCredential != nil && time.Now().Add(assumeRoleRefreshAdvance).Before(client.assumeRoleExpiration)
+	client.credentialMutex.RUnlock()
+	if valid {
+		return nil
+	}
+
+	client.credentialMutex.Lock()
+	defer client.credentialMutex.Unlock()
+	// The credential may have been refreshed by others while waiting for the lock.
+	if client.config.assumeRoleCredential != nil && time.Now().Add(assumeRoleRefreshAdvance).Before(client.assumeRoleExpiration) {
+		return nil
+	}
+
+	sessionName := client.config.RamRoleSessionName
+	if sessionName == "" {
+		sessionName = DefaultAssumeRoleSessionName
+	}
+	sessionExpiration := client.config.RamRoleSessionExpiration
+	if sessionExpiration == 0 {
+		sessionExpiration = DefaultAssumeRoleSessionExpiration
+	}
+
+	raw, err := client.WithStsClient(func(stsClient *sts.Client) (interface{}, error) {
+		request := sts.CreateAssumeRoleRequest()
+		request.RoleArn = client.config.RamRoleArn
+		request.RoleSessionName = sessionName
+		request.Policy = client.config.RamRolePolicy
+		request.DurationSeconds = requests.NewInteger(sessionExpiration)
+		return stsClient.AssumeRole(request)
+	})
+	if err != nil {
+		return fmt.Errorf("AssumeRole %s got an error: %#v", client.config.RamRoleArn, err)
+	}
+	response, _ := raw.(*sts.AssumeRoleResponse)
+	expiration, err := time.Parse(time.RFC3339, response.Credentials.Expiration)
+	if err != nil {
+		return fmt.Errorf("AssumeRole %s returned an invalid expiration %s: %#v", client.config.RamRoleArn, response.Credentials.Expiration, err)
+	}
+
+	log.Printf("[DEBUG] Assumed role %s and the temporary credential expires at %s", client.config.RamRoleArn, response.Credentials.Expiration)
+	credential := response.Credentials
+	client.config.assumeRoleCredential = &credential
+	client.assumeRoleExpiration = expiration
+	client.AccessKey = credential.AccessKeyId
+	client.SecretKey = credential.AccessKeySecret
+	client.SecurityToken = credential.SecurityToken
+	client.credentialVersion++
+	return nil
+}
 
-	// Initialize the STS client if necessary
-	if client.stsconn == nil {
-		endpoint := client.config.StsEndpoint
+// endpointMapping is a product whose endpoint is registered to the SDK, and endpoint is the custom one in the config.
+type endpointMapping struct {
+	code     ServiceCode
+	product  string
+	endpoint string
+}
+
+func (client *AliyunClient) endpointMappings() []endpointMapping {
+	return []endpointMapping{
+		{ECSCode, string(ECSCode), client.config.EcsEndpoint},
+		{RDSCode, string(RDSCode), client.config.RdsEndpoint},
+		{SLBCode, string(SLBCode), client.config.SlbEndpoint},
+		{VPCCode, string(VPCCode), client.config.VpcEndpoint},
+		{CENCode, string(CENCode), client.config.CenEndpoint},
+		{ESSCode, string(ESSCode), client.config.EssEndpoint},
+		{DNSCode, string(DNSCode), client.config.DnsEndpoint},
+		{RAMCode, string(RAMCode), client.config.RamEndpoint},
+		{KMSCode, string(KMSCode), client.config.KmsEndpoint},
+		{OTSCode, string(OTSCode), client.config.OtsEndpoint},
+		{PVTZCode, string(PVTZCode), client.config.PvtzEndpoint},
+		{STSCode, string(STSCode), client.config.StsEndpoint},
+		{DDSCode, string(DDSCode), client.config.DdsEndpoint},
+		{KVSTORECode, fmt.Sprintf("R-%s", string(KVSTORECode)), client.config.KVStoreEndpoint},
+		{CLOUDAPICode, "CLOUDAPI", client.config.ApigatewayEndpoint},
+		{ELASTICSEARCHCode, string(ELASTICSEARCHCode), client.config.ElasticsearchEndpoint},
+	}
+}
+
+// customEndpoint returns the endpoint of the product specified in the config, or empty when it is not specified.
+func (client *AliyunClient) customEndpoint(code ServiceCode) string {
+	for _, mapping := range client.endpointMappings() {
+		if mapping.code == code {
+			return mapping.endpoint
+		}
+	}
+	return ""
+}
+
+// addEndpointMappings registers the custom endpoints of the products built on alibaba-cloud-sdk-go.
+// It must be done before sending any request because the endpoint mapping of the SDK is a global map
+// which does not support concurrent writing.
+func (client *AliyunClient) addEndpointMappings() {
+	regionId := client.config.RegionId
+	for _, mapping := range client.endpointMappings() {
+		endpoint := mapping.endpoint
 		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, STSCode)
+			endpoint = loadEndpoint(regionId, mapping.code)
 		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(STSCode), endpoint)
+		if mapping.code == RAMCode && strings.HasPrefix(endpoint, "http") {
+			endpoint = fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "http://"))
 		}
-		stsconn, err := sts.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
-		if err != nil {
-			return nil, fmt.Errorf("unable to initialize the STS client: %#v", err)
+		if mapping.code == PVTZCode && endpoint == "" {
+			endpoint = "pvtz.aliyuncs.com"
+		}
+		if endpoint != "" {
+			endpoints.AddEndpointMapping(regionId, mapping.product, endpoint)
 		}
-
-		stsconn.AppendUserAgent(Terraform, version)
-		client.stsconn = stsconn
 	}
-
-	return do(client.stsconn)
 }
 
 func (client *AliyunClient) WithLogClient(do func(*sls.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the LOG client if necessary
-	if client.logconn == nil {
+	// Initialize the LOG client at the first calling
+	return client.withProductClient(LOGCode, string(LOGCode), func() (interface{}, error) {
 		endpoint := client.config.LogEndpoint
 		if endpoint == "" {
 			endpoint = loadEndpoint(client.config.RegionId, LOGCode)
@@ -589,28 +677,25 @@ func (client *AliyunClient) WithLogClient(do func(*sls.Client) (interface{}, err
 		if !strings.HasPrefix(endpoint, "http") {
 			endpoint = fmt.Sprintf("https://%s", strings.TrimPrefix(endpoint, "://"))
 		}
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
 		if err != nil {
 			return nil, err
 		}
-		client.logconn = &sls.Client{
+		return &sls.Client{
 			AccessKeyID:     accessKey,
 			AccessKeySecret: secretKey,
 			Endpoint:        endpoint,
 			SecurityToken:   securityToken,
 			UserAgent:       client.getUserAgent(),
-		}
-	}
-
-	return do(client.logconn)
+		}, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*sls.Client))
+	})
 }
 
 func (client *AliyunClient) WithDrdsClient(do func(*drds.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the DRDS client if necessary
-	if client.drdsconn == nil {
+	// Initialize the DRDS client at the first calling
+	return client.withProductClient(DRDSCode, string(DRDSCode), func() (interface{}, error) {
 		endpoint := client.config.DrdsEndpoint
 		if endpoint == "" {
 			endpoint = loadEndpoint(client.config.RegionId, DRDSCode)
@@ -619,75 +704,52 @@ func (client *AliyunClient) WithDrdsClient(do func(*drds.Client) (interface{}, e
 			}
 		}
 
-		drdsconn, err := drds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+		drdsconn, err := drds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the DRDS client: %#v", err)
 
 		}
 
 		drdsconn.AppendUserAgent(Terraform, version)
-		client.drdsconn = drdsconn
-	}
-
-	return do(client.drdsconn)
+		return drdsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*drds.Client))
+	})
 }
 
 func (client *AliyunClient) WithDdsClient(do func(*dds.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the DDS client if necessary
-	if client.ddsconn == nil {
-		endpoint := client.config.DdsEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, DDSCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(DDSCode), endpoint)
-		}
-		ddsconn, err := dds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the DDS client at the first calling
+	return client.withProductClient(DDSCode, string(DDSCode), func() (interface{}, error) {
+		ddsconn, err := dds.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the DDS client: %#v", err)
 		}
 
 		ddsconn.AppendUserAgent(Terraform, version)
-		client.ddsconn = ddsconn
-	}
-
-	return do(client.ddsconn)
+		return ddsconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*dds.Client))
+	})
 }
 
 func (client *AliyunClient) WithRkvClient(do func(*r_kvstore.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the RKV client if necessary
-	if client.rkvconn == nil {
-		endpoint := client.config.KVStoreEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, KVSTORECode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, fmt.Sprintf("R-%s", string(KVSTORECode)), endpoint)
-		}
-		rkvconn, err := r_kvstore.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the RKV client at the first calling
+	return client.withProductClient(KVSTORECode, string(KVSTORECode), func() (interface{}, error) {
+		rkvconn, err := r_kvstore.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the RKV client: %#v", err)
 		}
 
 		rkvconn.AppendUserAgent(Terraform, version)
-		client.rkvconn = rkvconn
-	}
-
-	return do(client.rkvconn)
+		return rkvconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*r_kvstore.Client))
+	})
 }
 
 func (client *AliyunClient) WithFcClient(do func(*fc.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the FC client if necessary
-	if client.fcconn == nil {
+	// Initialize the FC client at the first calling
+	return client.withProductClient(FCCode, string(FCCode), func() (interface{}, error) {
 		endpoint := client.config.FcEndpoint
 		if endpoint == "" {
 			endpoint = loadEndpoint(client.config.RegionId, FCCode)
@@ -702,7 +764,7 @@ func (client *AliyunClient) WithFcClient(do func(*fc.Client) (interface{}, error
 		if err != nil {
 			return nil, err
 		}
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
 		if err != nil {
 			return nil, err
 		}
@@ -715,44 +777,31 @@ func (client *AliyunClient) WithFcClient(do func(*fc.Client) (interface{}, error
 		}
 
 		fcconn.Config.UserAgent = client.getUserAgent()
-		fcconn.Config.SecurityToken = client.config.SecurityToken
-		client.fcconn = fcconn
-	}
-
-	return do(client.fcconn)
+		fcconn.Config.SecurityToken = securityToken
+		return fcconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*fc.Client))
+	})
 }
 
 func (client *AliyunClient) WithCloudApiClient(do func(*cloudapi.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the Cloud API client if necessary
-	if client.cloudapiconn == nil {
-		endpoint := client.config.ApigatewayEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.RegionId, CLOUDAPICode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.RegionId, "CLOUDAPI", endpoint)
-		}
-		cloudapiconn, err := cloudapi.NewClientWithOptions(client.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	// Initialize the Cloud API client at the first calling
+	return client.withProductClient(CLOUDAPICode, string(CLOUDAPICode), func() (interface{}, error) {
+		cloudapiconn, err := cloudapi.NewClientWithOptions(client.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the CloudAPI client: %#v", err)
 		}
 
 		cloudapiconn.AppendUserAgent(Terraform, version)
-		client.cloudapiconn = cloudapiconn
-	}
-
-	return do(client.cloudapiconn)
+		return cloudapiconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*cloudapi.Client))
+	})
 }
 
 func (client *AliyunClient) WithDataHubClient(do func(*datahub.DataHub) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the DataHub client if necessary
-	if client.dhconn == nil {
+	// Initialize the DataHub client at the first calling
+	return client.withProductClient(DATAHUBCode, string(DATAHUBCode), func() (interface{}, error) {
 		endpoint := client.config.DatahubEndpoint
 		if endpoint == "" {
 			endpoint = loadEndpoint(client.RegionId, DATAHUBCode)
@@ -767,7 +816,7 @@ func (client *AliyunClient) WithDataHubClient(do func(*datahub.DataHub) (interfa
 		if !strings.HasPrefix(endpoint, "http") {
 			endpoint = fmt.Sprintf("https://%s", endpoint)
 		}
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
 		if err != nil {
 			return nil, err
 		}
@@ -776,18 +825,19 @@ func (client *AliyunClient) WithDataHubClient(do func(*datahub.DataHub) (interfa
 			UserAgent: client.getUserAgent(),
 		}
 
-		client.dhconn = datahub.NewClientWithConfig(endpoint, config, account)
-	}
-
-	return do(client.dhconn)
+		datahubconn := datahub.NewClientWithConfig(endpoint, config, account)
+		if client.vcr != nil {
+			datahubconn.Client.HttpClient.Transport = client.vcr
+		}
+		return datahubconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*datahub.DataHub))
+	})
 }
 
 func (client *AliyunClient) WithMnsClient(do func(*ali_mns.MNSClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the MNS client if necessary
-	if client.mnsconn == nil {
+	// Initialize the MNS client at the first calling
+	return client.withProductClient(MNSCode, string(MNSCode), func() (interface{}, error) {
 		endpoint := client.config.MnsEndpoint
 		if endpoint == "" {
 			endpoint = loadEndpoint(client.config.RegionId, MNSCode)
@@ -804,38 +854,53 @@ func (client *AliyunClient) WithMnsClient(do func(*ali_mns.MNSClient) (interface
 			endpoint = strings.TrimPrefix(strings.TrimPrefix(endpoint, "http://"), "https://")
 		}
 		mnsUrl := fmt.Sprintf("https://%s.mns.%s", accountId, endpoint)
+		if client.vcr != nil {
+			mnsUrl = client.vcr.Endpoint(fmt.Sprintf("%s.mns.%s", accountId, endpoint))
+		}
 
-		mnsClient := ali_mns.NewAliMNSClient(mnsUrl, client.config.AccessKey, client.config.SecretKey)
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
+		if err != nil {
+			return nil, err
+		}
+		mnsClient := ali_mns.NewAliMNSClient(mnsUrl, accessKey, secretKey)
+		if securityToken != "" {
+			mnsClient = &mnsStsClient{MNSClient: mnsClient, securityToken: securityToken}
+		}
 
-		client.mnsconn = &mnsClient
-	}
+		return &mnsClient, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*ali_mns.MNSClient))
+	})
+}
 
-	return do(client.mnsconn)
+// mnsStsClient sends the security token of a temporary credential with every request, which the MNS SDK
+// can not do by itself. The token is not a part of the signature.
+type mnsStsClient struct {
+	ali_mns.MNSClient
+	securityToken string
 }
 
-func (client *AliyunClient) WithElasticsearchClient(do func(*elasticsearch.Client) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
+func (c *mnsStsClient) Send(method ali_mns.Method, headers map[string]string, message interface{}, resource string) (*fasthttp.Response, error) {
+	if headers == nil {
+		headers = make(map[string]string)
+	}
+	headers["security-token"] = c.securityToken
+	return c.MNSClient.Send(method, headers, message, resource)
+}
 
-	// Initialize the Elasticsearch client if necessary
-	if client.elasticsearchconn == nil {
-		endpoint := client.config.ElasticsearchEndpoint
-		if endpoint == "" {
-			endpoint = loadEndpoint(client.config.RegionId, ELASTICSEARCHCode)
-		}
-		if endpoint != "" {
-			endpoints.AddEndpointMapping(client.config.RegionId, string(ELASTICSEARCHCode), endpoint)
-		}
-		elasticsearchconn, err := elasticsearch.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+func (client *AliyunClient) WithElasticsearchClient(do func(*elasticsearch.Client) (interface{}, error)) (interface{}, error) {
+	// Initialize the Elasticsearch client at the first calling
+	return client.withProductClient(ELASTICSEARCHCode, string(ELASTICSEARCHCode), func() (interface{}, error) {
+		elasticsearchconn, err := elasticsearch.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 		if err != nil {
 			return nil, fmt.Errorf("unable to initialize the Elasticsearch client: %#v", err)
 		}
 
 		elasticsearchconn.AppendUserAgent(Terraform, version)
-		client.elasticsearchconn = elasticsearchconn
-	}
-
-	return do(client.elasticsearchconn)
+		return elasticsearchconn, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*elasticsearch.Client))
+	})
 }
 
 func (client *AliyunClient) WithMnsQueueManager(do func(ali_mns.AliQueueManager) (interface{}, error)) (interface{}, error) {
@@ -860,12 +925,8 @@ func (client *AliyunClient) WithMnsSubscriptionManagerByTopicName(topicName stri
 }
 
 func (client *AliyunClient) WithTableStoreClient(instanceName string, do func(*tablestore.TableStoreClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the TABLESTORE client if necessary
-	tableStoreClient, ok := client.tablestoreconnByInstanceName[instanceName]
-	if !ok {
+	// Initialize the TABLESTORE client at the first calling
+	return client.withProductClient(OTSCode, fmt.Sprintf("TABLESTORE|%s", instanceName), func() (interface{}, error) {
 		endpoint := client.config.OtsEndpoint
 		if endpoint == "" {
 			endpoint = loadEndpoint(client.RegionId, OTSCode)
@@ -876,41 +937,41 @@ func (client *AliyunClient) WithTableStoreClient(instanceName string, do func(*t
 		if !strings.HasPrefix(endpoint, "https") && !strings.HasPrefix(endpoint, "http") {
 			endpoint = fmt.Sprintf("https://%s", endpoint)
 		}
-		accessKey, secretKey, securityToken, err := client.config.getAuthCredentialByEcsRoleName()
+		if client.vcr != nil {
+			endpoint = client.vcr.Endpoint(strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://"))
+		}
+		accessKey, secretKey, securityToken, err := client.getAuthCredentialByEcsRoleName()
 		if err != nil {
 			return nil, err
 		}
-		tableStoreClient = tablestore.NewClientWithConfig(endpoint, instanceName, accessKey, secretKey, securityToken, tablestore.NewDefaultTableStoreConfig())
-		client.tablestoreconnByInstanceName[instanceName] = tableStoreClient
-	}
-
-	return do(tableStoreClient)
+		return tablestore.NewClientWithConfig(endpoint, instanceName, accessKey, secretKey, securityToken, tablestore.NewDefaultTableStoreConfig()), nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*tablestore.TableStoreClient))
+	})
 }
 
 func (client *AliyunClient) WithCsProjectClient(clusterId, endpoint string, clusterCerts cs.ClusterCerts, do func(*cs.ProjectClient) (interface{}, error)) (interface{}, error) {
-	goSdkMutex.Lock()
-	defer goSdkMutex.Unlock()
-
-	// Initialize the PROJECT client if necessary
-	key := fmt.Sprintf("%s|%s|%s|%s|%s", clusterId, endpoint, clusterCerts.CA, clusterCerts.Cert, clusterCerts.Key)
-	csProjectClient, ok := client.csprojectconnByKey[key]
-	if !ok {
-		var err error
-		csProjectClient, err = cs.NewProjectClient(clusterId, endpoint, clusterCerts)
+	// Initialize the PROJECT client at the first calling
+	key := fmt.Sprintf("CSPROJECT|%s|%s|%s|%s|%s", clusterId, endpoint, clusterCerts.CA, clusterCerts.Cert, clusterCerts.Key)
+	return client.withProductClient(CONTAINCode, key, func() (interface{}, error) {
+		csProjectClient, err := cs.NewProjectClient(clusterId, endpoint, clusterCerts)
 		if err != nil {
 			return nil, fmt.Errorf("Getting Application Client failed by cluster id %s: %#v.", clusterCerts, err)
 		}
 		csProjectClient.SetDebug(false)
 		csProjectClient.SetUserAgent(client.getUserAgent())
-		client.csprojectconnByKey[key] = csProjectClient
-	}
-
-	return do(csProjectClient)
+		return csProjectClient, nil
+	}, func(conn interface{}) (interface{}, error) {
+		return do(conn.(*cs.ProjectClient))
+	})
 }
 
 func (client *AliyunClient) NewCommonRequest(product, serviceCode, schema string, apiVersion ApiVersion) (*requests.CommonRequest, error) {
 	request := requests.NewCommonRequest()
-	endpoint := loadEndpoint(client.RegionId, ServiceCode(strings.ToUpper(product)))
+	endpoint := client.customEndpoint(ServiceCode(strings.ToUpper(product)))
+	if endpoint == "" {
+		endpoint = loadEndpoint(client.RegionId, ServiceCode(strings.ToUpper(product)))
+	}
 	if endpoint == "" {
 		endpointItem, err := client.describeEndpointForService(serviceCode)
 		if err != nil {
@@ -954,9 +1015,14 @@ func (client *AliyunClient) AccountId() (string, error) {
 
 func (client *AliyunClient) getSdkConfig() *sdk.Config {
 	// Fix bug "open /usr/local/go/lib/time/zoneinfo.zip: no such file or directory" which happened in windows.
+	// The embedded data is skipped when it can not be parsed, and then the system zoneinfo is used.
 	if data, ok := resource.GetTZData("GMT"); ok {
-		utils.TZData = data
-		utils.LoadLocationFromTZData = time.LoadLocationFromTZData
+		if _, err := time.LoadLocationFromTZData("GMT", data); err == nil {
+			utils.TZData = data
+			utils.LoadLocationFromTZData = time.LoadLocationFromTZData
+		} else {
+			log.Printf("[DEBUG] Loading the embedded GMT zoneinfo got an error: %#v", err)
+		}
 	}
 	return sdk.NewConfig().
 		WithMaxRetryTime(DefaultClientRetryCountSmall).
@@ -978,12 +1044,23 @@ func (client *AliyunClient) getTransport() *http.Transport {
 	}
 	transport := &http.Transport{}
 	transport.TLSHandshakeTimeout = time.Duration(handshakeTimeout) * time.Second
+	if client.config.Insecure {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
 
 	// After building a new transport and it need to set http proxy to support proxy.
 	proxyUrl := client.getHttpProxyUrl()
 	if proxyUrl != nil {
 		transport.Proxy = http.ProxyURL(proxyUrl)
 	}
+
+	// All of the requests are sent by the recorder when recording or replaying. The empty TLSNextProto keeps HTTP/2
+	// from registering its own protocol on the transport.
+	if client.vcr != nil {
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		transport.RegisterProtocol("https", client.vcr)
+		transport.RegisterProtocol("http", client.vcr)
+	}
 	return transport
 }
 
@@ -1016,7 +1093,7 @@ func (client *AliyunClient) describeEndpointForService(serviceCode string) (*loc
 		args.Domain = "location-readonly.aliyuncs.com"
 	}
 
-	locationClient, err := location.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	locationClient, err := location.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 	if err != nil {
 		return nil, fmt.Errorf("Unable to initialize the location client: %#v", err)
 
@@ -1039,14 +1116,7 @@ func (client *AliyunClient) describeEndpointForService(serviceCode string) (*loc
 func (client *AliyunClient) getCallerIdentity() (*sts.GetCallerIdentityResponse, error) {
 	args := sts.CreateGetCallerIdentityRequest()
 
-	endpoint := client.config.StsEndpoint
-	if endpoint == "" {
-		endpoint = loadEndpoint(client.config.RegionId, STSCode)
-	}
-	if endpoint != "" {
-		endpoints.AddEndpointMapping(client.config.RegionId, string(STSCode), endpoint)
-	}
-	stsClient, err := sts.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.config.getAuthCredential(true))
+	stsClient, err := sts.NewClientWithOptions(client.config.RegionId, client.getSdkConfig(), client.getAuthCredential(true))
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize the STS client: %#v", err)
 	}
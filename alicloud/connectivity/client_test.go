@@ -0,0 +1,115 @@
+package connectivity
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dxh031/ali_mns"
+	"github.com/valyala/fasthttp"
+)
+
+func TestWithProductClientConcurrency(t *testing.T) {
+	client := &AliyunClient{
+		config: &Config{
+			MaxConcurrentRequests:          2,
+			MaxConcurrentRequestsByProduct: map[string]int{"vpc": 0},
+		},
+		productClients:  make(map[string]*productClient),
+		productLimiters: make(map[ServiceCode]chan struct{}),
+	}
+
+	cases := []struct {
+		code        ServiceCode
+		maxInFlight int32
+	}{
+		{ECSCode, 2},
+		{VPCCode, 10},
+	}
+
+	for _, c := range cases {
+		var builds, inFlight, maxInFlight int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.withProductClient(c.code, string(c.code), func() (interface{}, error) {
+					atomic.AddInt32(&builds, 1)
+					return "conn", nil
+				}, func(conn interface{}) (interface{}, error) {
+					current := atomic.AddInt32(&inFlight, 1)
+					for {
+						max := atomic.LoadInt32(&maxInFlight)
+						if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+							break
+						}
+					}
+					time.Sleep(50 * time.Millisecond)
+					atomic.AddInt32(&inFlight, -1)
+					return conn, nil
+				})
+				if err != nil {
+					t.Errorf("%s: unexpected error: %#v", c.code, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if builds != 1 {
+			t.Errorf("%s: expected the client to be built once, got %d", c.code, builds)
+		}
+		if maxInFlight > c.maxInFlight {
+			t.Errorf("%s: expected at most %d in-flight requests, got %d", c.code, c.maxInFlight, maxInFlight)
+		}
+		if c.maxInFlight > 2 && maxInFlight <= 2 {
+			t.Errorf("%s: expected the requests to run concurrently, got %d in-flight requests", c.code, maxInFlight)
+		}
+	}
+}
+
+type fakeMnsClient struct {
+	ali_mns.MNSClient
+	headers map[string]string
+}
+
+func (c *fakeMnsClient) Send(method ali_mns.Method, headers map[string]string, message interface{}, resource string) (*fasthttp.Response, error) {
+	c.headers = headers
+	return nil, nil
+}
+
+func TestWithMnsClientSecurityToken(t *testing.T) {
+	client := &AliyunClient{
+		config: &Config{
+			AccessKey:     "sts-ak",
+			SecretKey:     "sts-secret",
+			SecurityToken: "token",
+			MnsEndpoint:   "cn-hangzhou.aliyuncs.com",
+		},
+		accountId:       "123456",
+		productClients:  make(map[string]*productClient),
+		productLimiters: make(map[ServiceCode]chan struct{}),
+	}
+
+	_, err := client.WithMnsClient(func(mnsClient *ali_mns.MNSClient) (interface{}, error) {
+		stsClient, ok := (*mnsClient).(*mnsStsClient)
+		if !ok {
+			t.Fatalf("expected the MNS client to send the security token, got %T", *mnsClient)
+		}
+		if stsClient.securityToken != "token" {
+			t.Fatalf("expected the security token %q, got %q", "token", stsClient.securityToken)
+		}
+
+		fake := &fakeMnsClient{}
+		stsClient.MNSClient = fake
+		stsClient.Send(ali_mns.GET, nil, nil, "queues")
+		if fake.headers["security-token"] != "token" {
+			t.Fatalf("expected the security-token header %q, got %q", "token", fake.headers["security-token"])
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %#v", err)
+	}
+}
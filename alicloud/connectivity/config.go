@@ -2,6 +2,8 @@ package connectivity
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 
 	"encoding/json"
 	"net/http"
@@ -11,7 +13,9 @@ import (
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/auth/credentials"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
 	"github.com/jmespath/go-jmespath"
+	"github.com/mitchellh/go-homedir"
 )
 
 var securityCredURL = "http://100.100.100.200/latest/meta-data/ram/security-credentials/"
@@ -27,6 +31,37 @@ type Config struct {
 	OtsInstanceName string
 	AccountId       string
 
+	Profile               string
+	SharedCredentialsFile string
+
+	RamRoleArn               string
+	RamRoleSessionName       string
+	RamRolePolicy            string
+	RamRoleSessionExpiration int
+	// The temporary credential returned by STS AssumeRole when RamRoleArn is set.
+	assumeRoleCredential *sts.Credentials
+
+	// The maximum number of in-flight requests for each product, and the overrides keyed by product code in lower case.
+	MaxConcurrentRequests          int
+	MaxConcurrentRequestsByProduct map[string]int
+
+	// The maximum number of retries and the maximum seconds spent on retrying a request which is throttled.
+	MaxRetries      int
+	MaxRetryTimeout int
+
+	// Insecure skips verifying the certificates of the endpoints, such as the fake API server used by the unit tests.
+	Insecure bool
+
+	// DefaultTags are merged into the tags of every taggable resource. The tags whose keys are in IgnoreTagKeys or
+	// start with one of IgnoreTagKeyPrefixes are added by the other systems, and they are ignored by the resources.
+	DefaultTags          map[string]string
+	IgnoreTagKeys        []string
+	IgnoreTagKeyPrefixes []string
+
+	// VcrMode records the API requests to the VcrCassette file or replays them from it, see vcr.go.
+	VcrMode     VcrMode
+	VcrCassette string
+
 	EcsEndpoint           string
 	RdsEndpoint           string
 	SlbEndpoint           string
@@ -56,7 +91,12 @@ type Config struct {
 }
 
 func (c *Config) loadAndValidate() error {
-	err := c.validateRegion()
+	err := c.loadProfile()
+	if err != nil {
+		return err
+	}
+
+	err = c.validateRegion()
 	if err != nil {
 		return err
 	}
@@ -64,6 +104,110 @@ func (c *Config) loadAndValidate() error {
 	return nil
 }
 
+// The default location of the configuration file written by the Aliyun CLI
+const DefaultSharedCredentialsFile = "~/.aliyun/config.json"
+const DefaultProfileName = "default"
+
+// The credential modes of the Aliyun CLI profile
+const (
+	ProfileModeAK         = "AK"
+	ProfileModeStsToken   = "StsToken"
+	ProfileModeRamRoleArn = "RamRoleArn"
+	ProfileModeEcsRamRole = "EcsRamRole"
+)
+
+type cliConfiguration struct {
+	Current  string       `json:"current"`
+	Profiles []cliProfile `json:"profiles"`
+}
+
+type cliProfile struct {
+	Name            string `json:"name"`
+	Mode            string `json:"mode"`
+	AccessKeyId     string `json:"access_key_id"`
+	AccessKeySecret string `json:"access_key_secret"`
+	StsToken        string `json:"sts_token"`
+	RamRoleName     string `json:"ram_role_name"`
+	RamRoleArn      string `json:"ram_role_arn"`
+	RamSessionName  string `json:"ram_session_name"`
+	ExpiredSeconds  int    `json:"expired_seconds"`
+}
+
+// loadProfile populates the credential from a profile of the Aliyun CLI configuration file.
+// The access key pair and the ECS role name which come from the provider block or environment variables take precedence
+// over the profile, and the assume_role block takes precedence over the RAM role ARN of the profile.
+func (c *Config) loadProfile() error {
+	if (c.AccessKey != "" && c.SecretKey != "") || c.EcsRoleName != "" {
+		return nil
+	}
+
+	path := c.SharedCredentialsFile
+	if path == "" {
+		path = DefaultSharedCredentialsFile
+	}
+	path, err := homedir.Expand(path)
+	if err != nil {
+		return fmt.Errorf("Expanding the shared credentials file %s got an error: %#v", c.SharedCredentialsFile, err)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		// The default configuration file is optional
+		if os.IsNotExist(err) && c.SharedCredentialsFile == "" && c.Profile == "" {
+			return nil
+		}
+		return fmt.Errorf("Reading the shared credentials file %s got an error: %#v", path, err)
+	}
+
+	var configuration cliConfiguration
+	if err := json.Unmarshal(data, &configuration); err != nil {
+		return fmt.Errorf("Parsing the shared credentials file %s got an error: %#v", path, err)
+	}
+
+	name := c.Profile
+	if name == "" {
+		name = configuration.Current
+	}
+	if name == "" {
+		name = DefaultProfileName
+	}
+	for _, profile := range configuration.Profiles {
+		if profile.Name == name {
+			return c.setProfileCredential(profile)
+		}
+	}
+
+	return fmt.Errorf("The profile %s is not found in the shared credentials file %s.", name, path)
+}
+
+func (c *Config) setProfileCredential(profile cliProfile) error {
+	switch profile.Mode {
+	case ProfileModeAK:
+		c.AccessKey = profile.AccessKeyId
+		c.SecretKey = profile.AccessKeySecret
+	case ProfileModeStsToken:
+		c.AccessKey = profile.AccessKeyId
+		c.SecretKey = profile.AccessKeySecret
+		c.SecurityToken = profile.StsToken
+	case ProfileModeRamRoleArn:
+		c.AccessKey = profile.AccessKeyId
+		c.SecretKey = profile.AccessKeySecret
+		if c.RamRoleArn == "" {
+			c.RamRoleArn = profile.RamRoleArn
+			c.RamRoleSessionName = profile.RamSessionName
+			if profile.ExpiredSeconds > 0 {
+				c.RamRoleSessionExpiration = profile.ExpiredSeconds
+			}
+		}
+	case ProfileModeEcsRamRole:
+		c.EcsRoleName = profile.RamRoleName
+	default:
+		return fmt.Errorf("The mode %s of profile %s is not supported. Valid modes: %s, %s, %s and %s.",
+			profile.Mode, profile.Name, ProfileModeAK, ProfileModeStsToken, ProfileModeRamRoleArn, ProfileModeEcsRamRole)
+	}
+
+	return nil
+}
+
 func (c *Config) validateRegion() error {
 
 	for _, valid := range ValidRegions {
@@ -76,6 +220,16 @@ func (c *Config) validateRegion() error {
 }
 
 func (c *Config) getAuthCredential(stsSupported bool) auth.Credential {
+	if c.assumeRoleCredential != nil {
+		return credentials.NewStsTokenCredential(c.assumeRoleCredential.AccessKeyId, c.assumeRoleCredential.AccessKeySecret, c.assumeRoleCredential.SecurityToken)
+	}
+
+	return c.getSourceAuthCredential(stsSupported)
+}
+
+// getSourceAuthCredential returns the credential configured in the provider block, ignoring any assumed role.
+// It is used to call STS AssumeRole.
+func (c *Config) getSourceAuthCredential(stsSupported bool) auth.Credential {
 	if c.AccessKey != "" && c.SecretKey != "" {
 		if stsSupported {
 			return credentials.NewStsTokenCredential(c.AccessKey, c.SecretKey, c.SecurityToken)
@@ -96,6 +250,9 @@ func (c *Config) getAuthCredential(stsSupported bool) auth.Credential {
 // This method is a temporary solution and it should be removed after all go sdk support ecs role name
 // The related PR: https://github.com/terraform-providers/terraform-provider-alicloud/pull/731
 func (c *Config) getAuthCredentialByEcsRoleName() (accessKey, secretKey, token string, err error) {
+	if c.assumeRoleCredential != nil {
+		return c.assumeRoleCredential.AccessKeyId, c.assumeRoleCredential.AccessKeySecret, c.assumeRoleCredential.SecurityToken, nil
+	}
 	if c.AccessKey != "" {
 		return c.AccessKey, c.SecretKey, c.SecurityToken, nil
 	}
@@ -0,0 +1,128 @@
+package connectivity
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const testSharedCredentialsFile = `{
+	"current": "sts",
+	"profiles": [
+		{
+			"name": "default",
+			"mode": "AK",
+			"access_key_id": "ak",
+			"access_key_secret": "secret"
+		},
+		{
+			"name": "sts",
+			"mode": "StsToken",
+			"access_key_id": "sts-ak",
+			"access_key_secret": "sts-secret",
+			"sts_token": "token"
+		},
+		{
+			"name": "role",
+			"mode": "RamRoleArn",
+			"access_key_id": "role-ak",
+			"access_key_secret": "role-secret",
+			"ram_role_arn": "acs:ram::123456:role/test",
+			"ram_session_name": "session",
+			"expired_seconds": 900
+		},
+		{
+			"name": "ecs",
+			"mode": "EcsRamRole",
+			"ram_role_name": "ecs-role"
+		},
+		{
+			"name": "unknown",
+			"mode": "RsaKeyPair"
+		}
+	]
+}`
+
+func TestConfigLoadProfile(t *testing.T) {
+	file, err := ioutil.TempFile("", "alicloud-config")
+	if err != nil {
+		t.Fatalf("creating temporary file got an error: %#v", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.WriteString(testSharedCredentialsFile); err != nil {
+		t.Fatalf("writing temporary file got an error: %#v", err)
+	}
+	file.Close()
+
+	cases := []struct {
+		config   Config
+		expected Config
+		hasError bool
+	}{
+		{
+			config:   Config{},
+			expected: Config{AccessKey: "sts-ak", SecretKey: "sts-secret", SecurityToken: "token"},
+		},
+		{
+			config:   Config{Profile: "default"},
+			expected: Config{AccessKey: "ak", SecretKey: "secret"},
+		},
+		{
+			config: Config{Profile: "role"},
+			expected: Config{AccessKey: "role-ak", SecretKey: "role-secret", RamRoleArn: "acs:ram::123456:role/test",
+				RamRoleSessionName: "session", RamRoleSessionExpiration: 900},
+		},
+		{
+			config:   Config{Profile: "role", RamRoleArn: "acs:ram::123456:role/block"},
+			expected: Config{AccessKey: "role-ak", SecretKey: "role-secret", RamRoleArn: "acs:ram::123456:role/block"},
+		},
+		{
+			config:   Config{Profile: "ecs"},
+			expected: Config{EcsRoleName: "ecs-role"},
+		},
+		{
+			config:   Config{Profile: "default", AccessKey: "static-ak", SecretKey: "static-secret"},
+			expected: Config{AccessKey: "static-ak", SecretKey: "static-secret"},
+		},
+		{
+			config:   Config{Profile: "default", EcsRoleName: "static-role"},
+			expected: Config{EcsRoleName: "static-role"},
+		},
+		{
+			config:   Config{Profile: "unknown"},
+			hasError: true,
+		},
+		{
+			config:   Config{Profile: "missing"},
+			hasError: true,
+		},
+	}
+
+	for i, c := range cases {
+		c.config.SharedCredentialsFile = file.Name()
+		err := c.config.loadProfile()
+		if c.hasError {
+			if err == nil {
+				t.Errorf("case %d: expected an error, got nil", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %#v", i, err)
+			continue
+		}
+		c.expected.Profile = c.config.Profile
+		c.expected.SharedCredentialsFile = file.Name()
+		if !reflect.DeepEqual(c.config, c.expected) {
+			t.Errorf("case %d: expected %#v, got %#v", i, c.expected, c.config)
+		}
+	}
+}
+
+func TestConfigLoadProfileMissingFile(t *testing.T) {
+	config := Config{SharedCredentialsFile: "/not/exist/config.json"}
+	if err := config.loadProfile(); err == nil {
+		t.Fatalf("expected an error for the missing shared credentials file")
+	}
+}
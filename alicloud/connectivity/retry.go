@@ -0,0 +1,125 @@
+package connectivity
+
+import (
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+	"github.com/aliyun/aliyun-datahub-sdk-go/datahub"
+	"github.com/aliyun/aliyun-log-go-sdk"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/aliyun/fc-go-sdk"
+	"github.com/denverdino/aliyungo/common"
+)
+
+// The default maximum number of retries of a request which failed with a retryable error
+const DefaultMaxRetries = 10
+
+// The base and the maximum delay of the exponential backoff between two retries
+const (
+	DefaultRetryBaseDelay = time.Duration(1) * time.Second
+	DefaultRetryMaxDelay  = time.Duration(30) * time.Second
+)
+
+// commonRetryableCodes are the error codes returned by all of products when the request is rejected
+// because of throttling or the service is busy. The request is not processed, so it is safe to retry it.
+var commonRetryableCodes = []string{
+	"Throttling",
+	"Throttling.User",
+	"Throttling.Api",
+	"ServiceUnavailable",
+}
+
+// productRetryableCodes are the additional retryable error codes of each product.
+var productRetryableCodes = map[ServiceCode][]string{
+	ECSCode:     {"OperationConflict", "LastTokenProcessing"},
+	SLBCode:     {"SystemBusy", "OperationBusy", "ServiceIsConfiguring"},
+	VPCCode:     {"TaskConflict", "OperationConflict", "SystemBusy"},
+	ESSCode:     {"SystemBusy"},
+	RDSCode:     {"SystemBusy", "ServiceIsConfiguring"},
+	KVSTORECode: {"SystemBusy"},
+	PVTZCode:    {"System.Busy"},
+	CENCode:     {"Operation.Blocking"},
+	CONTAINCode: {"SystemBusy"},
+	OSSCode:     {"ServerBusy"},
+	LOGCode:     {"ServerBusy", "ExceedQuota"},
+	FCCode:      {"ServerBusy", "ResourceThrottled"},
+	DATAHUBCode: {"LimitExceeded"},
+}
+
+// RetryableErrorCodes returns the error codes which can be retried for the specified product.
+func RetryableErrorCodes(code ServiceCode) []string {
+	return append(append([]string{}, commonRetryableCodes...), productRetryableCodes[code]...)
+}
+
+// RetryDelay returns the delay before the next retry. It grows exponentially from base with the number of
+// attempts and does not exceed max. A random jitter of up to half of the delay is applied to spread the retries.
+func RetryDelay(base, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 0; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		delay = max
+	}
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// isRetryableError checks whether the error is returned because of throttling or the service is busy.
+func isRetryableError(code ServiceCode, err error) bool {
+	if err == nil {
+		return false
+	}
+	errorCode := ""
+	switch e := err.(type) {
+	case *errors.ServerError:
+		errorCode = e.ErrorCode()
+	case *common.Error:
+		errorCode = e.Code
+	case *sls.Error:
+		errorCode = e.Code
+	case oss.ServiceError:
+		errorCode = e.Code
+	case *fc.ServiceError:
+		errorCode = e.ErrorCode
+	case datahub.DatahubError:
+		errorCode = e.Code
+	default:
+		return false
+	}
+	for _, retryable := range RetryableErrorCodes(code) {
+		if errorCode == retryable {
+			return true
+		}
+	}
+	return false
+}
+
+// withRetry invokes do and retries it with exponential backoff when it failed with a retryable error of the product.
+// The retries stop when the max retries is exhausted or the max retry timeout is reached.
+func (client *AliyunClient) withRetry(code ServiceCode, do func() (interface{}, error)) (interface{}, error) {
+	maxRetries := client.config.MaxRetries
+	var deadline time.Time
+	if client.config.MaxRetryTimeout > 0 {
+		deadline = time.Now().Add(time.Duration(client.config.MaxRetryTimeout) * time.Second)
+	}
+
+	for attempt := 0; ; attempt++ {
+		raw, err := do()
+		if err == nil || attempt >= maxRetries || !isRetryableError(code, err) {
+			return raw, err
+		}
+		delay := RetryDelay(DefaultRetryBaseDelay, DefaultRetryMaxDelay, attempt)
+		if !deadline.IsZero() && time.Now().Add(delay).After(deadline) {
+			return raw, err
+		}
+		log.Printf("[DEBUG] %s request failed with a retryable error and will be retried after %s: %s", code, delay, strings.TrimSpace(err.Error()))
+		time.Sleep(delay)
+	}
+}
@@ -0,0 +1,57 @@
+package connectivity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
+)
+
+func TestRetryDelay(t *testing.T) {
+	base := time.Second
+	max := 8 * time.Second
+	for attempt, expected := range []time.Duration{1, 2, 4, 8, 8, 8} {
+		expected *= time.Second
+		for i := 0; i < 20; i++ {
+			delay := RetryDelay(base, max, attempt)
+			if delay < expected/2 || delay > expected {
+				t.Fatalf("attempt %d: expected a delay in [%s, %s], got %s", attempt, expected/2, expected, delay)
+			}
+		}
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	client := &AliyunClient{config: &Config{MaxRetries: 1}}
+	newError := func(code string) error {
+		return errors.NewServerError(400, fmt.Sprintf(`{"Code": "%s", "Message": "test"}`, code), "")
+	}
+
+	cases := []struct {
+		code     ServiceCode
+		err      error
+		attempts int
+	}{
+		{ECSCode, nil, 1},
+		{ECSCode, newError("Throttling"), 2},
+		{ECSCode, newError("InvalidParameter"), 1},
+		{ECSCode, newError("OperationBusy"), 1},
+		{SLBCode, newError("OperationBusy"), 2},
+		{ECSCode, fmt.Errorf("Throttling"), 1},
+	}
+
+	for i, c := range cases {
+		attempts := 0
+		_, err := client.withRetry(c.code, func() (interface{}, error) {
+			attempts++
+			return nil, c.err
+		})
+		if err != c.err {
+			t.Errorf("case %d: expected error %v, got %v", i, c.err, err)
+		}
+		if attempts != c.attempts {
+			t.Errorf("case %d: expected %d attempts, got %d", i, c.attempts, attempts)
+		}
+	}
+}
@@ -0,0 +1,472 @@
+package connectivity
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// The environment variables which control recording the HTTP exchanges of the SDK clients to a cassette file
+// and replaying them from it. It is designed for the acceptance tests and it is disabled when the mode is empty.
+const (
+	VcrModeEnv     = "ALICLOUD_VCR_MODE"
+	VcrCassetteEnv = "ALICLOUD_VCR_CASSETTE"
+)
+
+type VcrMode string
+
+const (
+	// VcrRecord sends the requests to Alibaba Cloud and records the exchanges to the cassette.
+	VcrRecord = VcrMode("record")
+	// VcrReplay replays the exchanges from the cassette and does not send any request to Alibaba Cloud.
+	VcrReplay = VcrMode("replay")
+)
+
+const vcrRedacted = "REDACTED"
+
+// vcrSensitiveParams are the credentials and signatures in the query and form parameters, they are not recorded.
+var vcrSensitiveParams = []string{"AccessKeyId", "Signature", "SecurityToken", "OSSAccessKeyId", "security-token"}
+
+// vcrVolatileParams change in every request, so they are ignored when matching the recorded requests.
+var vcrVolatileParams = append([]string{"SignatureNonce", "Timestamp", "ClientToken", "Expires"}, vcrSensitiveParams...)
+
+// vcrSensitiveHeaders are the headers carrying the credentials and signatures, they are not recorded.
+var vcrSensitiveHeaders = regexp.MustCompile(`(?i)^(authorization|cookie|proxy-authorization)$|security-token|signature|credential`)
+
+// vcrSensitiveFields matches the credentials in the JSON and XML bodies, such as the response of STS AssumeRole.
+var vcrSensitiveFields = regexp.MustCompile(`("(?:AccessKeyId|AccessKeySecret|SecurityToken)"\s*:\s*)"[^"]*"|<(AccessKeyId|AccessKeySecret|SecurityToken)>[^<]*</(?:AccessKeyId|AccessKeySecret|SecurityToken)>`)
+
+type vcrRequest struct {
+	Method     string      `json:"method"`
+	URL        string      `json:"url"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+type vcrResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// vcrInteraction is a recorded HTTP exchange, and each of them is a line in the cassette file.
+type vcrInteraction struct {
+	Request  vcrRequest  `json:"request"`
+	Response vcrResponse `json:"response"`
+
+	key       string
+	operation string
+	used      bool
+}
+
+// vcrRecorder records or replays the HTTP exchanges of a cassette. It is a http.RoundTripper for the clients whose
+// transport can be customized, and it also listens on a local address for the clients which only support a custom
+// endpoint or proxy. The requests received by the local address are forwarded to Alibaba Cloud by HTTPS.
+type vcrRecorder struct {
+	mode      VcrMode
+	cassette  string
+	transport http.RoundTripper
+	secrets   []string
+	listener  net.Listener
+
+	mutex        sync.Mutex
+	interactions []*vcrInteraction
+}
+
+var (
+	vcrRecorders      = make(map[string]*vcrRecorder)
+	vcrCurrent        *vcrRecorder
+	vcrRecordersMutex sync.Mutex
+)
+
+// startVcr returns the recorder of the cassette, and starts it at the first calling. Recording truncates the
+// existing cassette. The http.DefaultTransport is routed to the latest started recorder, because some clients,
+// such as the Log client, always use it.
+func startVcr(mode VcrMode, cassette string, insecure bool, secrets ...string) (*vcrRecorder, error) {
+	if mode != VcrRecord && mode != VcrReplay {
+		return nil, fmt.Errorf("%s must be one of %s and %s, got %s.", VcrModeEnv, VcrRecord, VcrReplay, mode)
+	}
+	if cassette == "" {
+		return nil, fmt.Errorf("%s must be set when %s is %s.", VcrCassetteEnv, VcrModeEnv, mode)
+	}
+
+	vcrRecordersMutex.Lock()
+	defer vcrRecordersMutex.Unlock()
+	if r, ok := vcrRecorders[cassette]; ok && r.mode == mode {
+		vcrCurrent = r
+		return r, nil
+	}
+
+	r := &vcrRecorder{
+		mode:     mode,
+		cassette: cassette,
+		transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+		},
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	if mode == VcrReplay {
+		if err := r.load(); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(cassette), 0755); err != nil {
+			return nil, fmt.Errorf("creating the directory of the cassette %s got an error: %#v", cassette, err)
+		}
+		if err := ioutil.WriteFile(cassette, nil, 0644); err != nil {
+			return nil, fmt.Errorf("truncating the cassette %s got an error: %#v", cassette, err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("starting the recorder of the cassette %s got an error: %#v", cassette, err)
+	}
+	r.listener = listener
+	go http.Serve(listener, r)
+
+	if vcrCurrent == nil {
+		http.DefaultTransport = &vcrDefaultTransport{http.DefaultTransport}
+	}
+	vcrRecorders[cassette] = r
+	vcrCurrent = r
+	log.Printf("[DEBUG] Start to %s the API requests with the cassette %s", mode, cassette)
+	return r, nil
+}
+
+// vcrDefaultTransport replaces http.DefaultTransport once a recorder is started.
+type vcrDefaultTransport struct {
+	fallback http.RoundTripper
+}
+
+func (t *vcrDefaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	vcrRecordersMutex.Lock()
+	r := vcrCurrent
+	vcrRecordersMutex.Unlock()
+	if r == nil {
+		return t.fallback.RoundTrip(req)
+	}
+	return r.RoundTrip(req)
+}
+
+// ProxyURL returns the URL of the local forward proxy, the requests sent to it are forwarded by HTTPS.
+func (r *vcrRecorder) ProxyURL() string {
+	return fmt.Sprintf("http://%s", r.listener.Addr().String())
+}
+
+// Endpoint returns a local endpoint for the host, the requests sent to it are forwarded to the host by HTTPS.
+// The endpoint uses localhost instead of the IP, because some clients parse the endpoint by dots.
+func (r *vcrRecorder) Endpoint(host string) string {
+	_, port, _ := net.SplitHostPort(r.listener.Addr().String())
+	return fmt.Sprintf("http://localhost:%s/%s", port, host)
+}
+
+// ServeHTTP forwards the requests received by the local address. The forward proxy requests carry the host in
+// the URL, and the other requests carry it as the first segment of the path.
+func (r *vcrRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	target := *req.URL
+	if target.Host == "" {
+		parts := strings.SplitN(strings.TrimPrefix(target.Path, "/"), "/", 2)
+		target.Host = parts[0]
+		target.Path = "/"
+		if len(parts) > 1 {
+			target.Path += parts[1]
+		}
+		target.RawPath = ""
+	}
+	target.Scheme = "https"
+
+	outgoing, err := http.NewRequest(req.Method, target.String(), req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	for k, v := range req.Header {
+		if k != "Proxy-Connection" && k != "Connection" {
+			outgoing.Header[k] = v
+		}
+	}
+	outgoing.ContentLength = req.ContentLength
+
+	resp, err := r.RoundTrip(outgoing)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+// RoundTrip records the exchange after sending the request, or replays the recorded one which matches the request.
+func (r *vcrRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+	key, operation := vcrRequestKey(req.Method, req.URL, req.Header.Get("Content-Type"), body)
+
+	if r.mode == VcrReplay {
+		return r.replay(req, key, operation)
+	}
+
+	outgoing := new(http.Request)
+	*outgoing = *req
+	outgoing.Body = ioutil.NopCloser(bytes.NewReader(body))
+	outgoing.ContentLength = int64(len(body))
+	resp, err := r.transport.RoundTrip(outgoing)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := &vcrInteraction{
+		Request: vcrRequest{
+			Method:  req.Method,
+			URL:     r.scrub(vcrScrubURL(req.URL)),
+			Headers: r.scrubHeaders(req.Header),
+		},
+		Response: vcrResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    r.scrubHeaders(resp.Header),
+		},
+	}
+	interaction.Request.Body, interaction.Request.BodyBase64 = r.encodeBody(vcrScrubForm(req.Header.Get("Content-Type"), body))
+	interaction.Response.Body, interaction.Response.BodyBase64 = r.encodeBody(respBody)
+	if err := r.append(interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (r *vcrRecorder) replay(req *http.Request, key, operation string) (*http.Response, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// The exchanges with the same request are replayed in the recorded order. The random values, such as the names
+	// of the resources, make the requests different from the recorded ones, so the next recorded exchange with the
+	// same operation is replayed when no one matches.
+	var matched *vcrInteraction
+	for _, interaction := range r.interactions {
+		if !interaction.used && interaction.key == key {
+			matched = interaction
+			break
+		}
+	}
+	if matched == nil {
+		for _, interaction := range r.interactions {
+			if !interaction.used && interaction.operation == operation {
+				matched = interaction
+				break
+			}
+		}
+	}
+	if matched == nil {
+		return nil, fmt.Errorf("there is no recorded exchange for the request %s %s in the cassette %s", req.Method, vcrScrubURL(req.URL), r.cassette)
+	}
+	matched.used = true
+
+	body, err := decodeVcrBody(matched.Response.Body, matched.Response.BodyBase64)
+	if err != nil {
+		return nil, err
+	}
+	header := make(http.Header)
+	for k, v := range matched.Response.Headers {
+		header[k] = v
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", matched.Response.StatusCode, http.StatusText(matched.Response.StatusCode)),
+		StatusCode:    matched.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// append writes the interaction to the end of the cassette at once, so the cassette is complete even if the
+// process exits without any notification.
+func (r *vcrRecorder) append(interaction *vcrInteraction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.interactions = append(r.interactions, interaction)
+	file, err := os.OpenFile(r.cassette, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("opening the cassette %s got an error: %#v", r.cassette, err)
+	}
+	defer file.Close()
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func (r *vcrRecorder) load() error {
+	file, err := os.Open(r.cassette)
+	if err != nil {
+		return fmt.Errorf("opening the cassette %s got an error: %#v", r.cassette, err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			interaction := &vcrInteraction{}
+			if err := json.Unmarshal(line, interaction); err != nil {
+				return fmt.Errorf("parsing the cassette %s got an error: %#v", r.cassette, err)
+			}
+			u, err := url.Parse(interaction.Request.URL)
+			if err != nil {
+				return fmt.Errorf("parsing the URL %s in the cassette %s got an error: %#v", interaction.Request.URL, r.cassette, err)
+			}
+			body, err := decodeVcrBody(interaction.Request.Body, interaction.Request.BodyBase64)
+			if err != nil {
+				return err
+			}
+			interaction.key, interaction.operation = vcrRequestKey(interaction.Request.Method, u, interaction.Request.Headers.Get("Content-Type"), body)
+			r.interactions = append(r.interactions, interaction)
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading the cassette %s got an error: %#v", r.cassette, err)
+		}
+	}
+}
+
+// scrub replaces the credentials of the config and the credentials in the JSON and XML fields.
+func (r *vcrRecorder) scrub(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.Replace(s, secret, vcrRedacted, -1)
+	}
+	return vcrSensitiveFields.ReplaceAllStringFunc(s, func(field string) string {
+		if strings.HasPrefix(field, "<") {
+			name := field[1:strings.Index(field, ">")]
+			return fmt.Sprintf("<%s>%s</%s>", name, vcrRedacted, name)
+		}
+		return fmt.Sprintf(`%s"%s"`, field[:strings.LastIndex(field[:len(field)-1], `"`)], vcrRedacted)
+	})
+}
+
+func (r *vcrRecorder) scrubHeaders(headers http.Header) http.Header {
+	scrubbed := make(http.Header)
+	for k, v := range headers {
+		if vcrSensitiveHeaders.MatchString(k) {
+			continue
+		}
+		for _, value := range v {
+			scrubbed.Add(k, r.scrub(value))
+		}
+	}
+	return scrubbed
+}
+
+// encodeBody returns the scrubbed body as a string when it is a valid UTF-8 text, otherwise encodes it by base64.
+func (r *vcrRecorder) encodeBody(body []byte) (string, string) {
+	if len(body) == 0 {
+		return "", ""
+	}
+	if utf8.Valid(body) {
+		return r.scrub(string(body)), ""
+	}
+	return "", base64.StdEncoding.EncodeToString(body)
+}
+
+func decodeVcrBody(body, bodyBase64 string) ([]byte, error) {
+	if bodyBase64 != "" {
+		return base64.StdEncoding.DecodeString(bodyBase64)
+	}
+	return []byte(body), nil
+}
+
+// vcrRequestKey returns the key to match a request exactly and the operation to match it loosely.
+func vcrRequestKey(method string, u *url.URL, contentType string, body []byte) (string, string) {
+	query := u.Query()
+	for _, param := range vcrVolatileParams {
+		query.Del(param)
+	}
+	action := query.Get("Action")
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for _, param := range vcrVolatileParams {
+				form.Del(param)
+			}
+			if action == "" {
+				action = form.Get("Action")
+			}
+			body = []byte(form.Encode())
+		}
+	}
+	key := fmt.Sprintf("%s %s%s?%s\n%s", method, u.Host, u.Path, query.Encode(), body)
+	operation := fmt.Sprintf("%s %s%s %s", method, u.Host, u.Path, action)
+	return key, operation
+}
+
+// vcrScrubURL removes the sensitive parameters, and the others are sorted by the encoding.
+func vcrScrubURL(u *url.URL) string {
+	scrubbed := *u
+	query := u.Query()
+	for _, param := range vcrSensitiveParams {
+		query.Del(param)
+	}
+	scrubbed.RawQuery = query.Encode()
+	return scrubbed.String()
+}
+
+func vcrScrubForm(contentType string, body []byte) []byte {
+	if !strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		return body
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return body
+	}
+	for _, param := range vcrSensitiveParams {
+		form.Del(param)
+	}
+	return []byte(form.Encode())
+}
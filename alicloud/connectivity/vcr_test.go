@@ -0,0 +1,98 @@
+package connectivity
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVcrRecordAndReplay(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"Action":"%s","Path":"%s","AccessKeySecret":"assumed-secret","RequestId":"test"}`, r.URL.Query().Get("Action"), r.URL.Path)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "https://")
+
+	dir, err := ioutil.TempDir("", "vcr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cassette := filepath.Join(dir, "cassettes", "test.jsonl")
+
+	defaultTransport := http.DefaultTransport
+	defer func() {
+		http.DefaultTransport = defaultTransport
+		vcrCurrent = nil
+		vcrRecorders = make(map[string]*vcrRecorder)
+	}()
+
+	// The requests are sent by the transport of the SDK clients and by the local endpoint of the recorder.
+	send := func(r *vcrRecorder, signature string) []string {
+		client := &AliyunClient{config: &Config{Insecure: true}, vcr: r}
+		sdkClient := &http.Client{Transport: client.getTransport()}
+		localClient := &http.Client{Transport: &http.Transport{}}
+		urls := []struct {
+			client *http.Client
+			url    string
+		}{
+			{sdkClient, fmt.Sprintf("https://%s/?Action=DescribeRegions&AccessKeyId=access-key&Signature=%s&Timestamp=%s", host, signature, signature)},
+			{localClient, fmt.Sprintf("%s/CreateTable", r.Endpoint(host))},
+		}
+		var bodies []string
+		for _, u := range urls {
+			resp, err := u.client.Post(u.url, "application/x-www-form-urlencoded", strings.NewReader("Action=CreateTable&SecurityToken=secret-key"))
+			if err != nil {
+				t.Fatalf("sending %s got an error: %#v", u.url, err)
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			bodies = append(bodies, string(body))
+		}
+		return bodies
+	}
+
+	recorder, err := startVcr(VcrRecord, cassette, true, "access-key", "secret-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorded := send(recorder, "first")
+	if !strings.Contains(recorded[0], `"Action":"DescribeRegions"`) || !strings.Contains(recorded[1], `"Path":"/CreateTable"`) {
+		t.Fatalf("unexpected responses %v", recorded)
+	}
+
+	content, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sensitive := range []string{"access-key", "secret-key", "assumed-secret", "Signature"} {
+		if strings.Contains(string(content), sensitive) {
+			t.Fatalf("the cassette contains %s: %s", sensitive, content)
+		}
+	}
+
+	// Replaying does not send any request, and the volatile parameters are ignored when matching the requests.
+	server.Close()
+	replayer, err := startVcr(VcrReplay, cassette, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := send(replayer, "second")
+	for i := range recorded {
+		expected := strings.Replace(recorded[i], "assumed-secret", vcrRedacted, -1)
+		if replayed[i] != expected {
+			t.Fatalf("expected the replayed response %s, got %s", expected, replayed[i])
+		}
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("https://%s/?Action=DescribeZones", host), nil)
+	if _, err := replayer.RoundTrip(request); err == nil {
+		t.Fatalf("expected an error for the request which is not recorded")
+	}
+}
@@ -0,0 +1,218 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// ecsInstanceCustomizeDiffFunc checks the cross-field constraints of the instance during plan.
+func ecsInstanceCustomizeDiffFunc(d *schema.ResourceDiff, meta interface{}) error {
+	chargeType := PayType(d.Get("instance_charge_type").(string))
+	spotStrategy := SpotStrategyType(d.Get("spot_strategy").(string))
+
+	if spotStrategy != NoSpot && chargeType != PostPaid {
+		return fmt.Errorf("'spot_strategy' can only be set when the 'instance_charge_type' is %s.", PostPaid)
+	}
+	if v, ok := d.GetOk("spot_price_limit"); ok && v.(float64) > 0 && spotStrategy != SpotWithPriceLimit {
+		return fmt.Errorf("'spot_price_limit' can only be set when the 'spot_strategy' is %s.", SpotWithPriceLimit)
+	}
+
+	if chargeType == PrePaid {
+		if period := d.Get("period").(int); TimeType(d.Get("period_unit").(string)) == Week && period > 4 {
+			return fmt.Errorf("'period' must be in [1-4] when the 'period_unit' is %s, got %d.", Week, period)
+		}
+	}
+
+	if v := d.Get("role_name").(string); v != "" && d.NewValueKnown("vswitch_id") && d.Get("vswitch_id").(string) == "" {
+		return fmt.Errorf("Role name only supported for VPC instance.")
+	}
+	if StoppedMode(d.Get("stopped_mode").(string)) == StopCharging {
+		if chargeType != PostPaid {
+			return fmt.Errorf("'stopped_mode' can only be %s when the 'instance_charge_type' is %s.", StopCharging, PostPaid)
+		}
+		if d.NewValueKnown("vswitch_id") && d.Get("vswitch_id").(string) == "" && d.Get("launch_template_id").(string) == "" {
+			return fmt.Errorf("'stopped_mode' can only be %s for the VPC instance.", StopCharging)
+		}
+	}
+
+	if d.Id() == "" {
+		// The image, instance type and security groups are taken from the launch template when it is set.
+		if d.NewValueKnown("launch_template_id") && d.Get("launch_template_id").(string) == "" {
+			// The count is checked for the security groups since the set is read as empty when its elements are unknown.
+			for _, key := range []string{"image_id", "instance_type", "security_groups.#"} {
+				if _, ok := d.GetOk(key); !ok && d.NewValueKnown(key) {
+					return fmt.Errorf("'%s': required field is not set when the 'launch_template_id' is not set.", strings.TrimSuffix(key, ".#"))
+				}
+			}
+		}
+		return nil
+	}
+	// The reclaimed spot instance is released by the system soon, so it is replaced instead of being updated.
+	if d.Get("spot_interruption").(string) != "" {
+		if err := d.SetNew("spot_interruption", ""); err != nil {
+			return WrapError(err)
+		}
+		if err := d.ForceNew("spot_interruption"); err != nil {
+			return WrapError(err)
+		}
+		return nil
+	}
+	// The user data is replaced in place only when the instance is allowed to reboot for it.
+	rebootKeys := []string{"image_id", "instance_type", "vswitch_id", "private_ip", "host_name", "password"}
+	if d.HasChange("user_data") {
+		if !d.Get("reboot_on_user_data_change").(bool) {
+			if err := d.ForceNew("user_data"); err != nil {
+				return WrapError(err)
+			}
+		} else {
+			rebootKeys = append(rebootKeys, "user_data")
+		}
+	}
+	// The running instance is stopped and started again to make these changes take effect.
+	if !d.Get("stopped_allowed").(bool) && Status(d.Get("status").(string)) == Running {
+		for _, key := range rebootKeys {
+			if ecsInstanceHasChange(d, key) {
+				return fmt.Errorf("'%s' can only be changed when the instance is stopped or the 'stopped_allowed' is true.", key)
+			}
+		}
+	}
+	// Fail early if the target instance type is sold out in the zone of the instance.
+	if ecsInstanceHasChange(d, "instance_type") && d.NewValueKnown("instance_type") {
+		ecsService := EcsService{meta.(*connectivity.AliyunClient)}
+		zoneId, validZones, err := ecsService.DescribeAvailableResources(d, meta, InstanceTypeResource)
+		if err != nil {
+			return err
+		}
+		if err := ecsService.InstanceTypeValidation(d.Get("instance_type").(string), zoneId, validZones); err != nil {
+			return err
+		}
+	}
+	if ecsInstanceHasChange(d, "system_disk_size") && !ecsInstanceHasChange(d, "image_id") {
+		return fmt.Errorf("'system_disk_size' isn't allowed to change separately. You can update it via renewing instance or replacing system disk.")
+	}
+	return nil
+}
+
+// ecsInstanceHasChange works like HasChange, but it ignores the arguments which are left to the launch template and
+// whose diff is suppressed by ecsLaunchTemplateDiffSuppressFunc.
+func ecsInstanceHasChange(d *schema.ResourceDiff, key string) bool {
+	if !d.HasChange(key) {
+		return false
+	}
+	if d.Get("launch_template_id").(string) == "" {
+		return true
+	}
+	_, n := d.GetChange(key)
+	value := fmt.Sprint(n)
+	return value != "" && value != ecsLaunchTemplateDefaults[key]
+}
+
+// slbListenerCustomizeDiffFunc checks the fields of the listener which depend on its protocol during plan.
+func slbListenerCustomizeDiffFunc(d *schema.ResourceDiff, meta interface{}) error {
+	protocol := Protocol(d.Get("protocol").(string))
+
+	if d.NewValueKnown("ssl_certificate_id") {
+		sslId := d.Get("ssl_certificate_id").(string)
+		if protocol == Https && sslId == "" {
+			return fmt.Errorf("'ssl_certificate_id': required field is not set when the protocol is 'https'.")
+		}
+		if protocol != Https && sslId != "" {
+			return fmt.Errorf("'ssl_certificate_id' can only be set when the protocol is 'https', got %s.", protocol)
+		}
+	}
+
+	if protocol != Http && protocol != Https || FlagType(d.Get("sticky_session").(string)) != OnFlag {
+		return nil
+	}
+	sessionType := StickySessionType(d.Get("sticky_session_type").(string))
+	switch sessionType {
+	case "":
+		return fmt.Errorf("'sticky_session_type': required field is not set when the StickySession is %s.", OnFlag)
+	case InsertStickySessionType:
+		if d.Get("cookie_timeout").(int) == 0 && d.NewValueKnown("cookie_timeout") {
+			return fmt.Errorf("'cookie_timeout': required field is not set when the StickySession is %s "+
+				"and StickySessionType is %s.", OnFlag, InsertStickySessionType)
+		}
+	case ServerStickySessionType:
+		if d.Get("cookie").(string) == "" && d.NewValueKnown("cookie") {
+			return fmt.Errorf("'cookie': required field is not set when the StickySession is %s "+
+				"and StickySessionType is %s.", OnFlag, ServerStickySessionType)
+		}
+	}
+	return nil
+}
+
+// csKubernetesCustomizeDiffFunc checks the lengths of the fields per availability zone during plan. The number of
+// the workers can only be increased, because the cluster is resized by adding the nodes.
+func csKubernetesCustomizeDiffFunc(d *schema.ResourceDiff, meta interface{}) error {
+	masterInstanceTypes := d.Get("master_instance_types").([]interface{})
+	workerInstanceTypes := d.Get("worker_instance_types").([]interface{})
+	if len(masterInstanceTypes) != len(workerInstanceTypes) {
+		return fmt.Errorf("length of fields `master_instance_types`, `worker_instance_types` must match")
+	}
+	if len(masterInstanceTypes) != 1 && len(masterInstanceTypes) != 3 {
+		return fmt.Errorf("length of fields `master_instance_types`, `worker_instance_types` should be either 3 (for MultiAZ) or 1 (for Single AZ)")
+	}
+
+	workerNumbers := d.Get("worker_numbers").([]interface{})
+	if d.NewValueKnown("worker_numbers") && len(workerNumbers) > 0 && len(workerNumbers) != len(workerInstanceTypes) {
+		return fmt.Errorf("length of fields `worker_numbers`, `worker_instance_types` must match")
+	}
+	if d.NewValueKnown("vswitch_ids") {
+		vswitchIds := d.Get("vswitch_ids").([]interface{})
+		if len(masterInstanceTypes) == 3 && len(vswitchIds) != 3 {
+			return fmt.Errorf("length of fields `vswitch_ids`, `worker_numbers` must be 3 for multiAZ clusters")
+		}
+		if len(vswitchIds) > 0 && len(workerNumbers) > 0 && len(vswitchIds) != len(workerNumbers) {
+			return fmt.Errorf("length of fields `vswitch_ids`, `worker_numbers` must match")
+		}
+	}
+
+	if d.Id() != "" && d.HasChange("worker_numbers") {
+		o, n := d.GetChange("worker_numbers")
+		oldNumbers, newNumbers := expandIntList(o.([]interface{})), expandIntList(n.([]interface{}))
+		for i := range oldNumbers {
+			if i < len(newNumbers) && newNumbers[i] < oldNumbers[i] {
+				return fmt.Errorf("'worker_numbers' can only be increased, got %d from %d.", newNumbers[i], oldNumbers[i])
+			}
+		}
+	}
+	return nil
+}
+
+// rdsInstanceCustomizeDiffFunc checks the changes of the DB instance which can not be applied during plan.
+func rdsInstanceCustomizeDiffFunc(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if o, n := d.GetChange("instance_storage"); n.(int) < o.(int) {
+		return fmt.Errorf("'instance_storage' can only be increased, got %d from %d.", n.(int), o.(int))
+	}
+
+	// The 'Prepaid' instance can not be deleted, so it can not be replaced either.
+	if o, _ := d.GetChange("instance_charge_type"); PayType(o.(string)) == Prepaid {
+		for _, key := range []string{"engine", "engine_version", "instance_charge_type", "zone_id", "vswitch_id"} {
+			if d.HasChange(key) && d.NewValueKnown(key) {
+				return fmt.Errorf("'%s' of the 'Prepaid' instance can not be changed, because the instance can not be replaced.", key)
+			}
+		}
+	}
+	return nil
+}
+
+// essScalingConfigurationCustomizeDiffFunc checks the data disks of the scaling configuration during plan.
+func essScalingConfigurationCustomizeDiffFunc(d *schema.ResourceDiff, meta interface{}) error {
+	for i, v := range d.Get("data_disk").([]interface{}) {
+		disk := v.(map[string]interface{})
+		if !d.NewValueKnown(fmt.Sprintf("data_disk.%d.snapshot_id", i)) {
+			continue
+		}
+		if disk["size"].(int) == 0 && disk["snapshot_id"].(string) == "" {
+			return fmt.Errorf("'data_disk.%d': one of 'size' and 'snapshot_id' must be set.", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package alicloud
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+type customizeDiffTestCase struct {
+	name        string
+	state       map[string]string
+	config      map[string]interface{}
+	expectedErr string
+	requiresNew bool
+}
+
+func testCustomizeDiff(t *testing.T, r *schema.Resource, cases []customizeDiffTestCase) {
+	for _, c := range cases {
+		raw, err := config.NewRawConfig(c.config)
+		if err != nil {
+			t.Fatalf("%s: %s", c.name, err)
+		}
+		var state *terraform.InstanceState
+		if c.state != nil {
+			state = &terraform.InstanceState{ID: "id", Attributes: c.state}
+		}
+
+		diff, err := r.Diff(state, terraform.NewResourceConfig(raw), nil)
+		if c.expectedErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.expectedErr) {
+				t.Fatalf("%s: expected the error %q, got %v", c.name, c.expectedErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", c.name, err)
+		}
+		// A diff creating the resource always requires a new one.
+		if c.state != nil && diff.RequiresNew() != c.requiresNew {
+			t.Fatalf("%s: expected requiring new to be %t, got %#v", c.name, c.requiresNew, diff)
+		}
+	}
+}
+
+func TestEcsInstanceCustomizeDiffFunc(t *testing.T) {
+	instance := map[string]interface{}{
+		"image_id":          "ubuntu_140405_64_40G_cloudinit_20161115.vhd",
+		"instance_type":     "ecs.n4.large",
+		"security_groups":   []interface{}{"sg-1"},
+		"availability_zone": "cn-beijing-a",
+	}
+	with := func(values map[string]interface{}) map[string]interface{} {
+		result := make(map[string]interface{})
+		for k, v := range instance {
+			result[k] = v
+		}
+		for k, v := range values {
+			result[k] = v
+		}
+		return result
+	}
+	state := map[string]string{
+		"image_id":             "ubuntu_140405_64_40G_cloudinit_20161115.vhd",
+		"instance_type":        "ecs.n4.large",
+		"instance_charge_type": string(PrePaid),
+		"system_disk_size":     "40",
+		"availability_zone":    "cn-beijing-a",
+	}
+	running := map[string]string{"status": string(Running)}
+	for k, v := range state {
+		running[k] = v
+	}
+	userData := map[string]string{"user_data": "echo foo", "system_disk_category": string(DiskCloudEfficiency)}
+	for k, v := range running {
+		userData[k] = v
+	}
+
+	reclaimed := map[string]string{"spot_interruption": SpotInstanceRecycling, "system_disk_category": string(DiskCloudEfficiency)}
+	for k, v := range running {
+		reclaimed[k] = v
+	}
+
+	testCustomizeDiff(t, resourceAliyunInstance(), []customizeDiffTestCase{
+		{
+			name:   "spot instance",
+			config: with(map[string]interface{}{"spot_strategy": string(SpotWithPriceLimit), "spot_price_limit": 1.002}),
+		},
+		{
+			name:        "spot prepaid instance",
+			config:      with(map[string]interface{}{"instance_charge_type": string(PrePaid), "spot_strategy": string(SpotWithPriceLimit)}),
+			expectedErr: "'spot_strategy' can only be set",
+		},
+		{
+			name:        "spot price limit without limit strategy",
+			config:      with(map[string]interface{}{"spot_price_limit": 1.002}),
+			expectedErr: "'spot_price_limit' can only be set",
+		},
+		{
+			name:        "weekly period",
+			config:      with(map[string]interface{}{"instance_charge_type": string(PrePaid), "period_unit": string(Week), "period": 5}),
+			expectedErr: "'period' must be in [1-4]",
+		},
+		{
+			name:   "launch template",
+			config: map[string]interface{}{"launch_template_id": "lt-1"},
+		},
+		{
+			name:        "missing image without launch template",
+			config:      map[string]interface{}{"instance_type": "ecs.n4.large", "security_groups": []interface{}{"sg-1"}},
+			expectedErr: "'image_id': required field is not set",
+		},
+		{
+			name:  "instance type without stopping",
+			state: running,
+			config: with(map[string]interface{}{
+				"instance_charge_type": string(PrePaid), "instance_type": "ecs.n4.xlarge", "stopped_allowed": false,
+			}),
+			expectedErr: "'instance_type' can only be changed when the instance is stopped",
+		},
+		{
+			name:        "stop charging prepaid instance",
+			config:      with(map[string]interface{}{"instance_charge_type": string(PrePaid), "stopped_mode": string(StopCharging)}),
+			expectedErr: "'stopped_mode' can only be StopCharging when",
+		},
+		{
+			name:        "stop charging classic instance",
+			config:      with(map[string]interface{}{"stopped_mode": string(StopCharging)}),
+			expectedErr: "'stopped_mode' can only be StopCharging for the VPC instance",
+		},
+		{
+			name:        "user data replacing",
+			state:       userData,
+			config:      with(map[string]interface{}{"instance_charge_type": string(PrePaid), "user_data": "echo bar"}),
+			requiresNew: true,
+		},
+		{
+			name:  "user data rebooting",
+			state: userData,
+			config: with(map[string]interface{}{
+				"instance_charge_type": string(PrePaid), "user_data": "echo bar", "reboot_on_user_data_change": true,
+			}),
+		},
+		{
+			name:  "user data rebooting without stopping",
+			state: userData,
+			config: with(map[string]interface{}{
+				"instance_charge_type": string(PrePaid), "user_data": "echo bar", "reboot_on_user_data_change": true,
+				"stopped_allowed": false,
+			}),
+			expectedErr: "'user_data' can only be changed when the instance is stopped",
+		},
+		{
+			name:        "reclaimed spot instance",
+			state:       reclaimed,
+			config:      with(map[string]interface{}{"instance_charge_type": string(PrePaid)}),
+			requiresNew: true,
+		},
+		{
+			name:        "system disk size",
+			state:       state,
+			config:      with(map[string]interface{}{"instance_charge_type": string(PrePaid), "system_disk_size": 80}),
+			expectedErr: "'system_disk_size' isn't allowed to change separately",
+		},
+	})
+}
+
+func TestSlbListenerCustomizeDiffFunc(t *testing.T) {
+	testCustomizeDiff(t, resourceAliyunSlbListener(), []customizeDiffTestCase{
+		{
+			name: "https listener",
+			config: map[string]interface{}{
+				"load_balancer_id": "lb-1", "frontend_port": 443, "backend_port": 80, "protocol": "https", "bandwidth": 10,
+				"ssl_certificate_id": "cert-1",
+			},
+		},
+		{
+			name: "https listener without certificate",
+			config: map[string]interface{}{
+				"load_balancer_id": "lb-1", "frontend_port": 443, "backend_port": 80, "protocol": "https", "bandwidth": 10,
+			},
+			expectedErr: "'ssl_certificate_id': required field is not set",
+		},
+		{
+			name: "http listener with certificate",
+			config: map[string]interface{}{
+				"load_balancer_id": "lb-1", "frontend_port": 80, "backend_port": 80, "protocol": "http", "bandwidth": 10,
+				"ssl_certificate_id": "cert-1",
+			},
+			expectedErr: "'ssl_certificate_id' can only be set",
+		},
+		{
+			name: "sticky session without cookie",
+			config: map[string]interface{}{
+				"load_balancer_id": "lb-1", "frontend_port": 80, "backend_port": 80, "protocol": "http", "bandwidth": 10,
+				"sticky_session": "on", "sticky_session_type": "server",
+			},
+			expectedErr: "'cookie': required field is not set",
+		},
+	})
+}
+
+func TestCsKubernetesCustomizeDiffFunc(t *testing.T) {
+	testCustomizeDiff(t, resourceAlicloudCSKubernetes(), []customizeDiffTestCase{
+		{
+			name: "multiple availability zones",
+			config: map[string]interface{}{
+				"master_instance_types": []interface{}{"ecs.n4.large", "ecs.n4.large", "ecs.n4.large"},
+				"worker_instance_types": []interface{}{"ecs.n4.large", "ecs.n4.large", "ecs.n4.large"},
+				"worker_numbers":        []interface{}{1, 1, 1},
+				"vswitch_ids":           []interface{}{"vsw-1", "vsw-2", "vsw-3"},
+			},
+		},
+		{
+			name: "worker numbers mismatching vswitches",
+			config: map[string]interface{}{
+				"master_instance_types": []interface{}{"ecs.n4.large"},
+				"worker_instance_types": []interface{}{"ecs.n4.large"},
+				"worker_numbers":        []interface{}{1},
+				"vswitch_ids":           []interface{}{"vsw-1", "vsw-2"},
+			},
+			expectedErr: "length of fields `vswitch_ids`, `worker_numbers` must match",
+		},
+		{
+			name: "master and worker instance types mismatching",
+			config: map[string]interface{}{
+				"master_instance_types": []interface{}{"ecs.n4.large", "ecs.n4.large", "ecs.n4.large"},
+				"worker_instance_types": []interface{}{"ecs.n4.large"},
+			},
+			expectedErr: "length of fields `master_instance_types`, `worker_instance_types` must match",
+		},
+		{
+			name: "decreasing workers",
+			state: map[string]string{
+				"master_instance_types.#": "1", "master_instance_types.0": "ecs.n4.large",
+				"worker_instance_types.#": "1", "worker_instance_types.0": "ecs.n4.large",
+				"worker_numbers.#": "1", "worker_numbers.0": "3",
+			},
+			config: map[string]interface{}{
+				"master_instance_types": []interface{}{"ecs.n4.large"},
+				"worker_instance_types": []interface{}{"ecs.n4.large"},
+				"worker_numbers":        []interface{}{2},
+			},
+			expectedErr: "'worker_numbers' can only be increased",
+		},
+	})
+}
+
+func TestRdsInstanceCustomizeDiffFunc(t *testing.T) {
+	state := map[string]string{
+		"engine": "MySQL", "engine_version": "5.6", "instance_type": "rds.mysql.s1.small", "instance_storage": "20",
+		"instance_charge_type": string(Prepaid), "period": "1", "zone_id": "cn-beijing-a",
+	}
+	testCustomizeDiff(t, resourceAlicloudDBInstance(), []customizeDiffTestCase{
+		{
+			name:  "increasing storage",
+			state: state,
+			config: map[string]interface{}{
+				"engine": "MySQL", "engine_version": "5.6", "instance_type": "rds.mysql.s1.small", "instance_storage": 30,
+				"instance_charge_type": string(Prepaid),
+			},
+		},
+		{
+			name:  "decreasing storage",
+			state: state,
+			config: map[string]interface{}{
+				"engine": "MySQL", "engine_version": "5.6", "instance_type": "rds.mysql.s1.small", "instance_storage": 10,
+				"instance_charge_type": string(Prepaid),
+			},
+			expectedErr: "'instance_storage' can only be increased",
+		},
+		{
+			name:  "replacing prepaid instance",
+			state: state,
+			config: map[string]interface{}{
+				"engine": "MySQL", "engine_version": "5.7", "instance_type": "rds.mysql.s1.small", "instance_storage": 20,
+				"instance_charge_type": string(Prepaid),
+			},
+			expectedErr: "'engine_version' of the 'Prepaid' instance can not be changed",
+		},
+	})
+}
+
+func TestEssScalingConfigurationCustomizeDiffFunc(t *testing.T) {
+	state := map[string]string{
+		"scaling_group_id": "asg-1", "image_id": "centos_7", "instance_type": "ecs.n4.large", "security_group_id": "sg-1",
+		"instance_name": "ESS-Instance", "internet_charge_type": "PayByBandwidth", "system_disk_category": "cloud_efficiency",
+	}
+	testCustomizeDiff(t, resourceAlicloudEssScalingConfiguration(), []customizeDiffTestCase{
+		{
+			name: "data disk without size",
+			config: map[string]interface{}{
+				"scaling_group_id": "asg-1", "image_id": "centos_7", "instance_type": "ecs.n4.large", "security_group_id": "sg-1",
+				"data_disk": []interface{}{map[string]interface{}{"category": "cloud_ssd"}},
+			},
+			expectedErr: "one of 'size' and 'snapshot_id' must be set",
+		},
+		{
+			name:  "instance name",
+			state: state,
+			config: map[string]interface{}{
+				"scaling_group_id": "asg-1", "image_id": "centos_7", "instance_type": "ecs.n4.large", "security_group_id": "sg-1",
+				"instance_name": "tf-test",
+			},
+			requiresNew: false,
+		},
+	})
+}
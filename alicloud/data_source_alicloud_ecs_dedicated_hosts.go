@@ -0,0 +1,225 @@
+package alicloud
+
+import (
+	"regexp"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudEcsDedicatedHosts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudEcsDedicatedHostsRead,
+
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				ForceNew: true,
+				MinItems: 1,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateNameRegex,
+				ForceNew:     true,
+			},
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"dedicated_host_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tags": tagsSchema(),
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"hosts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"availability_zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dedicated_host_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action_on_maintenance": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cores": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"sockets": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"supported_instance_type_families": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"instance_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": tagsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlicloudEcsDedicatedHostsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := ecs.CreateDescribeDedicatedHostsRequest()
+
+	if v, ok := d.GetOk("ids"); ok && len(v.([]interface{})) > 0 {
+		args.DedicatedHostIds = convertListToJsonString(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("availability_zone"); ok && v.(string) != "" {
+		args.ZoneId = v.(string)
+	}
+	if v, ok := d.GetOk("dedicated_host_type"); ok && v.(string) != "" {
+		args.DedicatedHostType = v.(string)
+	}
+	if v, ok := d.GetOk("status"); ok && v.(string) != "" {
+		args.Status = v.(string)
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		var tags []ecs.DescribeDedicatedHostsTag
+
+		for key, value := range v.(map[string]interface{}) {
+			tags = append(tags, ecs.DescribeDedicatedHostsTag{
+				Key:   key,
+				Value: value.(string),
+			})
+		}
+		args.Tag = &tags
+	}
+
+	var allHosts []ecs.DedicatedHost
+	args.PageSize = requests.NewInteger(PageSizeLarge)
+	args.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeDedicatedHosts(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "ecs_dedicated_hosts", args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		resp, _ := raw.(*ecs.DescribeDedicatedHostsResponse)
+
+		if resp == nil || len(resp.DedicatedHosts.DedicatedHost) < 1 {
+			break
+		}
+
+		allHosts = append(allHosts, resp.DedicatedHosts.DedicatedHost...)
+
+		if len(resp.DedicatedHosts.DedicatedHost) < PageSizeLarge {
+			break
+		}
+
+		if page, err := getNextpageNumber(args.PageNumber); err != nil {
+			return WrapError(err)
+		} else {
+			args.PageNumber = page
+		}
+	}
+
+	var filteredHosts []ecs.DedicatedHost
+	if v, ok := d.GetOk("name_regex"); ok && v.(string) != "" {
+		r := regexp.MustCompile(v.(string))
+		for _, host := range allHosts {
+			if r.MatchString(host.DedicatedHostName) {
+				filteredHosts = append(filteredHosts, host)
+			}
+		}
+	} else {
+		filteredHosts = allHosts
+	}
+	return dedicatedHostsDescriptionAttributes(d, filteredHosts)
+}
+
+func dedicatedHostsDescriptionAttributes(d *schema.ResourceData, hosts []ecs.DedicatedHost) error {
+	var ids []string
+	var s []map[string]interface{}
+	for _, host := range hosts {
+		var instanceIds []string
+		for _, instance := range host.Instances.Instance {
+			instanceIds = append(instanceIds, instance.InstanceId)
+		}
+		mapping := map[string]interface{}{
+			"id":                               host.DedicatedHostId,
+			"name":                             host.DedicatedHostName,
+			"description":                      host.Description,
+			"availability_zone":                host.ZoneId,
+			"dedicated_host_type":              host.DedicatedHostType,
+			"action_on_maintenance":            host.ActionOnMaintenance,
+			"status":                           host.Status,
+			"cores":                            host.Cores,
+			"sockets":                          host.Sockets,
+			"supported_instance_type_families": host.SupportedInstanceTypeFamilies.SupportedInstanceTypeFamily,
+			"instance_ids":                     instanceIds,
+			"creation_time":                    host.CreationTime,
+			"tags":                             tagsToMap(host.Tags.Tag),
+		}
+
+		ids = append(ids, host.DedicatedHostId)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("hosts", s); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
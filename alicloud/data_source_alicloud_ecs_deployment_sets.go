@@ -0,0 +1,161 @@
+package alicloud
+
+import (
+	"regexp"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudEcsDeploymentSets() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudEcsDeploymentSetsRead,
+
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				ForceNew: true,
+				MinItems: 1,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateNameRegex,
+				ForceNew:     true,
+			},
+			"strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(DeploymentSetAvailability)}),
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"sets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"strategy": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlicloudEcsDeploymentSetsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := ecs.CreateDescribeDeploymentSetsRequest()
+
+	if v, ok := d.GetOk("ids"); ok && len(v.([]interface{})) > 0 {
+		args.DeploymentSetIds = convertListToJsonString(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("strategy"); ok && v.(string) != "" {
+		args.Strategy = v.(string)
+	}
+
+	var allSets []ecs.DeploymentSet
+	args.PageSize = requests.NewInteger(PageSizeLarge)
+	args.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeDeploymentSets(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "ecs_deployment_sets", args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		resp, _ := raw.(*ecs.DescribeDeploymentSetsResponse)
+
+		if resp == nil || len(resp.DeploymentSets.DeploymentSet) < 1 {
+			break
+		}
+
+		allSets = append(allSets, resp.DeploymentSets.DeploymentSet...)
+
+		if len(resp.DeploymentSets.DeploymentSet) < PageSizeLarge {
+			break
+		}
+
+		if page, err := getNextpageNumber(args.PageNumber); err != nil {
+			return WrapError(err)
+		} else {
+			args.PageNumber = page
+		}
+	}
+
+	var filteredSets []ecs.DeploymentSet
+	if v, ok := d.GetOk("name_regex"); ok && v.(string) != "" {
+		r := regexp.MustCompile(v.(string))
+		for _, set := range allSets {
+			if r.MatchString(set.DeploymentSetName) {
+				filteredSets = append(filteredSets, set)
+			}
+		}
+	} else {
+		filteredSets = allSets
+	}
+	return deploymentSetsDescriptionAttributes(d, filteredSets)
+}
+
+func deploymentSetsDescriptionAttributes(d *schema.ResourceData, sets []ecs.DeploymentSet) error {
+	var ids []string
+	var s []map[string]interface{}
+	for _, set := range sets {
+		mapping := map[string]interface{}{
+			"id":            set.DeploymentSetId,
+			"name":          set.DeploymentSetName,
+			"description":   set.DeploymentSetDescription,
+			"strategy":      set.DeploymentStrategy,
+			"instance_ids":  set.InstanceIds.InstanceId,
+			"creation_time": set.CreationTime,
+		}
+
+		ids = append(ids, set.DeploymentSetId)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("sets", s); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
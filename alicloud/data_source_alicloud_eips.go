@@ -31,6 +31,7 @@ func dataSourceAlicloudEips() *schema.Resource {
 				ForceNew: true,
 				MinItems: 1,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -104,6 +105,16 @@ func dataSourceAlicloudEipsRead(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
+	var taggedIds map[string]bool
+	if v, ok := d.GetOk("tags"); ok && len(v.(map[string]interface{})) > 0 {
+		tagService := TagService{client}
+		ids, err := tagService.DescribeTaggedResourceIds(connectivity.VPCCode, TagResourceEip, v.(map[string]interface{}))
+		if err != nil {
+			return WrapError(err)
+		}
+		taggedIds = ids
+	}
+
 	var allEips []vpc.EipAddress
 
 	for {
@@ -129,6 +140,9 @@ func dataSourceAlicloudEipsRead(d *schema.ResourceData, meta interface{}) error
 					continue
 				}
 			}
+			if taggedIds != nil && !taggedIds[e.AllocationId] {
+				continue
+			}
 			allEips = append(allEips, e)
 		}
 
@@ -0,0 +1,358 @@
+package alicloud
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudInstanceTypeRecommendations() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudInstanceTypeRecommendationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"availability_zones": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"instance_type_family": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateInstanceType,
+			},
+			"cpu_core_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+			"memory_size_min": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				ForceNew: true,
+			},
+			"memory_size_max": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+				ForceNew: true,
+			},
+			"architecture": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"X86", "ARM"}),
+			},
+			"exclude_gpu": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"instance_charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      PostPaid,
+				ValidateFunc: validateInstanceChargeType,
+			},
+			"spot_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      NoSpot,
+				ValidateFunc: validateInstanceSpotStrategy,
+			},
+			"max_candidates": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      20,
+				ValidateFunc: validateIntegerInRange(1, 100),
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"instance_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"recommendations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"zone_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"family": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpu_core_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"memory_size": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"architecture": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"gpu_amount": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"price": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"spot_price": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"currency": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type instanceTypeRecommendation struct {
+	spec      instanceTypeSpec
+	zoneId    string
+	status    string
+	price     ecs.Price
+	spotPrice float64
+}
+
+// cost is the price which the recommendation is ranked by, and it is the spot price for the spot instance.
+func (r instanceTypeRecommendation) cost(spot bool) float64 {
+	if spot {
+		return r.spotPrice
+	}
+	return r.price.TradePrice
+}
+
+func dataSourceAlicloudInstanceTypeRecommendationsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	_, validZones, err := ecsService.DescribeAvailableResources(d, meta, InstanceTypeResource)
+	if err != nil {
+		return WrapError(err)
+	}
+	zoneIds := make(map[string]bool)
+	for _, zoneId := range expandStringList(d.Get("availability_zones").([]interface{})) {
+		zoneIds[zoneId] = true
+	}
+	// The stock status of each instance type in each zone
+	stocks := make(map[string]map[string]string)
+	for _, zone := range validZones {
+		if len(zoneIds) > 0 && !zoneIds[zone.ZoneId] {
+			continue
+		}
+		for _, r := range zone.AvailableResources.AvailableResource {
+			if r.Type != string(InstanceTypeResource) {
+				continue
+			}
+			for _, t := range r.SupportedResources.SupportedResource {
+				if stocks[t.Value] == nil {
+					stocks[t.Value] = make(map[string]string)
+				}
+				stocks[t.Value][zone.ZoneId] = t.Status
+			}
+		}
+	}
+
+	specs, err := ecsService.DescribeInstanceTypeSpecs(strings.TrimSpace(d.Get("instance_type_family").(string)))
+	if err != nil {
+		return WrapError(err)
+	}
+
+	cpu := d.Get("cpu_core_count").(int)
+	memMin := d.Get("memory_size_min").(float64)
+	memMax := d.Get("memory_size_max").(float64)
+	architecture := d.Get("architecture").(string)
+	chargeType := PayType(d.Get("instance_charge_type").(string))
+	spotStrategy := SpotStrategyType(d.Get("spot_strategy").(string))
+	var candidates []instanceTypeSpec
+	for _, spec := range specs {
+		if len(stocks[spec.InstanceTypeId]) < 1 {
+			continue
+		}
+		if cpu > 0 && spec.CpuCoreCount != cpu {
+			continue
+		}
+		if (memMin > 0 && spec.MemorySize < memMin) || (memMax > 0 && spec.MemorySize > memMax) {
+			continue
+		}
+		if architecture != "" && !strings.EqualFold(spec.CpuArchitecture, architecture) {
+			continue
+		}
+		if d.Get("exclude_gpu").(bool) && spec.GPUAmount > 0 {
+			continue
+		}
+		candidates = append(candidates, spec)
+	}
+
+	// Each candidate costs price requests, so only the smallest ones, which are usually the cheapest, are priced.
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.CpuCoreCount != b.CpuCoreCount {
+			return a.CpuCoreCount < b.CpuCoreCount
+		}
+		if a.MemorySize != b.MemorySize {
+			return a.MemorySize < b.MemorySize
+		}
+		return a.InstanceTypeId < b.InstanceTypeId
+	})
+	if max := d.Get("max_candidates").(int); len(candidates) > max {
+		candidates = candidates[:max]
+	}
+
+	// The candidates are priced concurrently, and the concurrent requests are still limited by max_concurrent_requests
+	// of the provider.
+	results := make([][]instanceTypeRecommendation, len(candidates))
+	errs := make([]error, len(candidates))
+	var wg sync.WaitGroup
+	for i, spec := range candidates {
+		wg.Add(1)
+		go func(i int, spec instanceTypeSpec) {
+			defer wg.Done()
+			results[i], errs[i] = describeInstanceTypeRecommendations(&ecsService, spec, stocks[spec.InstanceTypeId], chargeType, spotStrategy)
+		}(i, spec)
+	}
+	wg.Wait()
+
+	var recommendations []instanceTypeRecommendation
+	for i := range candidates {
+		if errs[i] != nil {
+			return WrapError(errs[i])
+		}
+		recommendations = append(recommendations, results[i]...)
+	}
+
+	// The purchasable ones are ranked first, and then the cheaper ones.
+	spot := spotStrategy != NoSpot
+	sort.Slice(recommendations, func(i, j int) bool {
+		a, b := recommendations[i], recommendations[j]
+		if (a.status == string(Available)) != (b.status == string(Available)) {
+			return a.status == string(Available)
+		}
+		if a.cost(spot) != b.cost(spot) {
+			return a.cost(spot) < b.cost(spot)
+		}
+		if a.spec.InstanceTypeId != b.spec.InstanceTypeId {
+			return a.spec.InstanceTypeId < b.spec.InstanceTypeId
+		}
+		return a.zoneId < b.zoneId
+	})
+	return instanceTypeRecommendationsDescriptionAttributes(d, recommendations)
+}
+
+// describeInstanceTypeRecommendations prices the instance type in the zones where it is in stock. The price is the same in
+// all of the zones, while the spot price is not, and the spot prices of all of the zones are queried at once.
+func describeInstanceTypeRecommendations(ecsService *EcsService, spec instanceTypeSpec, stocks map[string]string, chargeType PayType, spotStrategy SpotStrategyType) ([]instanceTypeRecommendation, error) {
+	price, err := ecsService.DescribeInstanceTypePrice(spec.InstanceTypeId, chargeType)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+
+	spotPrices := make(map[string]ecs.SpotPriceType)
+	if spotStrategy != NoSpot {
+		args := ecs.CreateDescribeSpotPriceHistoryRequest()
+		args.InstanceType = spec.InstanceTypeId
+		args.NetworkType = strings.ToLower(string(Vpc))
+		history, _, err := ecsService.DescribeSpotPriceHistory(args)
+		if err != nil {
+			return nil, WrapError(err)
+		}
+		// The latest spot price in each zone is the current one.
+		for _, spotPrice := range history {
+			if latest, ok := spotPrices[spotPrice.ZoneId]; !ok || spotPrice.Timestamp > latest.Timestamp {
+				spotPrices[spotPrice.ZoneId] = spotPrice
+			}
+		}
+	}
+
+	var recommendations []instanceTypeRecommendation
+	for zoneId, status := range stocks {
+		recommendations = append(recommendations, instanceTypeRecommendation{
+			spec:      spec,
+			zoneId:    zoneId,
+			status:    status,
+			price:     price,
+			spotPrice: spotPrices[zoneId].SpotPrice,
+		})
+	}
+	return recommendations, nil
+}
+
+func instanceTypeRecommendationsDescriptionAttributes(d *schema.ResourceData, recommendations []instanceTypeRecommendation) error {
+	var ids []string
+	var instanceTypes []string
+	var s []map[string]interface{}
+	seen := make(map[string]bool)
+	for _, r := range recommendations {
+		mapping := map[string]interface{}{
+			"instance_type":  r.spec.InstanceTypeId,
+			"zone_id":        r.zoneId,
+			"family":         r.spec.InstanceTypeFamily,
+			"cpu_core_count": r.spec.CpuCoreCount,
+			"memory_size":    r.spec.MemorySize,
+			"architecture":   r.spec.CpuArchitecture,
+			"gpu_amount":     r.spec.GPUAmount,
+			"status":         r.status,
+			"price":          r.price.TradePrice,
+			"spot_price":     r.spotPrice,
+			"currency":       r.price.Currency,
+		}
+		// The instance types are listed in the order of their best ranked recommendations which can be purchased.
+		if r.status == string(Available) && !seen[r.spec.InstanceTypeId] {
+			seen[r.spec.InstanceTypeId] = true
+			instanceTypes = append(instanceTypes, r.spec.InstanceTypeId)
+		}
+
+		ids = append(ids, r.spec.InstanceTypeId+":"+r.zoneId)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("recommendations", s); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("instance_types", instanceTypes); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
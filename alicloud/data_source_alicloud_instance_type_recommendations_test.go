@@ -0,0 +1,145 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccAlicloudInstanceTypeRecommendationsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckAlicloudInstanceTypeRecommendationsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlicloudDataSourceID("data.alicloud_instance_type_recommendations.default"),
+					resource.TestCheckResourceAttrSet("data.alicloud_instance_type_recommendations.default", "instance_types.0"),
+					resource.TestCheckResourceAttr("data.alicloud_instance_type_recommendations.default", "recommendations.0.cpu_core_count", "2"),
+					resource.TestCheckResourceAttr("data.alicloud_instance_type_recommendations.default", "recommendations.0.gpu_amount", "0"),
+					resource.TestCheckResourceAttr("data.alicloud_instance_type_recommendations.default", "recommendations.0.status", string(Available)),
+					resource.TestCheckResourceAttrSet("data.alicloud_instance_type_recommendations.default", "recommendations.0.zone_id"),
+					resource.TestCheckResourceAttrSet("data.alicloud_instance_type_recommendations.default", "recommendations.0.price"),
+					resource.TestCheckResourceAttrSet("data.alicloud_instance_type_recommendations.default", "recommendations.0.currency"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudInstanceTypeRecommendationsDataSource_rank ranks the instance types of the fake API server, where
+// the cheaper one is sold out in the second zone. The spot instances are ranked by the latest spot prices instead, and
+// only the smallest instance types are priced when the candidates are capped.
+func TestUnitAlicloudInstanceTypeRecommendationsDataSource_rank(t *testing.T) {
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	describe := server.handlers["ECS.DescribeAvailableResource"]
+	server.Handle("ECS.DescribeAvailableResource", func(request *fakeApiRequest) (interface{}, error) {
+		response, err := describe(request)
+		if err != nil || request.Params.Get("DestinationResource") != string(InstanceTypeResource) {
+			return response, err
+		}
+		for _, zone := range response.(fakeObject)["AvailableZones"].(fakeObject)["AvailableZone"].([]fakeObject) {
+			if zone["ZoneId"] != fakeApiZones[1] {
+				continue
+			}
+			for _, r := range zone["AvailableResources"].(fakeObject)["AvailableResource"].([]fakeObject) {
+				for _, t := range r["SupportedResources"].(fakeObject)["SupportedResource"].([]fakeObject) {
+					if t["Value"] == fakeApiInstanceTypes[1] {
+						t["Status"] = string(SoldOut)
+					}
+				}
+			}
+		}
+		return response, nil
+	})
+
+	name := "data.alicloud_instance_type_recommendations.default"
+	spot := "data.alicloud_instance_type_recommendations.spot"
+	capped := "data.alicloud_instance_type_recommendations.capped"
+	resource.UnitTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckAlicloudInstanceTypeRecommendationsDataSourceRank,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlicloudDataSourceID(name),
+					resource.TestCheckResourceAttr(name, "instance_types.#", "2"),
+					resource.TestCheckResourceAttr(name, "instance_types.0", fakeApiInstanceTypes[1]),
+					resource.TestCheckResourceAttr(name, "instance_types.1", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr(name, "recommendations.#", "4"),
+					resource.TestCheckResourceAttr(name, "recommendations.0.instance_type", fakeApiInstanceTypes[1]),
+					resource.TestCheckResourceAttr(name, "recommendations.0.zone_id", fakeApiZones[0]),
+					resource.TestCheckResourceAttr(name, "recommendations.0.status", string(Available)),
+					resource.TestCheckResourceAttr(name, "recommendations.0.price", "0.375"),
+					resource.TestCheckResourceAttr(name, "recommendations.0.currency", "CNY"),
+					resource.TestCheckResourceAttr(name, "recommendations.0.architecture", "X86"),
+					resource.TestCheckResourceAttr(name, "recommendations.0.cpu_core_count", "2"),
+					resource.TestCheckResourceAttr(name, "recommendations.0.memory_size", "4"),
+					resource.TestCheckResourceAttr(name, "recommendations.1.instance_type", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr(name, "recommendations.1.zone_id", fakeApiZones[0]),
+					resource.TestCheckResourceAttr(name, "recommendations.1.price", "0.5"),
+					resource.TestCheckResourceAttr(name, "recommendations.2.instance_type", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr(name, "recommendations.2.zone_id", fakeApiZones[1]),
+					resource.TestCheckResourceAttr(name, "recommendations.3.instance_type", fakeApiInstanceTypes[1]),
+					resource.TestCheckResourceAttr(name, "recommendations.3.zone_id", fakeApiZones[1]),
+					resource.TestCheckResourceAttr(name, "recommendations.3.status", string(SoldOut)),
+					resource.TestCheckResourceAttr(spot, "recommendations.#", "2"),
+					resource.TestCheckResourceAttr(spot, "recommendations.0.instance_type", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr(spot, "recommendations.0.spot_price", fmt.Sprint(fakeApiSpotPrices[len(fakeApiSpotPrices)-1])),
+					resource.TestCheckResourceAttr(spot, "recommendations.1.instance_type", fakeApiInstanceTypes[1]),
+					resource.TestCheckResourceAttr(capped, "instance_types.#", "1"),
+					resource.TestCheckResourceAttr(capped, "instance_types.0", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr(capped, "recommendations.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckAlicloudInstanceTypeRecommendationsDataSourceEmpty,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAlicloudDataSourceID(name),
+					resource.TestCheckResourceAttr(name, "instance_types.#", "0"),
+					resource.TestCheckResourceAttr(name, "recommendations.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckAlicloudInstanceTypeRecommendationsDataSourceConfig = `
+data "alicloud_instance_type_recommendations" "default" {
+  cpu_core_count  = 2
+  memory_size_min = 4
+  memory_size_max = 8
+  exclude_gpu     = true
+}
+`
+
+const testAccCheckAlicloudInstanceTypeRecommendationsDataSourceRank = `
+data "alicloud_instance_type_recommendations" "default" {
+  cpu_core_count  = 2
+  memory_size_min = 4
+  memory_size_max = 8
+  architecture    = "X86"
+  exclude_gpu     = true
+}
+
+data "alicloud_instance_type_recommendations" "spot" {
+  availability_zones = ["cn-beijing-a"]
+  spot_strategy      = "SpotAsPriceGo"
+}
+
+data "alicloud_instance_type_recommendations" "capped" {
+  max_candidates = 1
+}
+`
+
+const testAccCheckAlicloudInstanceTypeRecommendationsDataSourceEmpty = `
+data "alicloud_instance_type_recommendations" "default" {
+  architecture = "ARM"
+}
+`
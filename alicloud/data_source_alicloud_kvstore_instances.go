@@ -156,6 +156,16 @@ func dataSourceAlicloudKVStoreInstancesRead(d *schema.ResourceData, meta interfa
 	args.PageSize = requests.NewInteger(PageSizeLarge)
 	args.PageNumber = requests.NewInteger(1)
 
+	var taggedIds map[string]bool
+	if v, ok := d.GetOk("tags"); ok && len(v.(map[string]interface{})) > 0 {
+		tagService := TagService{client}
+		ids, err := tagService.DescribeTaggedResourceIds(connectivity.KVSTORECode, TagResourceKVStoreInstance, v.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		taggedIds = ids
+	}
+
 	var dbi []r_kvstore.KVStoreInstance
 
 	var nameRegex *regexp.Regexp
@@ -183,6 +193,9 @@ func dataSourceAlicloudKVStoreInstancesRead(d *schema.ResourceData, meta interfa
 					continue
 				}
 			}
+			if taggedIds != nil && !taggedIds[item.InstanceId] {
+				continue
+			}
 			dbi = append(dbi, item)
 		}
 
@@ -20,6 +20,7 @@ func dataSourceAlicloudOssBuckets() *schema.Resource {
 				ValidateFunc: validateNameRegex,
 				ForceNew:     true,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -245,6 +246,29 @@ func dataSourceAlicloudOssBucketsRead(d *schema.ResourceData, meta interface{})
 		filteredBucketsTemp = allBuckets
 	}
 
+	// OSS does not support listing the buckets by tags, so the tags of each bucket are compared.
+	if v, ok := d.GetOk("tags"); ok && len(v.(map[string]interface{})) > 0 {
+		ossService := OssService{client}
+		var taggedBuckets []oss.BucketProperties
+		for _, bucket := range filteredBucketsTemp {
+			tags, err := ossService.DescribeOssBucketTags(bucket.Name)
+			if err != nil {
+				return err
+			}
+			matched := true
+			for key, value := range v.(map[string]interface{}) {
+				if tagValue, ok := tags[key]; !ok || tagValue != value.(string) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				taggedBuckets = append(taggedBuckets, bucket)
+			}
+		}
+		filteredBucketsTemp = taggedBuckets
+	}
+
 	return bucketsDescriptionAttributes(d, filteredBucketsTemp, meta)
 }
 
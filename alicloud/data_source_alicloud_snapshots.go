@@ -0,0 +1,231 @@
+package alicloud
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudSnapshotsRead,
+
+		Schema: map[string]*schema.Schema{
+			"ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				ForceNew: true,
+				MinItems: 1,
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateNameRegex,
+				ForceNew:     true,
+			},
+			"disk_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"instance_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(SnapshotTypeAuto), string(SnapshotTypeUser), string(SnapshotTypeAll)}),
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(SnapshotProgressing), string(SnapshotAccomplished), string(SnapshotFailed)}),
+			},
+			"tags": tagsSchema(),
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"snapshots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"disk_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_disk_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_disk_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"progress": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"usage": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"encrypted": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"retention_days": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"creation_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": tagsSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlicloudSnapshotsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := ecs.CreateDescribeSnapshotsRequest()
+
+	if v, ok := d.GetOk("ids"); ok && len(v.([]interface{})) > 0 {
+		args.SnapshotIds = convertListToJsonString(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("disk_id"); ok && v.(string) != "" {
+		args.DiskId = v.(string)
+	}
+	if v, ok := d.GetOk("instance_id"); ok && v.(string) != "" {
+		args.InstanceId = v.(string)
+	}
+	if v, ok := d.GetOk("type"); ok && v.(string) != "" {
+		args.SnapshotType = v.(string)
+	}
+	if v, ok := d.GetOk("status"); ok && v.(string) != "" {
+		args.Status = v.(string)
+	}
+	if v, ok := d.GetOk("tags"); ok {
+		var tags []ecs.DescribeSnapshotsTag
+
+		for key, value := range v.(map[string]interface{}) {
+			tags = append(tags, ecs.DescribeSnapshotsTag{
+				Key:   key,
+				Value: value.(string),
+			})
+		}
+		args.Tag = &tags
+	}
+
+	var allSnapshots []ecs.Snapshot
+	args.PageSize = requests.NewInteger(PageSizeLarge)
+	args.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeSnapshots(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DataDefaultErrorMsg, "snapshots", args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		resp, _ := raw.(*ecs.DescribeSnapshotsResponse)
+
+		if resp == nil || len(resp.Snapshots.Snapshot) < 1 {
+			break
+		}
+
+		allSnapshots = append(allSnapshots, resp.Snapshots.Snapshot...)
+
+		if len(resp.Snapshots.Snapshot) < PageSizeLarge {
+			break
+		}
+
+		if page, err := getNextpageNumber(args.PageNumber); err != nil {
+			return WrapError(err)
+		} else {
+			args.PageNumber = page
+		}
+	}
+
+	var filteredSnapshots []ecs.Snapshot
+	if v, ok := d.GetOk("name_regex"); ok && v.(string) != "" {
+		r := regexp.MustCompile(v.(string))
+		for _, snapshot := range allSnapshots {
+			if r.MatchString(snapshot.SnapshotName) {
+				filteredSnapshots = append(filteredSnapshots, snapshot)
+			}
+		}
+	} else {
+		filteredSnapshots = allSnapshots
+	}
+	return snapshotsDescriptionAttributes(d, filteredSnapshots)
+}
+
+func snapshotsDescriptionAttributes(d *schema.ResourceData, snapshots []ecs.Snapshot) error {
+	var ids []string
+	var s []map[string]interface{}
+	for _, snapshot := range snapshots {
+		size, _ := strconv.Atoi(snapshot.SourceDiskSize)
+		mapping := map[string]interface{}{
+			"id":               snapshot.SnapshotId,
+			"name":             snapshot.SnapshotName,
+			"description":      snapshot.Description,
+			"disk_id":          snapshot.SourceDiskId,
+			"source_disk_type": snapshot.SourceDiskType,
+			"source_disk_size": size,
+			"status":           snapshot.Status,
+			"progress":         snapshot.Progress,
+			"usage":            snapshot.Usage,
+			"encrypted":        snapshot.Encrypted,
+			"retention_days":   snapshot.RetentionDays,
+			"creation_time":    snapshot.CreationTime,
+			"tags":             tagsToMap(snapshot.Tags.Tag),
+		}
+
+		ids = append(ids, snapshot.SnapshotId)
+		s = append(s, mapping)
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	if err := d.Set("snapshots", s); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package alicloud
+
+import (
+	"math"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func dataSourceAlicloudSpotPriceHistory() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAlicloudSpotPriceHistoryRead,
+
+		Schema: map[string]*schema.Schema{
+			"instance_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"zone_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"network_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "vpc",
+				ValidateFunc: validateAllowedStringValue([]string{"vpc", "classic"}),
+			},
+			"io_optimized": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "optimized",
+				ValidateFunc: validateAllowedStringValue([]string{"optimized", "none"}),
+			},
+			"os_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "linux",
+				ValidateFunc: validateAllowedStringValue([]string{"linux", "windows"}),
+			},
+			// The API returns the prices in the last 3 hours when the time range is not set, and it can be 30 days at most.
+			"start_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUTCTime,
+			},
+			"end_time": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateUTCTime,
+			},
+			"output_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// Computed values
+			"currency": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"min_price": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"avg_price": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"max_price": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"prices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"zone_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"instance_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"network_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"io_optimized": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"spot_price": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"origin_price": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAlicloudSpotPriceHistoryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateDescribeSpotPriceHistoryRequest()
+	args.InstanceType = d.Get("instance_type").(string)
+	args.NetworkType = d.Get("network_type").(string)
+	args.IoOptimized = d.Get("io_optimized").(string)
+	args.OSType = d.Get("os_type").(string)
+	if v, ok := d.GetOk("zone_id"); ok && v.(string) != "" {
+		args.ZoneId = v.(string)
+	}
+	if v, ok := d.GetOk("start_time"); ok && v.(string) != "" {
+		args.StartTime = v.(string)
+	}
+	if v, ok := d.GetOk("end_time"); ok && v.(string) != "" {
+		args.EndTime = v.(string)
+	}
+
+	allPrices, currency, err := ecsService.DescribeSpotPriceHistory(args)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	return spotPriceHistoryDescriptionAttributes(d, currency, allPrices)
+}
+
+func spotPriceHistoryDescriptionAttributes(d *schema.ResourceData, currency string, prices []ecs.SpotPriceType) error {
+	var ids []string
+	var s []map[string]interface{}
+	min, max, sum := math.MaxFloat64, 0.0, 0.0
+	for _, price := range prices {
+		mapping := map[string]interface{}{
+			"zone_id":       price.ZoneId,
+			"instance_type": price.InstanceType,
+			"network_type":  price.NetworkType,
+			"io_optimized":  price.IoOptimized,
+			"timestamp":     price.Timestamp,
+			"spot_price":    price.SpotPrice,
+			"origin_price":  price.OriginPrice,
+		}
+		min = math.Min(min, price.SpotPrice)
+		max = math.Max(max, price.SpotPrice)
+		sum += price.SpotPrice
+
+		ids = append(ids, price.ZoneId+":"+price.Timestamp)
+		s = append(s, mapping)
+	}
+	avg := 0.0
+	if len(prices) > 0 {
+		avg = sum / float64(len(prices))
+	} else {
+		min = 0
+	}
+
+	d.SetId(dataResourceIdHash(ids))
+	d.Set("currency", currency)
+	d.Set("min_price", min)
+	d.Set("avg_price", avg)
+	d.Set("max_price", max)
+	if err := d.Set("prices", s); err != nil {
+		return WrapError(err)
+	}
+
+	// create a json file in current directory and write data source to it.
+	if output, ok := d.GetOk("output_file"); ok && output.(string) != "" {
+		writeToFile(output.(string), s)
+	}
+	return nil
+}
@@ -40,6 +40,7 @@ func dataSourceAlicloudVpcs() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -110,6 +111,16 @@ func dataSourceAlicloudVpcsRead(d *schema.ResourceData, meta interface{}) error
 	args.PageSize = requests.NewInteger(PageSizeLarge)
 	args.PageNumber = requests.NewInteger(1)
 
+	var taggedIds map[string]bool
+	if v, ok := d.GetOk("tags"); ok && len(v.(map[string]interface{})) > 0 {
+		tagService := TagService{client}
+		ids, err := tagService.DescribeTaggedResourceIds(connectivity.VPCCode, TagResourceVpc, v.(map[string]interface{}))
+		if err != nil {
+			return WrapError(err)
+		}
+		taggedIds = ids
+	}
+
 	var allVpcs []vpc.Vpc
 	invoker := NewInvoker()
 	for {
@@ -168,6 +179,10 @@ func dataSourceAlicloudVpcsRead(d *schema.ResourceData, meta interface{}) error
 			continue
 		}
 
+		if taggedIds != nil && !taggedIds[v.VpcId] {
+			continue
+		}
+
 		request := vpc.CreateDescribeVRoutersRequest()
 		request.VRouterId = v.VRouterId
 		request.RegionId = string(client.Region)
@@ -41,6 +41,7 @@ func dataSourceAlicloudVSwitches() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"tags": tagsSchema(),
 			"output_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -119,6 +120,16 @@ func dataSourceAlicloudVSwitchesRead(d *schema.ResourceData, meta interface{}) e
 			nameRegex = r
 		}
 	}
+	var taggedIds map[string]bool
+	if v, ok := d.GetOk("tags"); ok && len(v.(map[string]interface{})) > 0 {
+		tagService := TagService{client}
+		ids, err := tagService.DescribeTaggedResourceIds(connectivity.VPCCode, TagResourceVSwitch, v.(map[string]interface{}))
+		if err != nil {
+			return WrapError(err)
+		}
+		taggedIds = ids
+	}
+
 	invoker := NewInvoker()
 	for {
 		var raw interface{}
@@ -150,6 +161,10 @@ func dataSourceAlicloudVSwitchesRead(d *schema.ResourceData, meta interface{}) e
 					continue
 				}
 			}
+
+			if taggedIds != nil && !taggedIds[vsw.VSwitchId] {
+				continue
+			}
 			allVSwitches = append(allVSwitches, vsw)
 		}
 
@@ -167,6 +167,22 @@ func ecsInternetDiffSuppressFunc(k, old, new string, d *schema.ResourceData) boo
 	return true
 }
 
+// The defaults of the instance arguments which are taken from the launch template when they are not set
+var ecsLaunchTemplateDefaults = map[string]string{
+	"instance_name":              "ECS-Instance",
+	"internet_max_bandwidth_out": "0",
+	"system_disk_category":       string(DiskCloudEfficiency),
+	"system_disk_size":           "40",
+	"security_groups.#":          "0",
+}
+
+func ecsLaunchTemplateDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if d.Get("launch_template_id").(string) == "" {
+		return false
+	}
+	return new == "" || new == ecsLaunchTemplateDefaults[k]
+}
+
 func ecsPostPaidDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
 	return common.InstanceChargeType(d.Get("instance_charge_type").(string)) == common.PostPaid
 }
@@ -28,6 +28,21 @@ const (
 	MessageInstanceNotFound = "instance is not found"
 	EcsThrottling           = "Throttling"
 	EcsInternalError        = "InternalError"
+	// image
+	InvalidImageIdNotFound = "InvalidImageId.NotFound"
+	IncorrectImageStatus   = "IncorrectImageStatus"
+	// snapshot
+	InvalidSnapshotIdNotFound = "InvalidSnapshotId.NotFound"
+	IncorrectSnapshotStatus   = "IncorrectSnapshotStatus"
+	// launch template
+	InvalidLaunchTemplateNotFound = "InvalidLaunchTemplate.NotFound"
+	// network interface
+	InvalidEcsNetworkInterfaceIdNotFound = "InvalidEcsNetworkInterfaceId.NotFound"
+	// dedicated host
+	InvalidDedicatedHostIdNotFound = "InvalidDedicatedHostId.NotFound"
+	IncorrectDedicatedHostStatus   = "IncorrectDedicatedHostStatus"
+	// deployment set
+	InvalidDeploymentSetIdNotFound = "InvalidDeploymentSetId.NotFound"
 	// disk
 	InternalError       = "InternalError"
 	DependencyViolation = "DependencyViolation"
@@ -78,6 +93,9 @@ const (
 	ForbiddenVpcNotFound = "Forbidden.VpcNotFound"
 	Throttling           = "Throttling"
 	IncorrectVpcStatus   = "IncorrectVpcStatus"
+	// ipv6 gateway
+	InvalidIpv6GatewayIdNotFound        = "InvalidIpv6GatewayId.NotFound"
+	InvalidIpv6EgressOnlyRuleIdNotFound = "InvalidIpv6EgressOnlyRuleId.NotFound"
 
 	//apigatway
 	ApiGroupNotFound      = "NotFoundApiGroup"
@@ -292,6 +310,11 @@ var SlbIsBusy = []string{"SystemBusy", "OperationBusy", "ServiceIsStopping", "Ba
 var EcsNotFound = []string{"InvalidInstanceId.NotFound", "Forbidden.InstanceNotFound"}
 var DiskInvalidOperation = []string{"IncorrectDiskStatus", "IncorrectInstanceStatus", "OperationConflict", InternalError, "InvalidOperation.Conflict", "IncorrectDiskStatus.Initializing"}
 var NetworkInterfaceInvalidOperations = []string{"InvalidOperation.InvalidEniState", "InvalidOperation.InvalidEcsState", "OperationConflict", "ServiceUnavailable", "InternalError"}
+var NetworkAclInvalidOperations = []string{"OperationConflict", "IncorrectStatus.NetworkAcl", "IncorrectStatus.Vswitch", TaskConflict, "SystemBusy", "ServiceUnavailable"}
+var Ipv6GatewayInvalidOperations = []string{"OperationConflict", "IncorrectStatus.Ipv6Gateway", "IncorrectStatus.Vpc", TaskConflict, "SystemBusy", "ServiceUnavailable"}
+var RouteEntryInvalidOperations = []string{TaskConflict, IncorrectRouteEntryStatus, IncorrectVpcStatus, RouterEntryForbbiden, Throttling, "OperationConflict", "SystemBusy"}
+var FlowLogInvalidOperations = []string{"OperationConflict", "IncorrectStatus.FlowLog", "IncorrectStatus.Resource", TaskConflict, "SystemBusy", "ServiceUnavailable"}
+var NatEntryInvalidOperations = []string{InvalidIpNotInNatgw, EIP_NOT_IN_GATEWAY, IncorretSnatEntryStatus, "IncorretForwardEntryStatus", "OperationConflict", TaskConflict, Throttling, "SystemBusy", UnknownError}
 var OperationDeniedDBStatus = []string{"OperationDenied.DBStatus", OperationDeniedDBInstanceStatus, DBInternalError, DBOperationDeniedOutofUsage}
 var DBReadInstanceNotReadyStatus = []string{"OperationDenied.ReadDBInstanceStatus", "OperationDenied.MasterDBInstanceState", "ReadDBInstance.Mismatch"}
 
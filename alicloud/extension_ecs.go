@@ -39,6 +39,12 @@ const (
 	SpotAsPriceGo      = SpotStrategyType("SpotAsPriceGo")
 )
 
+// The lock reason of the spot instance which is reclaimed and will be released by the system.
+const SpotInstanceRecycling = "Recycling"
+
+// The layout of the time in UTC used by the ECS APIs, such as the spot price history.
+const UTCTimeLayout = "2006-01-02T15:04:05Z"
+
 type DestinationResource string
 
 const (
@@ -111,6 +117,32 @@ const (
 	ImageOwnerDefault     = ImageOwnerAlias("") //Return the values for system, self, and others
 )
 
+type ImageStatus string
+
+const (
+	ImageCreating     = ImageStatus("Creating")
+	ImageWaiting      = ImageStatus("Waiting")
+	ImageAvailable    = ImageStatus("Available")
+	ImageUnAvailable  = ImageStatus("UnAvailable")
+	ImageCreateFailed = ImageStatus("CreateFailed")
+)
+
+type SnapshotStatus string
+
+const (
+	SnapshotProgressing  = SnapshotStatus("progressing")
+	SnapshotAccomplished = SnapshotStatus("accomplished")
+	SnapshotFailed       = SnapshotStatus("failed")
+)
+
+type SnapshotType string
+
+const (
+	SnapshotTypeAuto = SnapshotType("auto")
+	SnapshotTypeUser = SnapshotType("user")
+	SnapshotTypeAll  = SnapshotType("all")
+)
+
 type SecurityEnhancementStrategy string
 
 const (
@@ -51,8 +51,15 @@ const (
 	NextHopHaVip            = NextHopType("HaVip")
 	NextHopVpnGateway       = NextHopType("VpnGateway")
 	NextHopNetworkInterface = NextHopType("NetworkInterface")
+	NextHopNatGateway       = NextHopType("NatGateway")
 )
 
+// The system route entries of a route table are created by the cloud and can not be changed
+const RouteEntryCustom = "Custom"
+
+// The weight of each next hop of an ECMP route entry
+const DefaultRouteNextHopWeight = 100
+
 func GetAllRouterInterfaceSpec() (specifications []string) {
 	specifications = append(specifications, string(Mini2), string(Mini5),
 		string(Small1), string(Small2), string(Small5),
@@ -61,3 +68,47 @@ func GetAllRouterInterfaceSpec() (specifications []string) {
 		string(Negative))
 	return
 }
+
+type NetworkAclPolicy string
+
+const (
+	NetworkAclAccept = NetworkAclPolicy("accept")
+	NetworkAclDrop   = NetworkAclPolicy("drop")
+)
+
+// The system entries of a network ACL are created by the cloud and can not be changed
+const NetworkAclEntryCustom = "custom"
+
+// The statuses of the vswitches associated with a network ACL
+const (
+	NetworkAclResourceBinded    = Status("BINDED")
+	NetworkAclResourceBinding   = Status("BINDING")
+	NetworkAclResourceUnbinding = Status("UNBINDING")
+)
+
+type Ipv6GatewaySpec string
+
+const (
+	Ipv6GatewaySmallSpec  = Ipv6GatewaySpec("Small")
+	Ipv6GatewayMediumSpec = Ipv6GatewaySpec("Medium")
+	Ipv6GatewayLargeSpec  = Ipv6GatewaySpec("Large")
+)
+
+// The egress-only rules of an IPv6 gateway are applied to the IPv6 addresses
+const Ipv6EgressRuleInstanceType = "Ipv6Address"
+
+type FlowLogResourceType string
+
+const (
+	FlowLogVpc              = FlowLogResourceType("VPC")
+	FlowLogVSwitch          = FlowLogResourceType("VSwitch")
+	FlowLogNetworkInterface = FlowLogResourceType("NetworkInterface")
+)
+
+type FlowLogTrafficType string
+
+const (
+	FlowLogTrafficAll   = FlowLogTrafficType("All")
+	FlowLogTrafficAllow = FlowLogTrafficType("Allow")
+	FlowLogTrafficDrop  = FlowLogTrafficType("Drop")
+)
@@ -42,6 +42,18 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ECS_ROLE_NAME", os.Getenv("ALICLOUD_ECS_ROLE_NAME")),
 				Description: descriptions["ecs_role_name"],
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_PROFILE", ""),
+				Description: descriptions["profile"],
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_SHARED_CREDENTIALS_FILE", ""),
+				Description: descriptions["shared_credentials_file"],
+			},
 			"region": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -75,7 +87,41 @@ func Provider() terraform.ResourceProvider {
 				Optional:   true,
 				Deprecated: "Field 'fc' has been deprecated from provider version 1.28.0. New field 'fc' which in nested endpoints instead.",
 			},
-			"endpoints": endpointsSchema(),
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ALICLOUD_MAX_CONCURRENT_REQUESTS", connectivity.DefaultMaxConcurrentRequests),
+				ValidateFunc: validateIntegerInRange(0, 100),
+				Description:  descriptions["max_concurrent_requests"],
+			},
+			"product_max_concurrent_requests": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: descriptions["product_max_concurrent_requests"],
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ALICLOUD_MAX_RETRIES", connectivity.DefaultMaxRetries),
+				ValidateFunc: validateIntegerInRange(0, 100),
+				Description:  descriptions["max_retries"],
+			},
+			"max_retry_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ALICLOUD_MAX_RETRY_TIMEOUT", 0),
+				ValidateFunc: validateIntegerInRange(0, 3600),
+				Description:  descriptions["max_retry_timeout"],
+			},
+			"endpoints":   endpointsSchema(),
+			"assume_role": assumeRoleSchema(),
+			"default_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: descriptions["default_tags"],
+			},
+			"ignore_tags": ignoreTagsSchema(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 
@@ -84,8 +130,13 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_regions":            dataSourceAlicloudRegions(),
 			"alicloud_zones":              dataSourceAlicloudZones(),
 			"alicloud_instance_types":     dataSourceAlicloudInstanceTypes(),
+			"alicloud_spot_price_history": dataSourceAlicloudSpotPriceHistory(),
+
+			"alicloud_instance_type_recommendations": dataSourceAlicloudInstanceTypeRecommendations(),
+
 			"alicloud_instances":          dataSourceAlicloudInstances(),
 			"alicloud_disks":              dataSourceAlicloudDisks(),
+			"alicloud_snapshots":          dataSourceAlicloudSnapshots(),
 			"alicloud_network_interfaces": dataSourceAlicloudNetworkInterfaces(),
 			"alicloud_vpcs":               dataSourceAlicloudVpcs(),
 			"alicloud_vswitches":          dataSourceAlicloudVSwitches(),
@@ -105,6 +156,8 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_ram_users":                dataSourceAlicloudRamUsers(),
 			"alicloud_ram_roles":                dataSourceAlicloudRamRoles(),
 			"alicloud_ram_policies":             dataSourceAlicloudRamPolicies(),
+			"alicloud_ecs_dedicated_hosts":      dataSourceAlicloudEcsDedicatedHosts(),
+			"alicloud_ecs_deployment_sets":      dataSourceAlicloudEcsDeploymentSets(),
 			"alicloud_security_groups":          dataSourceAlicloudSecurityGroups(),
 			"alicloud_security_group_rules":     dataSourceAlicloudSecurityGroupRules(),
 			"alicloud_slbs":                     dataSourceAlicloudSlbs(),
@@ -147,8 +200,17 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_ram_role_attachment":                resourceAlicloudRamRoleAttachment(),
 			"alicloud_disk":                               resourceAliyunDisk(),
 			"alicloud_disk_attachment":                    resourceAliyunDiskAttachment(),
+			"alicloud_image":                              resourceAliyunImage(),
+			"alicloud_image_copy":                         resourceAliyunImageCopy(),
+			"alicloud_image_share_permission":             resourceAliyunImageSharePermission(),
+			"alicloud_launch_template":                    resourceAliyunLaunchTemplate(),
+			"alicloud_snapshot":                           resourceAliyunSnapshot(),
+			"alicloud_snapshot_policy":                    resourceAliyunSnapshotPolicy(),
+			"alicloud_snapshot_policy_attachment":         resourceAliyunSnapshotPolicyAttachment(),
 			"alicloud_network_interface":                  resourceAliyunNetworkInterface(),
 			"alicloud_network_interface_attachment":       resourceAliyunNetworkInterfaceAttachment(),
+			"alicloud_ecs_dedicated_host":                 resourceAliyunEcsDedicatedHost(),
+			"alicloud_ecs_deployment_set":                 resourceAliyunEcsDeploymentSet(),
 			"alicloud_security_group":                     resourceAliyunSecurityGroup(),
 			"alicloud_security_group_rule":                resourceAliyunSecurityGroupRule(),
 			"alicloud_db_database":                        resourceAlicloudDBDatabase(),
@@ -174,6 +236,12 @@ func Provider() terraform.ResourceProvider {
 			"alicloud_route_entry":            resourceAliyunRouteEntry(),
 			"alicloud_route_table":            resourceAliyunRouteTable(),
 			"alicloud_route_table_attachment": resourceAliyunRouteTableAttachment(),
+			"alicloud_network_acl":            resourceAliyunNetworkAcl(),
+			"alicloud_network_acl_entries":    resourceAliyunNetworkAclEntries(),
+			"alicloud_network_acl_attachment": resourceAliyunNetworkAclAttachment(),
+			"alicloud_ipv6_gateway":           resourceAliyunIpv6Gateway(),
+			"alicloud_ipv6_egress_rule":       resourceAliyunIpv6EgressRule(),
+			"alicloud_vpc_flow_log":           resourceAliyunVpcFlowLog(),
 			"alicloud_snat_entry":             resourceAliyunSnatEntry(),
 			"alicloud_forward_entry":          resourceAliyunForwardEntry(),
 			"alicloud_eip":                    resourceAliyunEip(),
@@ -280,6 +348,26 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		EcsRoleName: strings.TrimSpace(d.Get("ecs_role_name").(string)),
 		Region:      connectivity.Region(strings.TrimSpace(region.(string))),
 		RegionId:    strings.TrimSpace(region.(string)),
+
+		Profile:               strings.TrimSpace(d.Get("profile").(string)),
+		SharedCredentialsFile: strings.TrimSpace(d.Get("shared_credentials_file").(string)),
+
+		MaxConcurrentRequests:          d.Get("max_concurrent_requests").(int),
+		MaxConcurrentRequestsByProduct: make(map[string]int),
+
+		MaxRetries:      d.Get("max_retries").(int),
+		MaxRetryTimeout: d.Get("max_retry_timeout").(int),
+
+		VcrMode:     connectivity.VcrMode(strings.TrimSpace(os.Getenv(connectivity.VcrModeEnv))),
+		VcrCassette: strings.TrimSpace(os.Getenv(connectivity.VcrCassetteEnv)),
+	}
+
+	for product, limit := range d.Get("product_max_concurrent_requests").(map[string]interface{}) {
+		key := strings.ToLower(strings.TrimSpace(product))
+		if _, ok := endpointsSchema().Elem.(*schema.Resource).Schema[key]; !ok {
+			return nil, fmt.Errorf("The product %s of 'product_max_concurrent_requests' is invalid, valid values are the keys of the 'endpoints' block.", product)
+		}
+		config.MaxConcurrentRequestsByProduct[key] = limit.(int)
 	}
 
 	if token, ok := d.GetOk("security_token"); ok && token.(string) != "" {
@@ -337,6 +425,16 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		config.FcEndpoint = strings.TrimSpace(fcEndpoint.(string))
 	}
 
+	configureTags(d, &config)
+
+	for _, assumeRoleI := range d.Get("assume_role").(*schema.Set).List() {
+		assumeRole := assumeRoleI.(map[string]interface{})
+		config.RamRoleArn = strings.TrimSpace(assumeRole["role_arn"].(string))
+		config.RamRoleSessionName = strings.TrimSpace(assumeRole["session_name"].(string))
+		config.RamRolePolicy = strings.TrimSpace(assumeRole["policy"].(string))
+		config.RamRoleSessionExpiration = assumeRole["session_expiration"].(int)
+	}
+
 	client, err := config.Client()
 	if err != nil {
 		return nil, err
@@ -345,6 +443,25 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	return client, nil
 }
 
+// configureTags sets the default tags and the ignored tags of the provider to the config.
+func configureTags(d *schema.ResourceData, config *connectivity.Config) {
+	if v, ok := d.GetOk("default_tags"); ok {
+		config.DefaultTags = make(map[string]string)
+		for key, value := range v.(map[string]interface{}) {
+			config.DefaultTags[key] = value.(string)
+		}
+	}
+
+	for _, ignoreTagsI := range d.Get("ignore_tags").([]interface{}) {
+		if ignoreTagsI == nil {
+			continue
+		}
+		ignoreTags := ignoreTagsI.(map[string]interface{})
+		config.IgnoreTagKeys = expandStringList(ignoreTags["keys"].(*schema.Set).List())
+		config.IgnoreTagKeyPrefixes = expandStringList(ignoreTags["key_prefixes"].(*schema.Set).List())
+	}
+}
+
 // This is a global MutexKV for use within this plugin.
 var alicloudMutexKV = mutexkv.NewMutexKV()
 
@@ -358,6 +475,18 @@ func init() {
 
 		"ecs_role_name": "The RAM Role Name attached on a ECS instance for API operations. You can retrieve this from the 'Access Control' section of the Alibaba Cloud console.",
 
+		"profile": "The profile for API operations. If not set, the current profile of the shared credentials file will be used.",
+
+		"shared_credentials_file": "The path to the shared credentials file. If not set this defaults to ~/.aliyun/config.json",
+
+		"max_concurrent_requests": "The maximum number of in-flight requests for each product. 0 means the requests are not limited. Default to 10.",
+
+		"product_max_concurrent_requests": "The maximum number of in-flight requests for the specified products, keyed by the product names used in the 'endpoints' block. It overrides 'max_concurrent_requests'.",
+
+		"max_retries": "The maximum number of times a request is retried when it is throttled or the service is busy. Default to 10.",
+
+		"max_retry_timeout": "The maximum seconds spent on retrying a request which is throttled or the service is busy. 0 means there is no limit other than 'max_retries'.",
+
 		"region": "The region where Alibaba Cloud operations will take place. Examples are cn-beijing, cn-hangzhou, eu-central-1, etc.",
 
 		"security_token": "security token. A security token is only required if you are using Security Token Service.",
@@ -414,10 +543,87 @@ func init() {
 
 		"location_endpoint": "Use this to override the default endpoint URL constructed from the `region`. It's typically used to connect to custom Location Service endpoints.",
 
+		"default_tags": "The tags applied to every taggable resource. The tags of a resource override the default ones with the same keys.",
+
+		"ignore_tags_keys": "The tag keys which are managed outside of Terraform and ignored by every taggable resource.",
+
+		"ignore_tags_key_prefixes": "The prefixes of the tag keys which are managed outside of Terraform and ignored by every taggable resource.",
+
+		"assume_role_role_arn": "The ARN of a RAM role to assume prior to making API calls.",
+
+		"assume_role_session_name": "The session name to use when assuming the role. If omitted, `terraform` is passed to the AssumeRole call as session name.",
+
+		"assume_role_policy": "The permissions applied when assuming a role. You cannot use this policy to grant permissions which exceed those of the role that is being assumed.",
+
+		"assume_role_session_expiration": "The time after which the established session for assuming role expires. Valid value range: [900-3600] seconds. Default to 3600.",
+
 		"elasticsearch_endpoint": "Use this to override the default endpoint URL constructed from the `region`. It's typically used to connect to custom Elasticsearch endpoints.",
 	}
 }
 
+func assumeRoleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"role_arn": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: descriptions["assume_role_role_arn"],
+					DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ASSUME_ROLE_ARN", os.Getenv("ALICLOUD_ASSUME_ROLE_ARN")),
+				},
+				"session_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: descriptions["assume_role_session_name"],
+					DefaultFunc: schema.EnvDefaultFunc("ALICLOUD_ASSUME_ROLE_SESSION_NAME", ""),
+				},
+				"policy": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  descriptions["assume_role_policy"],
+					ValidateFunc: validateJsonString,
+				},
+				"session_expiration": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      connectivity.DefaultAssumeRoleSessionExpiration,
+					Description:  descriptions["assume_role_session_expiration"],
+					ValidateFunc: validateIntegerInRange(900, 3600),
+				},
+			},
+		},
+	}
+}
+
+func ignoreTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"keys": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Set:         schema.HashString,
+					Description: descriptions["ignore_tags_keys"],
+				},
+				"key_prefixes": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Set:         schema.HashString,
+					Description: descriptions["ignore_tags_key_prefixes"],
+				},
+			},
+		},
+	}
+}
+
 func endpointsSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeSet,
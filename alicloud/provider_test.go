@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -34,6 +35,7 @@ func TestProvider_impl(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	testAccPreCheckVcr(t)
 	if v := os.Getenv("ALICLOUD_ACCESS_KEY"); v == "" {
 		t.Fatal("ALICLOUD_ACCESS_KEY must be set for acceptance tests")
 	}
@@ -46,10 +48,28 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// testAccPreCheckVcr uses a cassette for each testcase when recording or replaying the API requests.
+// Replaying does not need any credential, so the fake one is used when it is not set.
+func testAccPreCheckVcr(t *testing.T) {
+	mode := os.Getenv(connectivity.VcrModeEnv)
+	if mode == "" {
+		return
+	}
+	os.Setenv(connectivity.VcrCassetteEnv, filepath.Join("testdata", "cassettes", fmt.Sprintf("%s.jsonl", t.Name())))
+	if connectivity.VcrMode(mode) == connectivity.VcrReplay {
+		for _, key := range []string{"ALICLOUD_ACCESS_KEY", "ALICLOUD_SECRET_KEY"} {
+			if os.Getenv(key) == "" {
+				os.Setenv(key, "fake-credential")
+			}
+		}
+	}
+}
+
 // Skip automatically the testcases which does not support some known regions.
 // If supported is true, the regions should a list of supporting the service regions.
 // If supported is false, the regions should a list of unsupporting the service regions.
 func testAccPreCheckWithRegions(t *testing.T, supported bool, regions []connectivity.Region) {
+	testAccPreCheckVcr(t)
 	if v := os.Getenv("ALICLOUD_ACCESS_KEY"); v == "" {
 		t.Fatal("ALICLOUD_ACCESS_KEY must be set for acceptance tests")
 	}
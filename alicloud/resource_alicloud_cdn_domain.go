@@ -965,7 +965,7 @@ func WaitForDomainStatus(Id string, status Status, timeout int, meta interface{}
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("Domain", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -987,7 +987,7 @@ func WaitForServerCertificate(client *connectivity.AliyunClient, Id string, serv
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("ServerCertificate", string(serverCertificate)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
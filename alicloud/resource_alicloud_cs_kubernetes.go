@@ -41,6 +41,14 @@ func resourceAlicloudCSKubernetes() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		CustomizeDiff: csKubernetesCustomizeDiffFunc,
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:          schema.TypeString,
@@ -448,6 +456,7 @@ func resourceAlicloudCSKubernetes() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -498,7 +507,7 @@ func resourceAlicloudCSKubernetesCreate(d *schema.ResourceData, meta interface{}
 
 	if err := invoker.Run(func() error {
 		_, err := client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
-			return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, 3600)
+			return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, int(d.Timeout(schema.TimeoutCreate).Seconds()))
 		})
 		return err
 	}); err != nil {
@@ -519,7 +528,7 @@ func resourceAlicloudCSKubernetesUpdate(d *schema.ResourceData, meta interface{}
 
 		args := &cs.KubernetesClusterResizeArgs{
 			DisableRollback: true,
-			TimeoutMins:     60,
+			TimeoutMins:     int64(d.Timeout(schema.TimeoutUpdate).Minutes()),
 			LoginPassword:   d.Get("password").(string),
 		}
 
@@ -545,7 +554,7 @@ func resourceAlicloudCSKubernetesUpdate(d *schema.ResourceData, meta interface{}
 
 		if err := invoker.Run(func() error {
 			_, err := client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
-				return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, 3600)
+				return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, int(d.Timeout(schema.TimeoutUpdate).Seconds()))
 			})
 			return err
 		}); err != nil {
@@ -575,6 +584,11 @@ func resourceAlicloudCSKubernetesUpdate(d *schema.ResourceData, meta interface{}
 		d.SetPartial("name")
 		d.SetPartial("name_prefix")
 	}
+
+	csService := CsService{client}
+	if err := csService.SetClusterTags(d); err != nil {
+		return err
+	}
 	d.Partial(false)
 
 	return resourceAlicloudCSKubernetesRead(d, meta)
@@ -611,6 +625,14 @@ func resourceAlicloudCSKubernetesRead(d *schema.ResourceData, meta interface{})
 	d.Set("vpc_id", cluster.VPCID)
 	d.Set("security_group_id", cluster.SecurityGroupID)
 	d.Set("key_name", cluster.Parameters.KeyPair)
+
+	csService := CsService{client}
+	tags, err := csService.DescribeClusterTags(d.Id())
+	if err != nil {
+		return err
+	}
+	d.Set("tags", providerTags(client, d, tags))
+
 	if size, err := strconv.Atoi(cluster.Parameters.MasterSystemDiskSize); err != nil {
 		return BuildWrapError("strconv.Atoi", d.Id(), ProviderERROR, err, "")
 	} else {
@@ -887,7 +909,7 @@ func resourceAlicloudCSKubernetesDelete(d *schema.ResourceData, meta interface{}
 	client := meta.(*connectivity.AliyunClient)
 	invoker := NewInvoker()
 	var cluster cs.ClusterType
-	return resource.Retry(30*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if err := invoker.Run(func() error {
 			_, err := client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
 				return nil, csClient.DeleteCluster(d.Id())
@@ -1019,7 +1041,7 @@ func buildKubernetesArgs(d *schema.ResourceData, meta interface{}) (*cs.Kubernet
 		Name:                     clusterName,
 		ClusterType:              "Kubernetes",
 		DisableRollback:          true,
-		TimeoutMins:              60,
+		TimeoutMins:              int64(d.Timeout(schema.TimeoutCreate).Minutes()),
 		MasterInstanceType:       masterInstanceType,
 		WorkerInstanceType:       workerInstanceType,
 		VPCID:                    vpcId,
@@ -1128,7 +1150,7 @@ func buildKubernetesMultiAZArgs(d *schema.ResourceData, meta interface{}) (*cs.K
 		Name:                     clusterName,
 		ClusterType:              "Kubernetes",
 		DisableRollback:          true,
-		TimeoutMins:              60,
+		TimeoutMins:              int64(d.Timeout(schema.TimeoutCreate).Minutes()),
 		MultiAZ:                  true,
 		MasterInstanceTypeA:      masterInstanceTypes[0],
 		MasterInstanceTypeB:      masterInstanceTypes[1],
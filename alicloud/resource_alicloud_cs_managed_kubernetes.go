@@ -30,6 +30,12 @@ func resourceAlicloudCSManagedKubernetes() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(ManagedKubernetesCreationDefaultTimeoutInMinute * time.Minute),
+			Update: schema.DefaultTimeout(ManagedKubernetesCreationDefaultTimeoutInMinute * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:          schema.TypeString,
@@ -268,7 +274,7 @@ func resourceAlicloudCSManagedKubernetesCreate(d *schema.ResourceData, meta inte
 
 	if err := invoker.Run(func() error {
 		_, err := client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
-			return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, 3600)
+			return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, int(d.Timeout(schema.TimeoutCreate).Seconds()))
 		})
 		return err
 	}); err != nil {
@@ -291,7 +297,7 @@ func resourceAlicloudCSManagedKubernetesUpdate(d *schema.ResourceData, meta inte
 		// When cluster was created using password, LoginPassword is required to resize.
 		args := &cs.KubernetesClusterResizeArgs{
 			DisableRollback: true,
-			TimeoutMins:     ManagedKubernetesCreationDefaultTimeoutInMinute,
+			TimeoutMins:     int64(d.Timeout(schema.TimeoutUpdate).Minutes()),
 			LoginPassword:   d.Get("password").(string),
 		}
 
@@ -308,7 +314,7 @@ func resourceAlicloudCSManagedKubernetesUpdate(d *schema.ResourceData, meta inte
 
 		if err := invoker.Run(func() error {
 			_, err := client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
-				return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, 3600)
+				return nil, csClient.WaitForClusterAsyn(d.Id(), cs.Running, int(d.Timeout(schema.TimeoutUpdate).Seconds()))
 			})
 			return err
 		}); err != nil {
@@ -542,7 +548,7 @@ func resourceAlicloudCSManagedKubernetesDelete(d *schema.ResourceData, meta inte
 	client := meta.(*connectivity.AliyunClient)
 	invoker := NewInvoker()
 	var cluster cs.ClusterType
-	return resource.Retry(30*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		if err := invoker.Run(func() error {
 			_, err := client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
 				return nil, csClient.DeleteCluster(d.Id())
@@ -640,7 +646,7 @@ func buildManagedKubernetesArgs(d *schema.ResourceData, meta interface{}) (*cs.K
 		Name:                     clusterName,
 		ClusterType:              "ManagedKubernetes",
 		DisableRollback:          true,
-		TimeoutMins:              ManagedKubernetesCreationDefaultTimeoutInMinute,
+		TimeoutMins:              int64(d.Timeout(schema.TimeoutCreate).Minutes()),
 		WorkerInstanceType:       workerInstanceType,
 		VPCID:                    vpcId,
 		VSwitchId:                vswitchID,
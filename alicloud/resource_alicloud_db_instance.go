@@ -27,6 +27,14 @@ func resourceAlicloudDBInstance() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: rdsInstanceCustomizeDiffFunc,
+
 		Schema: map[string]*schema.Schema{
 			"engine": {
 				Type:         schema.TypeString,
@@ -245,6 +253,7 @@ func resourceAlicloudDBInstance() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -269,7 +278,7 @@ func resourceAlicloudDBInstanceCreate(d *schema.ResourceData, meta interface{})
 	d.SetId(resp.DBInstanceId)
 
 	// wait instance status change from Creating to running
-	if err := rdsService.WaitForDBInstance(d.Id(), Running, DefaultLongTimeout); err != nil {
+	if err := rdsService.WaitForDBInstance(d.Id(), Running, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
 		return fmt.Errorf("WaitForInstance %s got error: %#v", Running, err)
 	}
 
@@ -287,6 +296,11 @@ func resourceAlicloudDBInstanceUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 
+	tagService := TagService{client}
+	if err := tagService.SetResourceTags(d, connectivity.RDSCode, TagResourceDBInstance); err != nil {
+		return WrapError(err)
+	}
+
 	if d.IsNewResource() {
 		d.Partial(false)
 		return resourceAlicloudDBInstanceRead(d, meta)
@@ -338,7 +352,7 @@ func resourceAlicloudDBInstanceUpdate(d *schema.ResourceData, meta interface{})
 
 	if update {
 		// wait instance status is running before modifying
-		if err := rdsService.WaitForDBInstance(d.Id(), Running, 500); err != nil {
+		if err := rdsService.WaitForDBInstance(d.Id(), Running, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Running, err)
 		}
 		_, err := client.WithRdsClient(func(rdsClient *rds.Client) (interface{}, error) {
@@ -350,7 +364,7 @@ func resourceAlicloudDBInstanceUpdate(d *schema.ResourceData, meta interface{})
 		d.SetPartial("instance_type")
 		d.SetPartial("instance_storage")
 		// wait instance status is running after modifying
-		if err := rdsService.WaitForDBInstance(d.Id(), Running, 1800); err != nil {
+		if err := rdsService.WaitForDBInstance(d.Id(), Running, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Running, err)
 		}
 	}
@@ -391,6 +405,13 @@ func resourceAlicloudDBInstanceRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("connection_string", instance.ConnectionString)
 	d.Set("instance_name", instance.DBInstanceDescription)
 
+	tagService := TagService{client}
+	tags, err := tagService.DescribeTags(connectivity.RDSCode, TagResourceDBInstance, d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tags))
+
 	if err = rdsService.RefreshParameters(d, "parameters"); err != nil {
 		return err
 	}
@@ -416,7 +437,7 @@ func resourceAlicloudDBInstanceDelete(d *schema.ResourceData, meta interface{})
 	request := rds.CreateDeleteDBInstanceRequest()
 	request.DBInstanceId = d.Id()
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := client.WithRdsClient(func(rdsClient *rds.Client) (interface{}, error) {
 			return rdsClient.DeleteDBInstance(request)
 		})
@@ -176,9 +176,7 @@ func resourceAliyunDiskRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil && !NotFoundError(err) {
 		return WrapError(err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
 
 	return nil
 }
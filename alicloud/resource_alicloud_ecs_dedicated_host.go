@@ -0,0 +1,215 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunEcsDedicatedHost() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunEcsDedicatedHostCreate,
+		Read:   resourceAliyunEcsDedicatedHostRead,
+		Update: resourceAliyunEcsDedicatedHostUpdate,
+		Delete: resourceAliyunEcsDedicatedHostDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"dedicated_host_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"action_on_maintenance": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(MaintenanceMigrate), string(MaintenanceStop)}),
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"cores": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"sockets": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"supported_instance_type_families": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAliyunEcsDedicatedHostCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateAllocateDedicatedHostsRequest()
+	args.DedicatedHostType = d.Get("dedicated_host_type").(string)
+	args.ZoneId = d.Get("availability_zone").(string)
+	args.DedicatedHostName = d.Get("name").(string)
+	args.Description = d.Get("description").(string)
+	args.ActionOnMaintenance = d.Get("action_on_maintenance").(string)
+	args.ChargeType = string(PostPaid)
+	args.Quantity = requests.NewInteger(1)
+	args.ClientToken = buildClientToken("TF-AllocateDedicatedHosts")
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.AllocateDedicatedHosts(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "ecs_dedicated_host", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.AllocateDedicatedHostsResponse)
+	if resp == nil || len(resp.DedicatedHostIdSets.DedicatedHostId) < 1 {
+		return WrapError(fmt.Errorf("AllocateDedicatedHosts got an empty response: %#v", resp))
+	}
+
+	d.SetId(resp.DedicatedHostIdSets.DedicatedHostId[0])
+
+	if err := ecsService.WaitForDedicatedHost(d.Id(), Available, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunEcsDedicatedHostUpdate(d, meta)
+}
+
+func resourceAliyunEcsDedicatedHostRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	host, err := ecsService.DescribeDedicatedHostById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("dedicated_host_type", host.DedicatedHostType)
+	d.Set("availability_zone", host.ZoneId)
+	d.Set("name", host.DedicatedHostName)
+	d.Set("description", host.Description)
+	d.Set("action_on_maintenance", host.ActionOnMaintenance)
+	d.Set("status", host.Status)
+	d.Set("cores", host.Cores)
+	d.Set("sockets", host.Sockets)
+	d.Set("supported_instance_type_families", host.SupportedInstanceTypeFamilies.SupportedInstanceTypeFamily)
+
+	tags, err := ecsService.DescribeTags(d.Id(), TagResourceDedicatedHost)
+	if err != nil && !NotFoundError(err) {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
+
+	return nil
+}
+
+func resourceAliyunEcsDedicatedHostUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	d.Partial(true)
+
+	if err := setTags(client, TagResourceDedicatedHost, d); err != nil {
+		return WrapError(err)
+	} else {
+		d.SetPartial("tags")
+	}
+
+	// The name, description and maintenance action have been set when allocating the dedicated host.
+	if !d.IsNewResource() && (d.HasChange("name") || d.HasChange("description") || d.HasChange("action_on_maintenance")) {
+		args := ecs.CreateModifyDedicatedHostAttributeRequest()
+		args.DedicatedHostId = d.Id()
+		args.DedicatedHostName = d.Get("name").(string)
+		args.Description = d.Get("description").(string)
+		args.ActionOnMaintenance = d.Get("action_on_maintenance").(string)
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyDedicatedHostAttribute(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		d.SetPartial("name")
+		d.SetPartial("description")
+		d.SetPartial("action_on_maintenance")
+	}
+
+	d.Partial(false)
+	return resourceAliyunEcsDedicatedHostRead(d, meta)
+}
+
+func resourceAliyunEcsDedicatedHostDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateReleaseDedicatedHostRequest()
+	args.DedicatedHostId = d.Id()
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ReleaseDedicatedHost(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{InvalidDedicatedHostIdNotFound}) {
+				return nil
+			}
+			// The dedicated host can not be released until all of the instances on it have been released.
+			if IsExceptedErrors(err, []string{IncorrectDedicatedHostStatus, DependencyViolation}) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := ecsService.DescribeDedicatedHostById(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("ECS Dedicated Host", "Released")), DeleteTimeoutMsg, d.Id(), args.GetActionName(), ProviderERROR))
+	})
+}
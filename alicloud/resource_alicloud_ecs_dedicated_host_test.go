@@ -0,0 +1,195 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudEcsDedicatedHost_basic(t *testing.T) {
+	var host ecs.DedicatedHost
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_ecs_dedicated_host.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEcsDedicatedHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEcsDedicatedHostConfig("tf-testAccEcsDedicatedHostConfig", "Migrate"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDedicatedHostExists("alicloud_ecs_dedicated_host.foo", &host),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "name", "tf-testAccEcsDedicatedHostConfig"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "action_on_maintenance", "Migrate"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "status", string(Available)),
+					resource.TestCheckResourceAttrSet("alicloud_ecs_dedicated_host.foo", "cores"),
+				),
+			},
+			{
+				Config: testAccEcsDedicatedHostConfig("tf-testAccEcsDedicatedHostConfig-update", "Stop"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDedicatedHostExists("alicloud_ecs_dedicated_host.foo", &host),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "name", "tf-testAccEcsDedicatedHostConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "action_on_maintenance", "Stop"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudEcsDedicatedHost_update runs the CRUD of the dedicated host against the fake API server, including
+// placing an instance on it, updating its attributes and tags and listing it by the data source.
+func TestUnitAlicloudEcsDedicatedHost_update(t *testing.T) {
+	var host ecs.DedicatedHost
+	var instance ecs.Instance
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_ecs_dedicated_host.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckEcsDedicatedHostDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEcsDedicatedHostConfigInstance(EcsInstanceCommonTestCase, "tf-testAccEcsDedicatedHostConfig", "Migrate", "foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDedicatedHostExists("alicloud_ecs_dedicated_host.foo", &host),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "dedicated_host_type", "ddh.g5"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "availability_zone", fakeApiZones[0]),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "name", "tf-testAccEcsDedicatedHostConfig"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "description", "tf-testAccEcsDedicatedHostConfig"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "action_on_maintenance", "Migrate"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "status", string(Available)),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "cores", "52"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "sockets", "2"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "supported_instance_type_families.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "supported_instance_type_families.0", "ecs.g5"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "tags.foo", "foo"),
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttrPair("alicloud_instance.foo", "dedicated_host_id", "alicloud_ecs_dedicated_host.foo", "id"),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_dedicated_hosts.foo", "hosts.#", "1"),
+					resource.TestCheckResourceAttrPair("data.alicloud_ecs_dedicated_hosts.foo", "hosts.0.id", "alicloud_ecs_dedicated_host.foo", "id"),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_dedicated_hosts.foo", "hosts.0.name", "tf-testAccEcsDedicatedHostConfig"),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_dedicated_hosts.foo", "hosts.0.dedicated_host_type", "ddh.g5"),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_dedicated_hosts.foo", "hosts.0.instance_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.alicloud_ecs_dedicated_hosts.foo", "hosts.0.instance_ids.0", "alicloud_instance.foo", "id"),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_dedicated_hosts.foo", "hosts.0.tags.foo", "foo"),
+				),
+			},
+			{
+				Config: testAccEcsDedicatedHostConfigInstance(EcsInstanceCommonTestCase, "tf-testAccEcsDedicatedHostConfig-update", "Stop", "bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDedicatedHostExists("alicloud_ecs_dedicated_host.foo", &host),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "name", "tf-testAccEcsDedicatedHostConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "description", "tf-testAccEcsDedicatedHostConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "action_on_maintenance", "Stop"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_ecs_dedicated_host.foo", "tags.foo", "bar"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckEcsDedicatedHostExists(n string, host *ecs.DedicatedHost) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ECS Dedicated Host ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		ecsService := EcsService{client}
+		v, err := ecsService.DescribeDedicatedHostById(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+
+		*host = v
+		return nil
+	}
+}
+
+func testAccCheckEcsDedicatedHostDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_ecs_dedicated_host" {
+			continue
+		}
+
+		host, err := ecsService.DescribeDedicatedHostById(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("ECS dedicated host %s still exist", host.DedicatedHostId))
+	}
+
+	return testAccCheckInstanceDestroy(s)
+}
+
+func testAccEcsDedicatedHostConfig(name, actionOnMaintenance string) string {
+	return fmt.Sprintf(`
+	data "alicloud_zones" "default" {}
+
+	resource "alicloud_ecs_dedicated_host" "foo" {
+		dedicated_host_type = "ddh.g5"
+		availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+		name = "%s"
+		description = "%s"
+		action_on_maintenance = "%s"
+	}
+	`, name, name, actionOnMaintenance)
+}
+
+func testAccEcsDedicatedHostConfigInstance(common, name, actionOnMaintenance, tag string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccEcsDedicatedHostConfig"
+	}
+
+	resource "alicloud_ecs_dedicated_host" "foo" {
+		dedicated_host_type = "ddh.g5"
+		availability_zone = "${alicloud_vswitch.default.availability_zone}"
+		name = "%s"
+		description = "%s"
+		action_on_maintenance = "%s"
+		tags {
+			foo = "%s"
+		}
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		instance_name = "${var.name}"
+		dedicated_host_id = "${alicloud_ecs_dedicated_host.foo.id}"
+	}
+
+	data "alicloud_ecs_dedicated_hosts" "foo" {
+		ids = ["${alicloud_instance.foo.dedicated_host_id}"]
+		name_regex = "${alicloud_ecs_dedicated_host.foo.name}"
+	}
+	`, common, name, name, actionOnMaintenance, tag)
+}
@@ -0,0 +1,145 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunEcsDeploymentSet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunEcsDeploymentSetCreate,
+		Read:   resourceAliyunEcsDeploymentSetRead,
+		Update: resourceAliyunEcsDeploymentSetUpdate,
+		Delete: resourceAliyunEcsDeploymentSetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      DeploymentSetAvailability,
+				ValidateFunc: validateAllowedStringValue([]string{string(DeploymentSetAvailability)}),
+			},
+
+			"instance_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceAliyunEcsDeploymentSetCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := ecs.CreateCreateDeploymentSetRequest()
+	args.DeploymentSetName = d.Get("name").(string)
+	args.Description = d.Get("description").(string)
+	args.Strategy = d.Get("strategy").(string)
+	args.ClientToken = buildClientToken("TF-CreateDeploymentSet")
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateDeploymentSet(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "ecs_deployment_set", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.CreateDeploymentSetResponse)
+	if resp == nil {
+		return WrapError(fmt.Errorf("CreateDeploymentSet got a nil response: %#v", resp))
+	}
+
+	d.SetId(resp.DeploymentSetId)
+
+	return resourceAliyunEcsDeploymentSetRead(d, meta)
+}
+
+func resourceAliyunEcsDeploymentSetRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	set, err := ecsService.DescribeDeploymentSetById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", set.DeploymentSetName)
+	d.Set("description", set.DeploymentSetDescription)
+	d.Set("strategy", set.DeploymentStrategy)
+	d.Set("instance_ids", set.InstanceIds.InstanceId)
+
+	return nil
+}
+
+func resourceAliyunEcsDeploymentSetUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	if d.HasChange("name") || d.HasChange("description") {
+		args := ecs.CreateModifyDeploymentSetAttributeRequest()
+		args.DeploymentSetId = d.Id()
+		args.DeploymentSetName = d.Get("name").(string)
+		args.Description = d.Get("description").(string)
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyDeploymentSetAttribute(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+	}
+
+	return resourceAliyunEcsDeploymentSetRead(d, meta)
+}
+
+func resourceAliyunEcsDeploymentSetDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateDeleteDeploymentSetRequest()
+	args.DeploymentSetId = d.Id()
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteDeploymentSet(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, []string{InvalidDeploymentSetIdNotFound}) {
+				return nil
+			}
+			// The deployment set can not be deleted until all of the instances in it have been released.
+			if IsExceptedErrors(err, []string{DependencyViolation}) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := ecsService.DescribeDeploymentSetById(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("ECS Deployment Set", "Deleted")), DeleteTimeoutMsg, d.Id(), args.GetActionName(), ProviderERROR))
+	})
+}
@@ -0,0 +1,161 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudEcsDeploymentSet_basic(t *testing.T) {
+	var set ecs.DeploymentSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_ecs_deployment_set.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckEcsDeploymentSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEcsDeploymentSetConfig(EcsInstanceCommonTestCase, "tf-testAccEcsDeploymentSetConfig"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDeploymentSetExists("alicloud_ecs_deployment_set.foo", &set),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "name", "tf-testAccEcsDeploymentSetConfig"),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "strategy", string(DeploymentSetAvailability)),
+					resource.TestCheckResourceAttrPair("alicloud_instance.foo", "deployment_set_id", "alicloud_ecs_deployment_set.foo", "id"),
+				),
+			},
+			{
+				Config: testAccEcsDeploymentSetConfig(EcsInstanceCommonTestCase, "tf-testAccEcsDeploymentSetConfig-update"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDeploymentSetExists("alicloud_ecs_deployment_set.foo", &set),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "name", "tf-testAccEcsDeploymentSetConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "instance_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudEcsDeploymentSet_update runs the CRUD of the deployment set against the fake API server, including
+// spreading an instance over it, updating its attributes and listing it by the data source.
+func TestUnitAlicloudEcsDeploymentSet_update(t *testing.T) {
+	var set ecs.DeploymentSet
+	var instance ecs.Instance
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_ecs_deployment_set.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckEcsDeploymentSetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEcsDeploymentSetConfig(EcsInstanceCommonTestCase, "tf-testAccEcsDeploymentSetConfig"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDeploymentSetExists("alicloud_ecs_deployment_set.foo", &set),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "name", "tf-testAccEcsDeploymentSetConfig"),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "description", "tf-testAccEcsDeploymentSetConfig"),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "strategy", string(DeploymentSetAvailability)),
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttrPair("alicloud_instance.foo", "deployment_set_id", "alicloud_ecs_deployment_set.foo", "id"),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_deployment_sets.foo", "sets.#", "1"),
+					resource.TestCheckResourceAttrPair("data.alicloud_ecs_deployment_sets.foo", "sets.0.id", "alicloud_ecs_deployment_set.foo", "id"),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_deployment_sets.foo", "sets.0.strategy", string(DeploymentSetAvailability)),
+					resource.TestCheckResourceAttr("data.alicloud_ecs_deployment_sets.foo", "sets.0.instance_ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.alicloud_ecs_deployment_sets.foo", "sets.0.instance_ids.0", "alicloud_instance.foo", "id"),
+				),
+			},
+			{
+				Config: testAccEcsDeploymentSetConfig(EcsInstanceCommonTestCase, "tf-testAccEcsDeploymentSetConfig-update"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEcsDeploymentSetExists("alicloud_ecs_deployment_set.foo", &set),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "name", "tf-testAccEcsDeploymentSetConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "description", "tf-testAccEcsDeploymentSetConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ecs_deployment_set.foo", "instance_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckEcsDeploymentSetExists(n string, set *ecs.DeploymentSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ECS Deployment Set ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		ecsService := EcsService{client}
+		v, err := ecsService.DescribeDeploymentSetById(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+
+		*set = v
+		return nil
+	}
+}
+
+func testAccCheckEcsDeploymentSetDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_ecs_deployment_set" {
+			continue
+		}
+
+		set, err := ecsService.DescribeDeploymentSetById(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("ECS deployment set %s still exist", set.DeploymentSetId))
+	}
+
+	return testAccCheckInstanceDestroy(s)
+}
+
+func testAccEcsDeploymentSetConfig(common, name string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccEcsDeploymentSetConfig"
+	}
+
+	resource "alicloud_ecs_deployment_set" "foo" {
+		name = "%s"
+		description = "%s"
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		instance_name = "${var.name}"
+		deployment_set_id = "${alicloud_ecs_deployment_set.foo.id}"
+	}
+
+	data "alicloud_ecs_deployment_sets" "foo" {
+		ids = ["${alicloud_instance.foo.deployment_set_id}"]
+		strategy = "Availability"
+	}
+	`, common, name, name)
+}
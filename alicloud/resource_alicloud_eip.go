@@ -74,6 +74,7 @@ func resourceAliyunEip() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -148,6 +149,13 @@ func resourceAliyunEipRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("ip_address", eip.IpAddress)
 	d.Set("status", eip.Status)
 
+	tagService := TagService{client}
+	tags, err := tagService.DescribeTags(connectivity.VPCCode, TagResourceEip, d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tags))
+
 	return nil
 }
 
@@ -155,6 +163,11 @@ func resourceAliyunEipUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	d.Partial(true)
 
+	tagService := TagService{client}
+	if err := tagService.SetResourceTags(d, connectivity.VPCCode, TagResourceEip); err != nil {
+		return WrapError(err)
+	}
+
 	update := false
 	request := vpc.CreateModifyEipAddressAttributeRequest()
 	request.AllocationId = d.Id()
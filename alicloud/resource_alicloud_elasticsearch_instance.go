@@ -23,6 +23,12 @@ func resourceAlicloudElasticsearch() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(WaitInstanceActiveTimeout * time.Second),
+			Update: schema.DefaultTimeout(WaitInstanceActiveTimeout * time.Second),
+			Delete: schema.DefaultTimeout(2 * time.Hour),
+		},
+
 		Schema: map[string]*schema.Schema{
 			// Basic instance information
 			"description": &schema.Schema{
@@ -171,7 +177,7 @@ func resourceAlicloudElasticsearchCreate(d *schema.ResourceData, meta interface{
 	resp, _ := raw.(*elasticsearch.CreateInstanceResponse)
 	d.SetId(resp.Result.InstanceId)
 
-	if err := elasticsearchService.WaitForElasticsearchInstance(resp.Result.InstanceId, []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout); err != nil {
+	if err := elasticsearchService.WaitForElasticsearchInstance(resp.Result.InstanceId, []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
 		return WrapError(err)
 	}
 
@@ -263,7 +269,7 @@ func resourceAlicloudElasticsearchUpdate(d *schema.ResourceData, meta interface{
 
 	if d.HasChange("data_node_amount") {
 
-		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout); err != nil {
+		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return WrapError(err)
 		}
 
@@ -276,7 +282,7 @@ func resourceAlicloudElasticsearchUpdate(d *schema.ResourceData, meta interface{
 
 	if d.HasChange("data_node_spec") || d.HasChange("data_node_disk_size") || d.HasChange("data_node_disk_type") {
 
-		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout); err != nil {
+		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return WrapError(err)
 		}
 
@@ -291,7 +297,7 @@ func resourceAlicloudElasticsearchUpdate(d *schema.ResourceData, meta interface{
 
 	if d.HasChange("master_node_spec") {
 
-		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout); err != nil {
+		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return WrapError(err)
 		}
 
@@ -304,7 +310,7 @@ func resourceAlicloudElasticsearchUpdate(d *schema.ResourceData, meta interface{
 
 	if d.HasChange("password") {
 
-		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout); err != nil {
+		if err := elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return WrapError(err)
 		}
 
@@ -331,7 +337,7 @@ func resourceAlicloudElasticsearchDelete(d *schema.ResourceData, meta interface{
 	request.InstanceId = d.Id()
 	request.SetContentType("application/json")
 
-	if err := resource.Retry(2*time.Hour, func() *resource.RetryError {
+	if err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := client.WithElasticsearchClient(func(elasticsearchClient *elasticsearch.Client) (interface{}, error) {
 			return elasticsearchClient.DeleteInstance(request)
 		})
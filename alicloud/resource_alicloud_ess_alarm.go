@@ -123,22 +123,14 @@ func resourceAliyunEssAlarmCreate(d *schema.ResourceData, meta interface{}) erro
 	}
 
 	client := meta.(*connectivity.AliyunClient)
-	if err := resource.Retry(5*time.Minute, func() *resource.RetryError {
-		raw, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
-			return essClient.CreateAlarm(args)
-		})
-		if err != nil {
-			if IsExceptedError(err, EssThrottling) {
-				return resource.RetryableError(fmt.Errorf("CreateAlarm timeout and got an error: %#v.", err))
-			}
-			return resource.NonRetryableError(fmt.Errorf("CreateAlarm got an error: %#v.", err))
-		}
-		alarm, _ := raw.(*ess.CreateAlarmResponse)
-		d.SetId(alarm.AlarmTaskId)
-		return nil
-	}); err != nil {
-		return err
+	raw, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
+		return essClient.CreateAlarm(args)
+	})
+	if err != nil {
+		return fmt.Errorf("CreateAlarm got an error: %#v.", err)
 	}
+	alarm, _ := raw.(*ess.CreateAlarmResponse)
+	d.SetId(alarm.AlarmTaskId)
 
 	return resourceAliyunEssAlarmRead(d, meta)
 }
@@ -70,22 +70,14 @@ func resourceAliyunEssLifeCycleHookCreate(d *schema.ResourceData, meta interface
 	args := buildAlicloudEssLifeCycleHookArgs(d)
 	client := meta.(*connectivity.AliyunClient)
 
-	if err := resource.Retry(5*time.Minute, func() *resource.RetryError {
-		raw, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
-			return essClient.CreateLifecycleHook(args)
-		})
-		if err != nil {
-			if IsExceptedError(err, EssThrottling) {
-				return resource.RetryableError(fmt.Errorf("CreateLifecycleHook timeout and got an error: %#v.", err))
-			}
-			return resource.NonRetryableError(fmt.Errorf("CreateLifecycleHook got an error: %#v.", err))
-		}
-		hook, _ := raw.(*ess.CreateLifecycleHookResponse)
-		d.SetId(hook.LifecycleHookId)
-		return nil
-	}); err != nil {
-		return err
+	raw, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
+		return essClient.CreateLifecycleHook(args)
+	})
+	if err != nil {
+		return fmt.Errorf("CreateLifecycleHook got an error: %#v.", err)
 	}
+	hook, _ := raw.(*ess.CreateLifecycleHookResponse)
+	d.SetId(hook.LifecycleHookId)
 
 	return resourceAliyunEssLifeCycleHookRead(d, meta)
 }
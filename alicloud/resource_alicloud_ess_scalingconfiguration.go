@@ -21,6 +21,8 @@ func resourceAlicloudEssScalingConfiguration() *schema.Resource {
 		Update: resourceAliyunEssScalingConfigurationUpdate,
 		Delete: resourceAliyunEssScalingConfigurationDelete,
 
+		CustomizeDiff: essScalingConfigurationCustomizeDiffFunc,
+
 		Schema: map[string]*schema.Schema{
 			"active": {
 				Type:     schema.TypeBool,
@@ -267,6 +269,21 @@ func resourceAliyunEssScalingConfigurationUpdate(d *schema.ResourceData, meta in
 		d.SetPartial("active")
 	}
 
+	if d.HasChange("instance_name") || d.HasChange("scaling_configuration_name") {
+		request := ess.CreateModifyScalingConfigurationRequest()
+		request.ScalingConfigurationId = d.Id()
+		request.InstanceName = d.Get("instance_name").(string)
+		request.ScalingConfigurationName = d.Get("scaling_configuration_name").(string)
+		_, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
+			return essClient.ModifyScalingConfiguration(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		d.SetPartial("instance_name")
+		d.SetPartial("scaling_configuration_name")
+	}
+
 	if err := enableEssScalingConfiguration(d, meta); err != nil {
 		return err
 	}
@@ -379,7 +396,7 @@ func resourceAliyunEssScalingConfigurationRead(d *schema.ResourceData, meta inte
 	d.Set("key_name", c.KeyPairName)
 	d.Set("user_data", userDataHashSum(c.UserData))
 	d.Set("force_delete", d.Get("force_delete").(bool))
-	d.Set("tags", essTagsToMap(c.Tags.Tag))
+	d.Set("tags", providerTags(client, d, essTagsToMap(c.Tags.Tag)))
 	d.Set("instance_name", c.InstanceName)
 
 	return nil
@@ -526,9 +543,9 @@ func buildAlicloudEssScalingConfigurationArgs(d *schema.ResourceData, meta inter
 		}
 	}
 
-	if v, ok := d.GetOk("tags"); ok {
+	if v := mergeDefaultTags(meta.(*connectivity.AliyunClient), d.Get("tags").(map[string]interface{})); len(v) > 0 {
 		tags := "{"
-		for key, value := range v.(map[string]interface{}) {
+		for key, value := range v {
 			tags += "\"" + key + "\"" + ":" + "\"" + value.(string) + "\"" + ","
 		}
 		args.Tags = strings.TrimSuffix(tags, ",") + "}"
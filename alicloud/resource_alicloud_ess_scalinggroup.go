@@ -3,13 +3,10 @@ package alicloud
 import (
 	"fmt"
 
-	"time"
-
 	"reflect"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ess"
-	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
@@ -85,6 +82,16 @@ func resourceAlicloudEssScalingGroup() *schema.Resource {
 				ValidateFunc: validateAllowedStringValue([]string{string(Priority), string(Balance)}),
 				ForceNew:     true,
 			},
+			"launch_template_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"launch_template_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateLaunchTemplateVersion,
+			},
 		},
 	}
 }
@@ -98,21 +105,34 @@ func resourceAliyunEssScalingGroupCreate(d *schema.ResourceData, meta interface{
 
 	client := meta.(*connectivity.AliyunClient)
 
-	if err := resource.Retry(5*time.Minute, func() *resource.RetryError {
-		raw, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
-			return essClient.CreateScalingGroup(args)
+	raw, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
+		return essClient.CreateScalingGroup(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "new", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	scaling, _ := raw.(*ess.CreateScalingGroupResponse)
+	d.SetId(scaling.ScalingGroupId)
+
+	// The scaling group launching instances from a template is enabled without any scaling configuration.
+	if args.LaunchTemplateId != "" {
+		essService := EssService{client}
+		if err := essService.WaitForScalingGroup(d.Id(), Inactive, DefaultTimeout); err != nil {
+			return WrapError(err)
+		}
+		req := ess.CreateEnableScalingGroupRequest()
+		req.ScalingGroupId = d.Id()
+		req.LaunchTemplateId = args.LaunchTemplateId
+		req.LaunchTemplateVersion = args.LaunchTemplateVersion
+		_, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
+			return essClient.EnableScalingGroup(req)
 		})
 		if err != nil {
-			if IsExceptedError(err, EssThrottling) {
-				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, "new", args.GetActionName(), AlibabaCloudSdkGoERROR))
-			}
-			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, "new", args.GetActionName(), AlibabaCloudSdkGoERROR))
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), req.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		if err := essService.WaitForScalingGroup(d.Id(), Active, DefaultTimeout); err != nil {
+			return WrapError(err)
 		}
-		scaling, _ := raw.(*ess.CreateScalingGroupResponse)
-		d.SetId(scaling.ScalingGroupId)
-		return nil
-	}); err != nil {
-		return err
 	}
 
 	return resourceAliyunEssScalingGroupUpdate(d, meta)
@@ -167,6 +187,8 @@ func resourceAliyunEssScalingGroupRead(d *schema.ResourceData, meta interface{})
 		}
 	}
 	d.Set("vswitch_ids", vswitchIds)
+	d.Set("launch_template_id", scaling.LaunchTemplateId)
+	d.Set("launch_template_version", scaling.LaunchTemplateVersion)
 
 	return nil
 }
@@ -208,6 +230,13 @@ func resourceAliyunEssScalingGroupUpdate(d *schema.ResourceData, meta interface{
 		d.SetPartial("removal_policies")
 	}
 
+	if !d.IsNewResource() && (d.HasChange("launch_template_id") || d.HasChange("launch_template_version")) {
+		args.LaunchTemplateId = d.Get("launch_template_id").(string)
+		args.LaunchTemplateVersion = d.Get("launch_template_version").(string)
+		d.SetPartial("launch_template_id")
+		d.SetPartial("launch_template_version")
+	}
+
 	_, err := client.WithEssClient(func(essClient *ess.Client) (interface{}, error) {
 		return essClient.ModifyScalingGroup(args)
 	})
@@ -261,5 +290,10 @@ func buildAlicloudEssScalingGroupArgs(d *schema.ResourceData, meta interface{})
 		args.MultiAZPolicy = v
 	}
 
+	if v := d.Get("launch_template_id").(string); v != "" {
+		args.LaunchTemplateId = v
+		args.LaunchTemplateVersion = d.Get("launch_template_version").(string)
+	}
+
 	return args, nil
 }
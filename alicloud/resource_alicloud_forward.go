@@ -1,6 +1,8 @@
 package alicloud
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
@@ -46,43 +48,63 @@ func resourceAliyunForwardEntry() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validateForwardPort,
 			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceName,
+			},
 		},
 	}
 }
 
+// checkForwardEntryPorts checks the ports of the DNAT entry against each other and its protocol. The ports of the any
+// protocol must be any, and a port range must be mapped to a port range of the same size.
+func checkForwardEntryPorts(entry forwardEntry) error {
+	protocol := strings.ToLower(entry.IpProtocol)
+	external := strings.ToLower(entry.ExternalPort)
+	internal := strings.ToLower(entry.InternalPort)
+	if protocol == "any" || external == "any" || internal == "any" {
+		if protocol != "any" || external != "any" || internal != "any" {
+			return fmt.Errorf("The forward entry %s:%s must set 'ip_protocol', 'external_port' and 'internal_port' all to any, or none of them.", entry.ExternalIp, entry.ExternalPort)
+		}
+		return nil
+	}
+	externalFrom, externalTo, err := parseForwardPortRange(external)
+	if err != nil {
+		return err
+	}
+	internalFrom, internalTo, err := parseForwardPortRange(internal)
+	if err != nil {
+		return err
+	}
+	if externalTo-externalFrom != internalTo-internalFrom || strings.Contains(external, "/") != strings.Contains(internal, "/") {
+		return fmt.Errorf("The external port %s of the forward entry %s must be mapped to an internal port range of the same size, got %s.", entry.ExternalPort, entry.ExternalIp, entry.InternalPort)
+	}
+	return nil
+}
+
 func resourceAliyunForwardEntryCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	vpcService := VpcService{client}
 
-	args := vpc.CreateCreateForwardEntryRequest()
-	args.RegionId = string(client.Region)
-	args.ForwardTableId = d.Get("forward_table_id").(string)
-	args.ExternalIp = d.Get("external_ip").(string)
-	args.ExternalPort = d.Get("external_port").(string)
-	args.IpProtocol = d.Get("ip_protocol").(string)
-	args.InternalIp = d.Get("internal_ip").(string)
-	args.InternalPort = d.Get("internal_port").(string)
-
-	if err := resource.Retry(2*time.Minute, func() *resource.RetryError {
-		ar := args
-		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
-			return vpcClient.CreateForwardEntry(ar)
-		})
-		if err != nil {
-			if IsExceptedError(err, InvalidIpNotInNatgw) {
-				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, "forward_entry", args.GetActionName(), AlibabaCloudSdkGoERROR))
-			}
-			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, "forward_entry", args.GetActionName(), AlibabaCloudSdkGoERROR))
-		}
-		resp, _ := raw.(*vpc.CreateForwardEntryResponse)
-		d.SetId(resp.ForwardEntryId)
-		d.Set("forward_table_id", d.Get("forward_table_id").(string))
-		return nil
-	}); err != nil {
+	entry := forwardEntry{ForwardEntryName: d.Get("name").(string)}
+	entry.ForwardTableId = d.Get("forward_table_id").(string)
+	entry.ExternalIp = d.Get("external_ip").(string)
+	entry.ExternalPort = d.Get("external_port").(string)
+	entry.IpProtocol = d.Get("ip_protocol").(string)
+	entry.InternalIp = d.Get("internal_ip").(string)
+	entry.InternalPort = d.Get("internal_port").(string)
+	if err := checkForwardEntryPorts(entry); err != nil {
 		return WrapError(err)
 	}
 
-	if err := vpcService.WaitForForwardEntry(args.ForwardTableId, d.Id(), Available, DefaultTimeout); err != nil {
+	id, err := vpcService.CreateForwardEntry(entry)
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+
+	if err := vpcService.WaitForForwardEntry(entry.ForwardTableId, d.Id(), Available, DefaultTimeout); err != nil {
 		return WrapError(err)
 	}
 	return resourceAliyunForwardEntryRead(d, meta)
@@ -108,6 +130,7 @@ func resourceAliyunForwardEntryRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("ip_protocol", forwardEntry.IpProtocol)
 	d.Set("internal_ip", forwardEntry.InternalIp)
 	d.Set("internal_port", forwardEntry.InternalPort)
+	d.Set("name", forwardEntry.ForwardEntryName)
 
 	return nil
 }
@@ -116,45 +139,24 @@ func resourceAliyunForwardEntryUpdate(d *schema.ResourceData, meta interface{})
 	client := meta.(*connectivity.AliyunClient)
 	vpcService := VpcService{client}
 
-	forwardEntry, err := vpcService.DescribeForwardEntry(d.Get("forward_table_id").(string), d.Id())
-	if err != nil {
-		return WrapError(err)
-	}
-
-	attributeUpdate := false
-	args := vpc.CreateModifyForwardEntryRequest()
-	args.RegionId = string(client.Region)
-	args.ForwardTableId = forwardEntry.ForwardTableId
-	args.ForwardEntryId = forwardEntry.ForwardEntryId
-	args.ExternalIp = forwardEntry.ExternalIp
-	args.IpProtocol = forwardEntry.IpProtocol
-	args.ExternalPort = forwardEntry.ExternalPort
-	args.InternalIp = forwardEntry.InternalIp
-	args.InternalPort = forwardEntry.InternalPort
-
-	if d.HasChange("external_port") {
-		args.ExternalPort = d.Get("external_port").(string)
-		attributeUpdate = true
-	}
-
-	if d.HasChange("ip_protocol") {
-		args.IpProtocol = d.Get("ip_protocol").(string)
-		attributeUpdate = true
-	}
-
-	if d.HasChange("internal_port") {
-		args.InternalPort = d.Get("internal_port").(string)
-		attributeUpdate = true
-	}
-
-	if attributeUpdate {
-		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
-			return vpcClient.ModifyForwardEntry(args)
-		})
+	if d.HasChange("external_port") || d.HasChange("ip_protocol") || d.HasChange("internal_ip") || d.HasChange("internal_port") || d.HasChange("name") {
+		entry, err := vpcService.DescribeForwardEntry(d.Get("forward_table_id").(string), d.Id())
 		if err != nil {
-			return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+			return WrapError(err)
+		}
+		entry.ExternalPort = d.Get("external_port").(string)
+		entry.IpProtocol = d.Get("ip_protocol").(string)
+		entry.InternalIp = d.Get("internal_ip").(string)
+		entry.InternalPort = d.Get("internal_port").(string)
+		entry.ForwardEntryName = d.Get("name").(string)
+		if err := checkForwardEntryPorts(entry); err != nil {
+			return WrapError(err)
+		}
+
+		if err := vpcService.ModifyForwardEntry(entry); err != nil {
+			return err
 		}
-		if err := vpcService.WaitForForwardEntry(args.ForwardTableId, d.Id(), Available, DefaultTimeout); err != nil {
+		if err := vpcService.WaitForForwardEntry(entry.ForwardTableId, d.Id(), Available, DefaultTimeout); err != nil {
 			return WrapError(err)
 		}
 	}
@@ -2,16 +2,16 @@ package alicloud
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
-	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
 func TestAccAlicloudForward_basic(t *testing.T) {
-	var forward vpc.ForwardTableEntry
+	var forward forwardEntry
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -68,6 +68,50 @@ func TestAccAlicloudForward_basic(t *testing.T) {
 
 }
 
+// TestUnitAlicloudForward_update runs the DNAT entries of a port, a port range and the any protocol against the fake API
+// server, which does not serve EIPs, so the external IP is given directly.
+func TestUnitAlicloudForward_update(t *testing.T) {
+	var forward forwardEntry
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_forward_entry.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckForwardEntryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccForwardEntryUnitConfig("tf-testAccForwardEntry", "8080", "2000/2010"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckForwardEntryExists("alicloud_forward_entry.foo", &forward),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.foo", "name", "tf-testAccForwardEntry"),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.foo", "internal_port", "8080"),
+					testAccCheckForwardEntryExists("alicloud_forward_entry.range", &forward),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.range", "external_port", "1000/1010"),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.range", "internal_port", "2000/2010"),
+					testAccCheckForwardEntryExists("alicloud_forward_entry.any", &forward),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.any", "ip_protocol", "any"),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.any", "external_port", "any"),
+				),
+			},
+			{
+				Config: testAccForwardEntryUnitConfig("tf-testAccForwardEntry-update", "8081", "3000/3010"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckForwardEntryExists("alicloud_forward_entry.foo", &forward),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.foo", "name", "tf-testAccForwardEntry-update"),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.foo", "internal_port", "8081"),
+					testAccCheckForwardEntryExists("alicloud_forward_entry.range", &forward),
+					resource.TestCheckResourceAttr("alicloud_forward_entry.range", "internal_port", "3000/3010"),
+				),
+			},
+			{
+				Config:      testAccForwardEntryUnitConfig("tf-testAccForwardEntry-update", "8081", "3000/3020"),
+				ExpectError: regexp.MustCompile("must be mapped to an internal port range of the same size"),
+			},
+		},
+	})
+}
+
 func testAccCheckForwardEntryDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*connectivity.AliyunClient)
 	vpcService := VpcService{client}
@@ -93,7 +137,7 @@ func testAccCheckForwardEntryDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckForwardEntryExists(n string, snat *vpc.ForwardTableEntry) resource.TestCheckFunc {
+func testAccCheckForwardEntryExists(n string, forward *forwardEntry) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -115,7 +159,7 @@ func testAccCheckForwardEntryExists(n string, snat *vpc.ForwardTableEntry) resou
 			return WrapError(Error("ForwardEntry not found"))
 		}
 
-		snat = &instance
+		*forward = instance
 		return nil
 	}
 }
@@ -246,3 +290,46 @@ resource "alicloud_forward_entry" "foo2"{
 	internal_port = "8082"
 }
 `
+
+func testAccForwardEntryUnitConfig(name, internalPort, internalPortRange string) string {
+	return fmt.Sprintf(`
+resource "alicloud_vpc" "foo" {
+	name = "tf-testAccForwardEntryConfig"
+	cidr_block = "172.16.0.0/12"
+}
+
+resource "alicloud_nat_gateway" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	specification = "Small"
+	name = "tf-testAccForwardEntryConfig"
+}
+
+resource "alicloud_forward_entry" "foo"{
+	forward_table_id = "${alicloud_nat_gateway.foo.forward_table_ids}"
+	external_ip = "47.0.0.1"
+	external_port = "80"
+	ip_protocol = "tcp"
+	internal_ip = "172.16.0.3"
+	internal_port = "%s"
+	name = "%s"
+}
+
+resource "alicloud_forward_entry" "range"{
+	forward_table_id = "${alicloud_nat_gateway.foo.forward_table_ids}"
+	external_ip = "47.0.0.1"
+	external_port = "1000/1010"
+	ip_protocol = "udp"
+	internal_ip = "172.16.0.4"
+	internal_port = "%s"
+}
+
+resource "alicloud_forward_entry" "any"{
+	forward_table_id = "${alicloud_nat_gateway.foo.forward_table_ids}"
+	external_ip = "47.0.0.2"
+	external_port = "any"
+	ip_protocol = "any"
+	internal_ip = "172.16.0.5"
+	internal_port = "any"
+}
+`, internalPort, name, internalPortRange)
+}
@@ -0,0 +1,299 @@
+package alicloud
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunImageCreate,
+		Read:   resourceAliyunImageRead,
+		Update: resourceAliyunImageUpdate,
+		Delete: resourceAliyunImageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"snapshot_id", "disk_device_mapping"},
+			},
+
+			"snapshot_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instance_id", "disk_device_mapping"},
+			},
+
+			"disk_device_mapping": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"instance_id", "snapshot_id"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"device": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ForceNew: true,
+						},
+						"disk_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ForceNew:     true,
+							ValidateFunc: validateAllowedStringValue([]string{string(DiskTypeSystem), string(DiskTypeData)}),
+						},
+					},
+				},
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"image_version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"platform": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"architecture": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"i386", "x86_64"}),
+			},
+
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAliyunImageCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateCreateImageRequest()
+	args.InstanceId = d.Get("instance_id").(string)
+	args.SnapshotId = d.Get("snapshot_id").(string)
+	if v, ok := d.GetOk("disk_device_mapping"); ok {
+		var mappings []ecs.CreateImageDiskDeviceMapping
+		for _, e := range v.([]interface{}) {
+			mapping := e.(map[string]interface{})
+			m := ecs.CreateImageDiskDeviceMapping{
+				SnapshotId: mapping["snapshot_id"].(string),
+				Device:     mapping["device"].(string),
+				DiskType:   mapping["disk_type"].(string),
+			}
+			if size := mapping["size"].(int); size > 0 {
+				m.Size = strconv.Itoa(size)
+			}
+			mappings = append(mappings, m)
+		}
+		args.DiskDeviceMapping = &mappings
+	}
+	if args.InstanceId == "" && args.SnapshotId == "" && args.DiskDeviceMapping == nil {
+		return WrapError(fmt.Errorf("One of instance_id, snapshot_id and disk_device_mapping is required when creating an image."))
+	}
+
+	args.ImageName = d.Get("name").(string)
+	args.Description = d.Get("description").(string)
+	args.ImageVersion = d.Get("image_version").(string)
+	args.Platform = d.Get("platform").(string)
+	args.Architecture = d.Get("architecture").(string)
+	args.ClientToken = buildClientToken("TF-CreateImage")
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateImage(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "image", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.CreateImageResponse)
+	if resp == nil {
+		return WrapError(fmt.Errorf("CreateImage got a nil response: %#v", resp))
+	}
+
+	d.SetId(resp.ImageId)
+
+	if err := ecsService.WaitForImage(d.Id(), ImageAvailable, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunImageUpdate(d, meta)
+}
+
+func resourceAliyunImageRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	image, err := ecsService.DescribeImageById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", image.ImageName)
+	d.Set("description", image.Description)
+	d.Set("image_version", image.ImageVersion)
+	d.Set("platform", image.Platform)
+	d.Set("architecture", image.Architecture)
+	d.Set("status", image.Status)
+
+	var mappings []map[string]interface{}
+	for _, mapping := range image.DiskDeviceMappings.DiskDeviceMapping {
+		size, _ := strconv.Atoi(mapping.Size)
+		mappings = append(mappings, map[string]interface{}{
+			"snapshot_id": mapping.SnapshotId,
+			"size":        size,
+			"device":      mapping.Device,
+			"disk_type":   mapping.Type,
+		})
+	}
+	d.Set("disk_device_mapping", mappings)
+
+	tags, err := ecsService.DescribeTags(d.Id(), TagResourceImage)
+	if err != nil && !NotFoundError(err) {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
+
+	return nil
+}
+
+func resourceAliyunImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := updateImageAttribute(d, meta); err != nil {
+		return WrapError(err)
+	}
+	return resourceAliyunImageRead(d, meta)
+}
+
+func resourceAliyunImageDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteImage(d, meta)
+}
+
+// updateImageAttribute updates the tags, name and description of the custom image, which are shared by alicloud_image
+// and alicloud_image_copy.
+func updateImageAttribute(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	d.Partial(true)
+
+	if err := setTags(client, TagResourceImage, d); err != nil {
+		return WrapError(err)
+	} else {
+		d.SetPartial("tags")
+	}
+
+	// The name and description have been set when creating the image.
+	if !d.IsNewResource() && (d.HasChange("name") || d.HasChange("description")) {
+		args := ecs.CreateModifyImageAttributeRequest()
+		args.ImageId = d.Id()
+		args.ImageName = d.Get("name").(string)
+		args.Description = d.Get("description").(string)
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyImageAttribute(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		d.SetPartial("name")
+		d.SetPartial("description")
+	}
+
+	d.Partial(false)
+	return nil
+}
+
+// deleteImage deletes the custom image, and the image used by instances is deleted only when force is true.
+func deleteImage(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateDeleteImageRequest()
+	args.ImageId = d.Id()
+	args.Force = requests.NewBoolean(d.Get("force").(bool))
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteImage(args)
+		})
+		if err != nil {
+			if NotFoundError(err) || IsExceptedError(err, InvalidImageIdNotFound) {
+				return nil
+			}
+			if IsExceptedError(err, IncorrectImageStatus) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := ecsService.DescribeImageById(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("ECS Image", "Deleted")), DeleteTimeoutMsg, d.Id(), args.GetActionName(), ProviderERROR))
+	})
+}
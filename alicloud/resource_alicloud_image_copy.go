@@ -0,0 +1,140 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunImageCopy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunImageCopyCreate,
+		Read:   resourceAliyunImageCopyRead,
+		Update: resourceAliyunImageCopyUpdate,
+		Delete: resourceAliyunImageCopyDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"source_region_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAliyunImageCopyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	// The image is copied by the region of the source image to the region of the provider.
+	args := ecs.CreateCopyImageRequest()
+	args.RegionId = d.Get("source_region_id").(string)
+	args.ImageId = d.Get("source_image_id").(string)
+	args.DestinationRegionId = client.RegionId
+	args.DestinationImageName = d.Get("name").(string)
+	args.DestinationDescription = d.Get("description").(string)
+	if v, ok := d.GetOk("encrypted"); ok {
+		args.Encrypted = requests.NewBoolean(v.(bool))
+	}
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CopyImage(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "image_copy", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.CopyImageResponse)
+	if resp == nil {
+		return WrapError(fmt.Errorf("CopyImage got a nil response: %#v", resp))
+	}
+
+	d.SetId(resp.ImageId)
+
+	if err := ecsService.WaitForImage(d.Id(), ImageAvailable, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunImageCopyUpdate(d, meta)
+}
+
+func resourceAliyunImageCopyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	image, err := ecsService.DescribeImageById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("name", image.ImageName)
+	d.Set("description", image.Description)
+	d.Set("status", image.Status)
+
+	tags, err := ecsService.DescribeTags(d.Id(), TagResourceImage)
+	if err != nil && !NotFoundError(err) {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
+
+	return nil
+}
+
+func resourceAliyunImageCopyUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := updateImageAttribute(d, meta); err != nil {
+		return WrapError(err)
+	}
+	return resourceAliyunImageCopyRead(d, meta)
+}
+
+func resourceAliyunImageCopyDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteImage(d, meta)
+}
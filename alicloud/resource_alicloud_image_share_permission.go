@@ -0,0 +1,102 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunImageSharePermission() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunImageSharePermissionCreate,
+		Read:   resourceAliyunImageSharePermissionRead,
+		Delete: resourceAliyunImageSharePermissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"image_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAliyunImageSharePermissionCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := ecs.CreateModifyImageSharePermissionRequest()
+	args.ImageId = d.Get("image_id").(string)
+	args.AddAccount = &[]string{d.Get("account_id").(string)}
+	_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyImageSharePermission(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, args.ImageId, args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	d.SetId(args.ImageId + COLON_SEPARATED + d.Get("account_id").(string))
+
+	return resourceAliyunImageSharePermissionRead(d, meta)
+}
+
+func resourceAliyunImageSharePermissionRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	imageId, accountId, err := getImageIdAndAccountId(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	if err := ecsService.DescribeImageShareAccount(imageId, accountId); err != nil {
+		if NotFoundError(err) || IsExceptedError(err, InvalidImageIdNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("image_id", imageId)
+	d.Set("account_id", accountId)
+	return nil
+}
+
+func resourceAliyunImageSharePermissionDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	imageId, accountId, err := getImageIdAndAccountId(d)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	args := ecs.CreateModifyImageSharePermissionRequest()
+	args.ImageId = imageId
+	args.RemoveAccount = &[]string{accountId}
+	_, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyImageSharePermission(args)
+	})
+	if err != nil && !IsExceptedError(err, InvalidImageIdNotFound) {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	return nil
+}
+
+func getImageIdAndAccountId(d *schema.ResourceData) (string, string, error) {
+	parts := strings.Split(d.Id(), COLON_SEPARATED)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid resource id %s, it should be <image_id>:<account_id>", d.Id())
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,199 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudImage_basic(t *testing.T) {
+	var image ecs.Image
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_image.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImageConfigInstance(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("alicloud_image.foo", &image),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "name", "tf-testAccImageConfigInstance"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "description", "Image from the instance."),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "status", string(ImageAvailable)),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "disk_device_mapping.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "disk_device_mapping.0.disk_type", string(DiskTypeSystem)),
+					resource.TestCheckResourceAttrSet("alicloud_image.foo", "architecture"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudImage_update runs the CRUD of the image, its copy and share permission against the fake API server.
+func TestUnitAlicloudImage_update(t *testing.T) {
+	var image, copied ecs.Image
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_image.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckImageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImageConfigSnapshot,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("alicloud_image.foo", &image),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "name", "tf-testAccImageConfigSnapshot"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "description", "Image from the snapshot."),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "architecture", "x86_64"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "disk_device_mapping.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "disk_device_mapping.0.snapshot_id", "s-fake-source"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "tags.%", "1"),
+					server.CheckTags("alicloud_image.foo", map[string]string{"foo": "foo"}),
+					testAccCheckImageExists("alicloud_image_copy.foo", &copied),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "name", "tf-testAccImageConfigSnapshot-copy"),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "status", string(ImageAvailable)),
+					resource.TestCheckResourceAttr("alicloud_image_share_permission.foo", "account_id", "1234567890"),
+				),
+			},
+			{
+				Config: testAccImageConfigSnapshotUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckImageExists("alicloud_image.foo", &image),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "name", "tf-testAccImageConfigSnapshotUpdate"),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "description", "Image updated."),
+					resource.TestCheckResourceAttr("alicloud_image.foo", "tags.%", "1"),
+					server.CheckTags("alicloud_image.foo", map[string]string{"bar": "bar"}),
+					testAccCheckImageExists("alicloud_image_copy.foo", &copied),
+					resource.TestCheckResourceAttr("alicloud_image_copy.foo", "description", "Copy updated."),
+					resource.TestCheckResourceAttr("alicloud_image_share_permission.foo", "account_id", "1234567891"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckImageExists(n string, image *ecs.Image) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Image ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		ecsService := EcsService{client}
+		i, err := ecsService.DescribeImageById(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+
+		*image = i
+		return nil
+	}
+}
+
+func testAccCheckImageDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_image" && rs.Type != "alicloud_image_copy" {
+			continue
+		}
+
+		image, err := ecsService.DescribeImageById(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("Image %s still exist", image.ImageId))
+	}
+
+	return nil
+}
+
+func testAccImageConfigInstance(common string) string {
+	return fmt.Sprintf(`
+	%s
+
+	variable "name" {
+		default = "tf-testAccImageConfigInstance"
+	}
+	resource "alicloud_instance" "foo" {
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		system_disk_category = "cloud_efficiency"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		instance_name = "${var.name}"
+	}
+
+	resource "alicloud_image" "foo" {
+		instance_id = "${alicloud_instance.foo.id}"
+		name = "${var.name}"
+		description = "Image from the instance."
+	}`, common)
+}
+
+const testAccImageConfigSnapshot = `
+resource "alicloud_image" "foo" {
+	snapshot_id = "s-fake-source"
+	name = "tf-testAccImageConfigSnapshot"
+	description = "Image from the snapshot."
+	tags {
+		foo = "foo"
+	}
+}
+
+resource "alicloud_image_copy" "foo" {
+	source_image_id = "${alicloud_image.foo.id}"
+	source_region_id = "cn-beijing"
+	name = "tf-testAccImageConfigSnapshot-copy"
+}
+
+resource "alicloud_image_share_permission" "foo" {
+	image_id = "${alicloud_image.foo.id}"
+	account_id = "1234567890"
+}
+`
+
+const testAccImageConfigSnapshotUpdate = `
+resource "alicloud_image" "foo" {
+	snapshot_id = "s-fake-source"
+	name = "tf-testAccImageConfigSnapshotUpdate"
+	description = "Image updated."
+	tags {
+		bar = "bar"
+	}
+}
+
+resource "alicloud_image_copy" "foo" {
+	source_image_id = "${alicloud_image.foo.id}"
+	source_region_id = "cn-beijing"
+	name = "tf-testAccImageConfigSnapshot-copy"
+	description = "Copy updated."
+}
+
+resource "alicloud_image_share_permission" "foo" {
+	image_id = "${alicloud_image.foo.id}"
+	account_id = "1234567891"
+}
+`
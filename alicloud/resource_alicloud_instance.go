@@ -26,6 +26,14 @@ func resourceAliyunInstance() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		CustomizeDiff: ecsInstanceCustomizeDiffFunc,
+
 		Schema: map[string]*schema.Schema{
 			"availability_zone": {
 				Type:     schema.TypeString,
@@ -35,20 +43,47 @@ func resourceAliyunInstance() *schema.Resource {
 			},
 
 			"image_id": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
 			},
 
 			"instance_type": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ValidateFunc: validateInstanceType,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateInstanceType,
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
 			},
 
 			"security_groups": {
-				Type:     schema.TypeSet,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Required: true,
+				Type:             schema.TypeSet,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				Optional:         true,
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
+			},
+
+			"launch_template_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"launch_template_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"dedicated_host_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"deployment_set_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
 			},
 
 			"allocate_public_ip": {
@@ -58,10 +93,11 @@ func resourceAliyunInstance() *schema.Resource {
 			},
 
 			"instance_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Default:      "ECS-Instance",
-				ValidateFunc: validateInstanceName,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "ECS-Instance",
+				ValidateFunc:     validateInstanceName,
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
 			},
 
 			"description": {
@@ -85,10 +121,11 @@ func resourceAliyunInstance() *schema.Resource {
 				DiffSuppressFunc: ecsInternetDiffSuppressFunc,
 			},
 			"internet_max_bandwidth_out": {
-				Type:         schema.TypeInt,
-				Optional:     true,
-				Default:      0,
-				ValidateFunc: validateIntegerInRange(0, 100),
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateFunc:     validateIntegerInRange(0, 100),
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
 			},
 			"host_name": {
 				Type:     schema.TypeString,
@@ -110,16 +147,18 @@ func resourceAliyunInstance() *schema.Resource {
 				Optional: true,
 			},
 			"system_disk_category": {
-				Type:         schema.TypeString,
-				Default:      DiskCloudEfficiency,
-				Optional:     true,
-				ForceNew:     true,
-				ValidateFunc: validateDiskCategory,
+				Type:             schema.TypeString,
+				Default:          DiskCloudEfficiency,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validateDiskCategory,
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
 			},
 			"system_disk_size": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  40,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          40,
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
 			},
 			"data_disks": {
 				Type:     schema.TypeList,
@@ -182,8 +221,9 @@ func resourceAliyunInstance() *schema.Resource {
 			},
 
 			"vswitch_id": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: ecsLaunchTemplateDiffSuppressFunc,
 			},
 
 			"private_ip": {
@@ -192,6 +232,93 @@ func resourceAliyunInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"secondary_private_ips": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				MaxItems:      10,
+				ConflictsWith: []string{"secondary_private_ip_address_count"},
+			},
+
+			"secondary_private_ip_address_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateIntegerInRange(1, 10),
+				ConflictsWith: []string{"secondary_private_ips"},
+			},
+
+			"ipv6_addresses": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				MaxItems:      10,
+				ConflictsWith: []string{"ipv6_address_count"},
+			},
+
+			"ipv6_address_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateIntegerInRange(1, 10),
+				ConflictsWith: []string{"ipv6_addresses"},
+			},
+
+			"network_interfaces": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_interface_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vswitch_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"security_groups": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+						"primary_ip": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"secondary_private_ips": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+							MaxItems: 10,
+						},
+						"secondary_private_ip_address_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateIntegerInRange(1, 10),
+						},
+					},
+				},
+			},
+
 			"instance_charge_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -248,14 +375,29 @@ func resourceAliyunInstance() *schema.Resource {
 			},
 
 			"status": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(Running), string(Stopped)}),
 			},
 
+			"stopped_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      KeepCharging,
+				ValidateFunc: validateAllowedStringValue([]string{string(KeepCharging), string(StopCharging)}),
+			},
+
+			// The instance is replaced when the user data changes unless 'reboot_on_user_data_change' is true.
 			"user_data": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
+			},
+
+			"reboot_on_user_data_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
 			},
 			"role_name": {
 				Type:             schema.TypeString,
@@ -288,6 +430,12 @@ func resourceAliyunInstance() *schema.Resource {
 				DiffSuppressFunc: ecsSpotPriceLimitDiffSuppressFunc,
 			},
 
+			// The reason why the spot instance is interrupted, and the instance is replaced once it is set.
+			"spot_interruption": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"deletion_protection": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -302,6 +450,12 @@ func resourceAliyunInstance() *schema.Resource {
 				DiffSuppressFunc: ecsPostPaidDiffSuppressFunc,
 			},
 
+			"stopped_allowed": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
 			"security_enhancement_strategy": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -321,13 +475,15 @@ func resourceAliyunInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
 
-	// Ensure instance_type is valid
-	zoneId, validZones, err := ecsService.DescribeAvailableResources(d, meta, InstanceTypeResource)
-	if err != nil {
-		return err
-	}
-	if err := ecsService.InstanceTypeValidation(d.Get("instance_type").(string), zoneId, validZones); err != nil {
-		return err
+	// Ensure instance_type is valid, and it may be taken from the launch template
+	if instanceType := d.Get("instance_type").(string); instanceType != "" {
+		zoneId, validZones, err := ecsService.DescribeAvailableResources(d, meta, InstanceTypeResource)
+		if err != nil {
+			return err
+		}
+		if err := ecsService.InstanceTypeValidation(instanceType, zoneId, validZones); err != nil {
+			return err
+		}
 	}
 
 	args, err := buildAliyunInstanceArgs(d, meta)
@@ -366,7 +522,7 @@ func resourceAliyunInstanceCreate(d *schema.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	if err := ecsService.WaitForEcsInstance(d.Id(), Running, DefaultTimeoutMedium); err != nil {
+	if err := ecsService.WaitForEcsInstance(d.Id(), Running, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
 		return fmt.Errorf("WaitForInstance %s got error: %#v", Running, err)
 	}
 
@@ -381,12 +537,23 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 
 	if err != nil {
 		if NotFoundError(err) {
+			if SpotStrategyType(d.Get("spot_strategy").(string)) != NoSpot {
+				log.Printf("[WARN] The spot instance %s is not found, and it may have been reclaimed and released.", d.Id())
+			}
 			d.SetId("")
 			return nil
 		}
 		return fmt.Errorf("Error DescribeInstanceAttribute: %#v", err)
 	}
 
+	d.Set("spot_interruption", "")
+	for _, lock := range instance.OperationLocks.LockReason {
+		if lock.LockReason == SpotInstanceRecycling {
+			log.Printf("[WARN] The spot instance %s is reclaimed, and it will be replaced.", d.Id())
+			d.Set("spot_interruption", lock.LockReason)
+		}
+	}
+
 	disk, diskErr := ecsService.QueryInstanceSystemDisk(d.Id())
 
 	if diskErr != nil {
@@ -413,6 +580,8 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("key_name", instance.KeyPairName)
 	d.Set("spot_strategy", instance.SpotStrategy)
 	d.Set("spot_price_limit", instance.SpotPriceLimit)
+	d.Set("dedicated_host_id", instance.DedicatedHostAttribute.DedicatedHostId)
+	d.Set("deployment_set_id", instance.DeploymentSetId)
 	d.Set("internet_charge_type", instance.InternetChargeType)
 	d.Set("deletion_protection", instance.DeletionProtection)
 
@@ -440,6 +609,12 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
+	if instance.VpcAttributes.VSwitchId != "" {
+		if err := readInstanceNetworkInterfaces(d, meta); err != nil {
+			return err
+		}
+	}
+
 	if d.Get("user_data").(string) != "" {
 		args := ecs.CreateDescribeUserDataRequest()
 		args.InstanceId = d.Id()
@@ -492,9 +667,7 @@ func resourceAliyunInstanceRead(d *schema.ResourceData, meta interface{}) error
 	if err != nil && !NotFoundError(err) {
 		return fmt.Errorf("[ERROR] DescribeTags for instance got error: %#v", err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
 
 	return nil
 }
@@ -556,7 +729,12 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 	if err != nil {
 		return err
 	}
-	if imageUpdate || vpcUpdate || passwordUpdate || typeUpdate {
+
+	userDataUpdate, err := modifyInstanceUserData(d, meta, run)
+	if err != nil {
+		return err
+	}
+	if imageUpdate || vpcUpdate || passwordUpdate || typeUpdate || userDataUpdate {
 		run = true
 		log.Printf("[INFO] Need rebooting to make all changes valid.")
 		instance, errDesc := ecsService.DescribeInstanceById(d.Id())
@@ -564,10 +742,16 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			return fmt.Errorf("Describe instance got an error: %#v", errDesc)
 		}
 		if instance.Status == string(Running) {
+			if !d.Get("stopped_allowed").(bool) {
+				return fmt.Errorf("The instance %s needs to be stopped to make the changes take effect, but 'stopped_allowed' is false.", d.Id())
+			}
 			log.Printf("[DEBUG] Stop instance when changing image or password or vpc attribute")
 			stop := ecs.CreateStopInstanceRequest()
 			stop.InstanceId = d.Id()
 			stop.ForceStop = requests.NewBoolean(false)
+			if Status(d.Get("status").(string)) == Stopped {
+				stop.StoppedMode = d.Get("stopped_mode").(string)
+			}
 			_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
 				return ecsClient.StopInstance(stop)
 			})
@@ -576,7 +760,7 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			}
 		}
 
-		if err := ecsService.WaitForEcsInstance(d.Id(), Stopped, DefaultTimeout); err != nil {
+		if err := ecsService.WaitForEcsInstance(d.Id(), Stopped, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Stopped, err)
 		}
 
@@ -592,27 +776,50 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 			return err
 		}
 
-		log.Printf("[DEBUG] Start instance after changing image or password or vpc attribute")
-		start := ecs.CreateStartInstanceRequest()
-		start.InstanceId = d.Id()
-
-		_, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-			return ecsClient.StartInstance(start)
-		})
-		if err != nil {
-			return fmt.Errorf("StartInstance got error: %#v", err)
+		if _, err := modifyInstanceUserData(d, meta, run); err != nil {
+			return err
 		}
 
-		// Start instance sometimes costs more than 8 minutes when os type is centos.
-		if err := ecsService.WaitForEcsInstance(d.Id(), Running, DefaultTimeoutMedium); err != nil {
-			return fmt.Errorf("WaitForInstance %s got error: %#v", Running, err)
+		// The instance is left stopped when it is expected to be stopped.
+		if Status(d.Get("status").(string)) != Stopped {
+			log.Printf("[DEBUG] Start instance after changing image or password or vpc attribute")
+			start := ecs.CreateStartInstanceRequest()
+			start.InstanceId = d.Id()
+
+			_, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+				return ecsClient.StartInstance(start)
+			})
+			if err != nil {
+				return fmt.Errorf("StartInstance got error: %#v", err)
+			}
+
+			// Start instance sometimes costs more than 8 minutes when os type is centos.
+			if err := ecsService.WaitForEcsInstance(d.Id(), Running, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
+				return fmt.Errorf("WaitForInstance %s got error: %#v", Running, err)
+			}
 		}
 	}
 
+	if err := modifyInstanceStatus(d, meta); err != nil {
+		return err
+	}
+
 	if err := modifyInstanceNetworkSpec(d, meta); err != nil {
 		return err
 	}
 
+	if err := modifyInstancePrivateIps(d, meta); err != nil {
+		return err
+	}
+
+	if err := modifyInstanceIpv6Addresses(d, meta); err != nil {
+		return err
+	}
+
+	if err := modifyInstanceNetworkInterfaces(d, meta); err != nil {
+		return err
+	}
+
 	updateRenewal := false
 	if d.HasChange("instance_charge_type") {
 		if _, n := d.GetChange("instance_charge_type"); n.(string) == string(PrePaid) {
@@ -648,6 +855,14 @@ func resourceAliyunInstanceUpdate(d *schema.ResourceData, meta interface{}) erro
 		d.SetPartial("force_delete")
 	}
 
+	if d.HasChange("stopped_allowed") {
+		d.SetPartial("stopped_allowed")
+	}
+
+	if d.HasChange("reboot_on_user_data_change") {
+		d.SetPartial("reboot_on_user_data_change")
+	}
+
 	d.Partial(false)
 	return resourceAliyunInstanceRead(d, meta)
 }
@@ -672,7 +887,7 @@ func resourceAliyunInstanceDelete(d *schema.ResourceData, meta interface{}) erro
 	deld.InstanceId = d.Id()
 	deld.Force = requests.NewBoolean(true)
 
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	err := resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		instance, err := ecsService.DescribeInstanceById(d.Id())
 		if err != nil {
 			if NotFoundError(err) {
@@ -705,7 +920,20 @@ func resourceAliyunInstanceDelete(d *schema.ResourceData, meta interface{}) erro
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
+	// The network interfaces declared inline are detached along with releasing the instance, and then they can be deleted.
+	for _, raw := range d.Get("network_interfaces").([]interface{}) {
+		eni := raw.(map[string]interface{})
+		if eniId := eni["network_interface_id"].(string); eniId != "" {
+			if err := ecsService.DeleteNetworkInterface(eniId); err != nil {
+				return WrapError(err)
+			}
+		}
+	}
+	return nil
 }
 
 func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.RunInstancesRequest, error) {
@@ -733,16 +961,36 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Run
 			return nil, err
 		}
 
-		if err := ecsService.DiskAvailable(zone, systemDiskCategory); err != nil {
-			return nil, err
+		if systemDiskCategory != "" {
+			if err := ecsService.DiskAvailable(zone, systemDiskCategory); err != nil {
+				return nil, err
+			}
 		}
 
 		args.ZoneId = zoneID
 
 	}
 
+	// The arguments suppressed by ecsLaunchTemplateDiffSuppressFunc are empty, and they are taken from the launch template.
+	if v, ok := d.GetOk("launch_template_id"); ok {
+		args.LaunchTemplateId = v.(string)
+		if version, ok := d.GetOk("launch_template_version"); ok {
+			args.LaunchTemplateVersion = requests.NewInteger(version.(int))
+		}
+	}
+
+	// The instance is placed on the dedicated host or spread over the physical servers by the deployment set.
+	if v, ok := d.GetOk("dedicated_host_id"); ok {
+		args.DedicatedHostId = v.(string)
+	}
+	if v, ok := d.GetOk("deployment_set_id"); ok {
+		args.DeploymentSetId = v.(string)
+	}
+
 	args.SystemDiskCategory = string(systemDiskCategory)
-	args.SystemDiskSize = strconv.Itoa(d.Get("system_disk_size").(int))
+	if size := d.Get("system_disk_size").(int); size > 0 {
+		args.SystemDiskSize = strconv.Itoa(size)
+	}
 
 	sgs, ok := d.GetOk("security_groups")
 
@@ -772,7 +1020,9 @@ func buildAliyunInstanceArgs(d *schema.ResourceData, meta interface{}) (*ecs.Run
 		args.InternetChargeType = v
 	}
 
-	args.InternetMaxBandwidthOut = requests.NewInteger(d.Get("internet_max_bandwidth_out").(int))
+	if v := d.Get("internet_max_bandwidth_out").(int); v > 0 || args.LaunchTemplateId == "" {
+		args.InternetMaxBandwidthOut = requests.NewInteger(v)
+	}
 
 	if v := d.Get("host_name").(string); v != "" {
 		args.HostName = v
@@ -889,20 +1139,10 @@ func modifyInstanceChargeType(d *schema.ResourceData, meta interface{}, forceDel
 			args.PeriodUnit = d.Get("period_unit").(string)
 		}
 		args.InstanceChargeType = chargeType
-		if err := resource.Retry(6*time.Minute, func() *resource.RetryError {
-			_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-				return ecsClient.ModifyInstanceChargeType(args)
-			})
-			if err != nil {
-				if IsExceptedErrors(err, []string{Throttling}) {
-					time.Sleep(10 * time.Second)
-					return resource.RetryableError(fmt.Errorf("Modifying instance %s chareType timeout and got an error:%#v.", d.Id(), err))
-				}
-				return resource.NonRetryableError(fmt.Errorf("Modifying instance %s chareType timeout and got an error: %#v.", d.Id(), err))
-			}
-			return nil
+		if _, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyInstanceChargeType(args)
 		}); err != nil {
-			return err
+			return fmt.Errorf("Modifying instance %s chareType got an error: %#v.", d.Id(), err)
 		}
 		// Wait for instance charge type has been changed
 		if err := resource.Retry(3*time.Minute, func() *resource.RetryError {
@@ -943,7 +1183,9 @@ func modifyInstanceImage(d *schema.ResourceData, meta interface{}, run bool) (bo
 		args := ecs.CreateReplaceSystemDiskRequest()
 		args.InstanceId = d.Id()
 		args.ImageId = d.Get("image_id").(string)
-		args.SystemDiskSize = requests.NewInteger(d.Get("system_disk_size").(int))
+		if size := d.Get("system_disk_size").(int); size > 0 {
+			args.SystemDiskSize = requests.NewInteger(size)
+		}
 		args.ClientToken = buildClientToken("TF-ReplaceSystemDisk")
 		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
 			return ecsClient.ReplaceSystemDisk(args)
@@ -953,7 +1195,7 @@ func modifyInstanceImage(d *schema.ResourceData, meta interface{}, run bool) (bo
 		}
 
 		// Ensure instance's image has been replaced successfully.
-		timeout := DefaultTimeoutMedium
+		timeout := int(d.Timeout(schema.TimeoutUpdate).Seconds())
 		for {
 			instance, errDesc := ecsService.DescribeInstanceById(d.Id())
 			if errDesc != nil {
@@ -963,7 +1205,7 @@ func modifyInstanceImage(d *schema.ResourceData, meta interface{}, run bool) (bo
 			if instance.ImageId == d.Get("image_id") {
 				break
 			}
-			time.Sleep(DefaultIntervalShort * time.Second)
+			time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 			timeout = timeout - DefaultIntervalShort
 			if timeout <= 0 {
@@ -988,6 +1230,80 @@ func modifyInstanceImage(d *schema.ResourceData, meta interface{}, run bool) (bo
 	return update, nil
 }
 
+// modifyInstanceUserData replaces the user data of the instance, which only takes effect after the instance is
+// stopped and started again.
+func modifyInstanceUserData(d *schema.ResourceData, meta interface{}, run bool) (bool, error) {
+	if d.IsNewResource() || !d.HasChange("user_data") {
+		return false, nil
+	}
+	if !run {
+		return true, nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	args := ecs.CreateModifyInstanceAttributeRequest()
+	args.InstanceId = d.Id()
+	args.UserData = base64.StdEncoding.EncodeToString([]byte(d.Get("user_data").(string)))
+	_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyInstanceAttribute(args)
+	})
+	if err != nil {
+		return true, WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	d.SetPartial("user_data")
+	return true, nil
+}
+
+// modifyInstanceStatus starts or stops the instance to bring it to the expected status.
+func modifyInstanceStatus(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	target := Status(d.Get("status").(string))
+	if target != Running && target != Stopped {
+		return nil
+	}
+	instance, err := ecsService.DescribeInstanceById(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	if Status(instance.Status) == target {
+		d.SetPartial("status")
+		d.SetPartial("stopped_mode")
+		return nil
+	}
+
+	if target == Stopped {
+		if Status(instance.Status) != Stopping {
+			args := ecs.CreateStopInstanceRequest()
+			args.InstanceId = d.Id()
+			args.ForceStop = requests.NewBoolean(false)
+			// The VPC instance stops charging for its vCPUs and memory when stopped in 'StopCharging' mode.
+			args.StoppedMode = d.Get("stopped_mode").(string)
+			_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+				return ecsClient.StopInstance(args)
+			})
+			if err != nil {
+				return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+			}
+		}
+	} else if Status(instance.Status) != Starting {
+		args := ecs.CreateStartInstanceRequest()
+		args.InstanceId = d.Id()
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.StartInstance(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+	}
+
+	if err := ecsService.WaitForEcsInstance(d.Id(), target, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
+		return WrapError(err)
+	}
+	d.SetPartial("status")
+	d.SetPartial("stopped_mode")
+	return nil
+}
+
 func modifyInstanceAttribute(d *schema.ResourceData, meta interface{}) (bool, error) {
 	if d.IsNewResource() {
 		return false, nil
@@ -1113,9 +1429,6 @@ func modifyInstanceType(d *schema.ResourceData, meta interface{}, run bool) (boo
 		if !run {
 			return update, nil
 		}
-		if d.Get("instance_charge_type").(string) == string(PrePaid) {
-			return update, fmt.Errorf("At present, 'PrePaid' instance type cannot be modified.")
-		}
 		// Ensure instance_type is valid
 		zoneId, validZones, err := ecsService.DescribeAvailableResources(d, meta, InstanceTypeResource)
 		if err != nil {
@@ -1127,26 +1440,36 @@ func modifyInstanceType(d *schema.ResourceData, meta interface{}, run bool) (boo
 
 		d.SetPartial("instance_type")
 
-		//An instance that was successfully modified once cannot be modified again within 5 minutes.
-		args := ecs.CreateModifyInstanceSpecRequest()
-		args.InstanceId = d.Id()
-		args.InstanceType = d.Get("instance_type").(string)
-		args.ClientToken = buildClientToken("TF-ModifyInstanceSpec")
+		// The charge type is changed after the instance type, so the spec is modified according to the former one.
+		// An instance that was successfully modified once cannot be modified again within 5 minutes.
+		if o, _ := d.GetChange("instance_charge_type"); o.(string) == string(PrePaid) {
+			args := ecs.CreateModifyPrepayInstanceSpecRequest()
+			args.InstanceId = d.Id()
+			args.InstanceType = d.Get("instance_type").(string)
+			args.AutoPay = requests.NewBoolean(true)
+			args.ClientToken = buildClientToken("TF-ModifyPrepayInstanceSpec")
 
-		err = resource.Retry(6*time.Minute, func() *resource.RetryError {
 			_, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
-				return ecsClient.ModifyInstanceSpec(args)
+				return ecsClient.ModifyPrepayInstanceSpec(args)
 			})
 			if err != nil {
-				if IsExceptedError(err, EcsThrottling) {
-					time.Sleep(10 * time.Second)
-					return resource.RetryableError(fmt.Errorf("Modify instance type timeout and got an error; %#v", err))
-				}
-				return resource.NonRetryableError(fmt.Errorf("Modify instance type got an error: %#v", err))
+				return update, WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
 			}
-			return nil
+			return update, nil
+		}
+
+		args := ecs.CreateModifyInstanceSpecRequest()
+		args.InstanceId = d.Id()
+		args.InstanceType = d.Get("instance_type").(string)
+		args.ClientToken = buildClientToken("TF-ModifyInstanceSpec")
+
+		_, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyInstanceSpec(args)
 		})
-		return update, err
+		if err != nil {
+			return update, WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		return update, nil
 	}
 	return update, nil
 }
@@ -1218,3 +1541,413 @@ func modifyInstanceNetworkSpec(d *schema.ResourceData, meta interface{}) error {
 	}
 	return nil
 }
+
+// readInstanceNetworkInterfaces reads the secondary private IPs and IPv6 addresses of the primary network interface, and
+// the network interfaces declared inline. The ones attached by alicloud_network_interface_attachment are left out.
+func readInstanceNetworkInterfaces(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	primaries, err := ecsService.DescribeInstanceNetworkInterfaces(d.Id(), NetworkInterfacePrimary)
+	if err != nil {
+		return WrapError(err)
+	}
+	if len(primaries) > 0 {
+		ips := secondaryPrivateIps(primaries[0])
+		d.Set("secondary_private_ips", ips)
+		d.Set("secondary_private_ip_address_count", len(ips))
+		ipv6s := ipv6Addresses(primaries[0])
+		d.Set("ipv6_addresses", ipv6s)
+		d.Set("ipv6_address_count", len(ipv6s))
+	}
+
+	var enis []map[string]interface{}
+	for _, raw := range d.Get("network_interfaces").([]interface{}) {
+		eniId := raw.(map[string]interface{})["network_interface_id"].(string)
+		if eniId == "" {
+			continue
+		}
+		// The network interface is read even if it is not attached, so that it can still be deleted along with the instance.
+		eni, err := ecsService.DescribeNetworkInterfaceById("", eniId)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		ips := secondaryPrivateIps(eni)
+		enis = append(enis, map[string]interface{}{
+			"network_interface_id":               eni.NetworkInterfaceId,
+			"vswitch_id":                         eni.VSwitchId,
+			"security_groups":                    schema.NewSet(schema.HashString, flattenStringList(eni.SecurityGroupIds.SecurityGroupId)),
+			"primary_ip":                         eni.PrivateIpAddress,
+			"name":                               eni.NetworkInterfaceName,
+			"description":                        eni.Description,
+			"secondary_private_ips":              schema.NewSet(schema.HashString, flattenStringList(ips)),
+			"secondary_private_ip_address_count": len(ips),
+		})
+	}
+	if err := d.Set("network_interfaces", enis); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+func secondaryPrivateIps(eni ecs.NetworkInterfaceSet) []string {
+	ips := make([]string, 0, len(eni.PrivateIpSets.PrivateIpSet))
+	for _, ip := range eni.PrivateIpSets.PrivateIpSet {
+		if !ip.Primary {
+			ips = append(ips, ip.PrivateIpAddress)
+		}
+	}
+	return ips
+}
+
+// modifyInstancePrivateIps assigns or unassigns the secondary private IPs of the primary network interface.
+func modifyInstancePrivateIps(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("secondary_private_ips") && !d.HasChange("secondary_private_ip_address_count") {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	primaries, err := ecsService.DescribeInstanceNetworkInterfaces(d.Id(), NetworkInterfacePrimary)
+	if err != nil {
+		return WrapError(err)
+	}
+	if len(primaries) < 1 {
+		return WrapError(fmt.Errorf("Secondary private IPs are only supported by the VPC instance."))
+	}
+	if err := modifyNetworkInterfacePrivateIps(d, meta, primaries[0].NetworkInterfaceId, "secondary_private_ips", "secondary_private_ip_address_count"); err != nil {
+		return err
+	}
+	d.SetPartial("secondary_private_ips")
+	d.SetPartial("secondary_private_ip_address_count")
+	return nil
+}
+
+// modifyNetworkInterfacePrivateIps updates the secondary private IPs of the network interface from the given keys,
+// and only one of them is set in the configuration.
+func modifyNetworkInterfacePrivateIps(d *schema.ResourceData, meta interface{}, eniId, ipsKey, countKey string) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	if d.HasChange(ipsKey) {
+		o, n := d.GetChange(ipsKey)
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		if unassignIps := expandStringList(os.Difference(ns).List()); len(unassignIps) > 0 {
+			if err := ecsService.UnassignPrivateIps(eniId, unassignIps); err != nil {
+				return WrapError(err)
+			}
+		}
+		if assignIps := expandStringList(ns.Difference(os).List()); len(assignIps) > 0 {
+			if err := ecsService.AssignPrivateIps(eniId, assignIps, 0); err != nil {
+				return WrapError(err)
+			}
+		}
+		return WrapError(ecsService.WaitForPrivateIpsListChanged(eniId, expandStringList(ns.List())))
+	}
+
+	if d.HasChange(countKey) {
+		count := d.Get(countKey).(int)
+		ips, err := ecsService.QueryPrivateIps(eniId)
+		if err != nil {
+			return WrapError(err)
+		}
+		if count > len(ips) {
+			if err := ecsService.AssignPrivateIps(eniId, nil, count-len(ips)); err != nil {
+				return WrapError(err)
+			}
+		}
+		if count < len(ips) {
+			if err := ecsService.UnassignPrivateIps(eniId, ips[:len(ips)-count]); err != nil {
+				return WrapError(err)
+			}
+		}
+		return WrapError(ecsService.WaitForPrivateIpsCountChanged(eniId, count))
+	}
+	return nil
+}
+
+// modifyInstanceIpv6Addresses assigns or unassigns the IPv6 addresses of the primary network interface.
+func modifyInstanceIpv6Addresses(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("ipv6_addresses") && !d.HasChange("ipv6_address_count") {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	primaries, err := ecsService.DescribeInstanceNetworkInterfaces(d.Id(), NetworkInterfacePrimary)
+	if err != nil {
+		return WrapError(err)
+	}
+	if len(primaries) < 1 {
+		return WrapError(fmt.Errorf("IPv6 addresses are only supported by the VPC instance."))
+	}
+	if err := modifyNetworkInterfaceIpv6Addresses(d, meta, primaries[0].NetworkInterfaceId, "ipv6_addresses", "ipv6_address_count"); err != nil {
+		return err
+	}
+	d.SetPartial("ipv6_addresses")
+	d.SetPartial("ipv6_address_count")
+	return nil
+}
+
+// modifyNetworkInterfaceIpv6Addresses updates the IPv6 addresses of the network interface from the given keys, and
+// only one of them is set in the configuration.
+func modifyNetworkInterfaceIpv6Addresses(d *schema.ResourceData, meta interface{}, eniId, ipsKey, countKey string) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	if d.HasChange(ipsKey) {
+		o, n := d.GetChange(ipsKey)
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		if unassignIps := expandStringList(os.Difference(ns).List()); len(unassignIps) > 0 {
+			if err := ecsService.UnassignIpv6Addresses(eniId, unassignIps); err != nil {
+				return WrapError(err)
+			}
+		}
+		if assignIps := expandStringList(ns.Difference(os).List()); len(assignIps) > 0 {
+			if err := ecsService.AssignIpv6Addresses(eniId, assignIps, 0); err != nil {
+				return WrapError(err)
+			}
+		}
+		return WrapError(ecsService.WaitForIpv6AddressesChanged(eniId, expandStringList(ns.List()), 0))
+	}
+
+	if d.HasChange(countKey) {
+		count := d.Get(countKey).(int)
+		ips, err := ecsService.QueryIpv6Addresses(eniId)
+		if err != nil {
+			return WrapError(err)
+		}
+		if count > len(ips) {
+			if err := ecsService.AssignIpv6Addresses(eniId, nil, count-len(ips)); err != nil {
+				return WrapError(err)
+			}
+		}
+		if count < len(ips) {
+			if err := ecsService.UnassignIpv6Addresses(eniId, ips[:len(ips)-count]); err != nil {
+				return WrapError(err)
+			}
+		}
+		return WrapError(ecsService.WaitForIpv6AddressesChanged(eniId, nil, count))
+	}
+	return nil
+}
+
+// modifyInstanceNetworkInterfaces creates and attaches the network interfaces declared inline, and the ones whose
+// VSwitch or primary IP is changed are replaced. The others are updated in place.
+func modifyInstanceNetworkInterfaces(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("network_interfaces") {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	o, n := d.GetChange("network_interfaces")
+	olds := o.([]interface{})
+	news := n.([]interface{})
+
+	kept := func(i int) bool {
+		if i >= len(olds) || i >= len(news) {
+			return false
+		}
+		old := olds[i].(map[string]interface{})
+		eni := news[i].(map[string]interface{})
+		if old["network_interface_id"].(string) == "" || old["vswitch_id"] != eni["vswitch_id"] {
+			return false
+		}
+		primaryIp := eni["primary_ip"].(string)
+		return primaryIp == "" || primaryIp == old["primary_ip"]
+	}
+
+	for i, raw := range olds {
+		eniId := raw.(map[string]interface{})["network_interface_id"].(string)
+		if eniId == "" || kept(i) {
+			continue
+		}
+		if err := ecsService.DetachNetworkInterface(d.Id(), eniId); err != nil && !NotFoundError(err) {
+			return WrapError(err)
+		}
+		if err := ecsService.DeleteNetworkInterface(eniId); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	var enis []interface{}
+	// saveNetworkInterfaces keeps the IDs of the network interfaces created so far and the kept ones not updated
+	// yet, so that they can be read and deleted along with the instance even if a later step fails.
+	saveNetworkInterfaces := func(next int) error {
+		all := append([]interface{}{}, enis...)
+		for j := next; j < len(olds); j++ {
+			if kept(j) {
+				all = append(all, olds[j])
+			}
+		}
+		if err := d.Set("network_interfaces", all); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("network_interfaces")
+		return nil
+	}
+
+	for i, raw := range news {
+		eni := raw.(map[string]interface{})
+		prefix := fmt.Sprintf("network_interfaces.%d.", i)
+
+		if kept(i) {
+			eniId := olds[i].(map[string]interface{})["network_interface_id"].(string)
+			eni["network_interface_id"] = eniId
+			if d.HasChange(prefix+"name") || d.HasChange(prefix+"description") || d.HasChange(prefix+"security_groups") {
+				args := ecs.CreateModifyNetworkInterfaceAttributeRequest()
+				args.NetworkInterfaceId = eniId
+				args.NetworkInterfaceName = eni["name"].(string)
+				args.Description = eni["description"].(string)
+				if groups := expandStringList(eni["security_groups"].(*schema.Set).List()); len(groups) > 0 {
+					args.SecurityGroupId = &groups
+				}
+				_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+					return ecsClient.ModifyNetworkInterfaceAttribute(args)
+				})
+				if err != nil {
+					return WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR)
+				}
+			}
+			if err := modifyNetworkInterfacePrivateIps(d, meta, eniId, prefix+"secondary_private_ips", prefix+"secondary_private_ip_address_count"); err != nil {
+				return err
+			}
+			enis = append(enis, eni)
+			continue
+		}
+
+		eniId, err := createInstanceNetworkInterface(d, meta, eni)
+		if eniId != "" {
+			eni["network_interface_id"] = eniId
+			enis = append(enis, eni)
+			if err := saveNetworkInterfaces(i + 1); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return saveNetworkInterfaces(len(news))
+}
+
+// createInstanceNetworkInterface creates a network interface and attaches it to the instance. If the network interface
+// can not be set up and can not be deleted either, its ID is returned along with the error so that it is kept in the state.
+func createInstanceNetworkInterface(d *schema.ResourceData, meta interface{}, eni map[string]interface{}) (string, error) {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	// The network interface joins the security groups of the instance by default.
+	groups := expandStringList(eni["security_groups"].(*schema.Set).List())
+	if len(groups) < 1 {
+		instance, err := ecsService.DescribeInstanceById(d.Id())
+		if err != nil {
+			return "", WrapError(err)
+		}
+		groups = instance.SecurityGroupIds.SecurityGroupId
+	}
+	if len(groups) < 1 {
+		return "", WrapError(fmt.Errorf("The network interface in %s requires at least one security group.", eni["vswitch_id"]))
+	}
+
+	args := ecs.CreateCreateNetworkInterfaceRequest()
+	args.VSwitchId = eni["vswitch_id"].(string)
+	args.SecurityGroupId = groups[0]
+	args.PrimaryIpAddress = eni["primary_ip"].(string)
+	args.NetworkInterfaceName = eni["name"].(string)
+	args.Description = eni["description"].(string)
+	args.ClientToken = buildClientToken("TF-CreateNetworkInterface")
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateNetworkInterface(args)
+	})
+	if err != nil {
+		return "", WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.CreateNetworkInterfaceResponse)
+	if resp == nil {
+		return "", WrapError(fmt.Errorf("CreateNetworkInterface got a nil response: %#v", resp))
+	}
+	eniId := resp.NetworkInterfaceId
+
+	if err := setUpInstanceNetworkInterface(d, meta, eniId, eni, groups); err != nil {
+		// The network interface which can not be set up is deleted, otherwise it is left out of the state.
+		if e := deleteInstanceNetworkInterface(d, meta, eniId); e != nil {
+			log.Printf("[WARN] Deleting the network interface %s which failed to be set up got an error: %#v", eniId, e)
+			return eniId, err
+		}
+		return "", err
+	}
+	return eniId, nil
+}
+
+// setUpInstanceNetworkInterface attaches the new network interface to the instance and assigns its secondary private IPs.
+func setUpInstanceNetworkInterface(d *schema.ResourceData, meta interface{}, eniId string, eni map[string]interface{}, groups []string) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	if err := ecsService.WaitForEcsNetworkInterface(eniId, Available, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+
+	if len(groups) > 1 {
+		req := ecs.CreateModifyNetworkInterfaceAttributeRequest()
+		req.NetworkInterfaceId = eniId
+		req.SecurityGroupId = &groups
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyNetworkInterfaceAttribute(req)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, eniId, req.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+	}
+
+	if err := ecsService.AttachNetworkInterface(d.Id(), eniId); err != nil {
+		return WrapError(err)
+	}
+
+	if ips := expandStringList(eni["secondary_private_ips"].(*schema.Set).List()); len(ips) > 0 {
+		if err := ecsService.AssignPrivateIps(eniId, ips, 0); err != nil {
+			return WrapError(err)
+		}
+		if err := ecsService.WaitForPrivateIpsListChanged(eniId, ips); err != nil {
+			return WrapError(err)
+		}
+	} else if count := eni["secondary_private_ip_address_count"].(int); count > 0 {
+		if err := ecsService.AssignPrivateIps(eniId, nil, count); err != nil {
+			return WrapError(err)
+		}
+		if err := ecsService.WaitForPrivateIpsCountChanged(eniId, count); err != nil {
+			return WrapError(err)
+		}
+	}
+	return nil
+}
+
+// deleteInstanceNetworkInterface detaches the network interface from the instance if it is attached, and deletes it.
+func deleteInstanceNetworkInterface(d *schema.ResourceData, meta interface{}, eniId string) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	eni, err := ecsService.DescribeNetworkInterfaceById("", eniId)
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	if eni.InstanceId == d.Id() {
+		if err := ecsService.DetachNetworkInterface(d.Id(), eniId); err != nil && !NotFoundError(err) {
+			return WrapError(err)
+		}
+	}
+	return WrapError(ecsService.DeleteNetworkInterface(eniId))
+}
@@ -3,6 +3,8 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"net/http"
+	"regexp"
 	"testing"
 
 	"strings"
@@ -482,6 +484,435 @@ func TestAccAlicloudInstance_update(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudInstance_update runs the CRUD of the instance in VPC against the fake API server.
+func TestUnitAlicloudInstance_update(t *testing.T) {
+	var instance ecs.Instance
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigOrigin(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_name", "tf-testAccCheckInstanceConfigOrigin-foo"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "host_name", "host-foo"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "image_id", fakeApiImage),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_type", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "availability_zone", fakeApiZones[0]),
+					resource.TestCheckResourceAttrSet("alicloud_instance.foo", "private_ip"),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigOriginUpdate(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_name", "tf-testAccCheckInstanceConfigOrigin-bar"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "host_name", "host-bar"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudInstance_networkInterfaces runs the secondary private IPs and the network interfaces declared inline
+// against the fake API server, and the network interfaces are deleted along with the instance.
+func TestUnitAlicloudInstance_networkInterfaces(t *testing.T) {
+	var instance ecs.Instance
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckInstanceDestroy(s); err != nil {
+				return err
+			}
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+			if len(server.networkInterfaces) > 0 {
+				return fmt.Errorf("expected the network interfaces to be deleted, got %d", len(server.networkInterfaces))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigNetworkInterfaces(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "secondary_private_ip_address_count", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "secondary_private_ips.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.#", "1"),
+					resource.TestCheckResourceAttrSet("alicloud_instance.foo", "network_interfaces.0.network_interface_id"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.name", "tf-testAccCheckInstanceConfigNetworkInterfaces"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.primary_ip", "172.16.0.20"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.security_groups.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.secondary_private_ips.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.secondary_private_ip_address_count", "1"),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigNetworkInterfacesUpdate(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "secondary_private_ips.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "secondary_private_ip_address_count", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.name", "tf-testAccCheckInstanceConfigNetworkInterfaces-bar"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.primary_ip", "172.16.0.20"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.0.secondary_private_ips.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.1.secondary_private_ip_address_count", "3"),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigOrigin(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.#", "0"),
+					func(*terraform.State) error {
+						// Only the primary network interface of the instance is left.
+						server.mutex.Lock()
+						defer server.mutex.Unlock()
+						if len(server.networkInterfaces) != 1 {
+							return fmt.Errorf("expected the network interfaces declared inline to be deleted, got %d interfaces", len(server.networkInterfaces))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudInstance_networkInterfacesFailure checks that no network interface declared inline is left behind when
+// one of them fails to be attached.
+func TestUnitAlicloudInstance_networkInterfacesFailure(t *testing.T) {
+	var instance ecs.Instance
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	attach := server.handlers["ECS.AttachNetworkInterface"]
+	attached := 0
+	failAttaching := func() {
+		server.Handle("ECS.AttachNetworkInterface", func(request *fakeApiRequest) (interface{}, error) {
+			if attached++; attached > 1 {
+				return nil, &fakeApiError{http.StatusBadRequest, "InvalidParameter", "The network interface can not be attached."}
+			}
+			return attach(request)
+		})
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckInstanceDestroy(s); err != nil {
+				return err
+			}
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+			if len(server.networkInterfaces) > 0 {
+				return fmt.Errorf("expected the network interfaces to be deleted, got %d", len(server.networkInterfaces))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigOrigin(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					func(*terraform.State) error {
+						failAttaching()
+						return nil
+					},
+				),
+			},
+			{
+				Config:      testAccCheckInstanceConfigNetworkInterfacesUpdate(EcsInstanceCommonTestCase),
+				ExpectError: regexp.MustCompile("The network interface can not be attached"),
+			},
+			{
+				Config: testAccCheckInstanceConfigOrigin(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "network_interfaces.#", "0"),
+					func(*terraform.State) error {
+						// The interface created before the failure is kept in the state and deleted along with the
+						// change, and the one failed to be attached is deleted right away.
+						server.mutex.Lock()
+						defer server.mutex.Unlock()
+						if len(server.networkInterfaces) != 1 {
+							return fmt.Errorf("expected only the primary network interface to be left, got %d interfaces", len(server.networkInterfaces))
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlicloudInstance_ipv6(t *testing.T) {
+	var instance ecs.Instance
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigIpv6(EcsInstanceIpv6CommonTestCase, "ipv6_address_count = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "ipv6_address_count", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "ipv6_addresses.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudInstance_ipv6 runs assigning and unassigning the IPv6 addresses of the primary network interface of
+// the instance against the fake API server.
+func TestUnitAlicloudInstance_ipv6(t *testing.T) {
+	var instance ecs.Instance
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigIpv6(EcsInstanceIpv6CommonTestCase, "ipv6_address_count = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "ipv6_address_count", "2"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "ipv6_addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigIpv6(EcsInstanceIpv6CommonTestCase, `ipv6_addresses = ["2408:4000:1:1::100"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "ipv6_address_count", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "ipv6_addresses.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudInstance_specUpdate runs the instance type resize and the system disk replacement against the fake
+// API server, for both the PostPaid and PrePaid instances.
+func TestUnitAlicloudInstance_specUpdate(t *testing.T) {
+	var instance ecs.Instance
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	// The type ecs.n4.xlarge is sold out in all of the zones.
+	describe := server.handlers["ECS.DescribeAvailableResource"]
+	server.Handle("ECS.DescribeAvailableResource", func(request *fakeApiRequest) (interface{}, error) {
+		response, err := describe(request)
+		if err != nil || request.Params.Get("DestinationResource") != string(InstanceTypeResource) {
+			return response, err
+		}
+		for _, zone := range response.(fakeObject)["AvailableZones"].(fakeObject)["AvailableZone"].([]fakeObject) {
+			resource := zone["AvailableResources"].(fakeObject)["AvailableResource"].([]fakeObject)[0]
+			supported := resource["SupportedResources"].(fakeObject)
+			supported["SupportedResource"] = append(supported["SupportedResource"].([]fakeObject),
+				fakeObject{"Value": "ecs.n4.xlarge", "Status": string(SoldOut)})
+		}
+		return response, nil
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigSpec(EcsInstanceCommonTestCase, fakeApiInstanceTypes[0], "${data.alicloud_images.default.images.0.id}", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_type", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "image_id", fakeApiImage),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "stopped_allowed", "true"),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigSpec(EcsInstanceCommonTestCase, fakeApiInstanceTypes[1], "${alicloud_image.foo.id}", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_type", fakeApiInstanceTypes[1]),
+					resource.TestCheckResourceAttrPair("alicloud_instance.foo", "image_id", "alicloud_image.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(Running)),
+				),
+			},
+			{
+				Config:      testAccCheckInstanceConfigSpec(EcsInstanceCommonTestCase, fakeApiInstanceTypes[0], "${alicloud_image.foo.id}", "stopped_allowed = false"),
+				ExpectError: regexp.MustCompile("'instance_type' can only be changed when the instance is stopped"),
+			},
+			{
+				Config:      testAccCheckInstanceConfigSpec(EcsInstanceCommonTestCase, "ecs.n4.xlarge", "${alicloud_image.foo.id}", ""),
+				ExpectError: regexp.MustCompile("ecs.n4.xlarge"),
+			},
+			{
+				Config: testAccCheckInstanceConfigSpec(EcsInstanceCommonTestCase, fakeApiInstanceTypes[1], "${alicloud_image.foo.id}", testAccCheckInstancePrePaidArgs),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_charge_type", string(PrePaid)),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigSpec(EcsInstanceCommonTestCase, fakeApiInstanceTypes[0], "${alicloud_image.foo.id}", testAccCheckInstancePrePaidArgs),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_charge_type", string(PrePaid)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_type", fakeApiInstanceTypes[0]),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudInstance_powerState runs the power state transitions and the user data changes against the fake API
+// server. The instance is rebooted for the new user data only when 'reboot_on_user_data_change' is true.
+func TestUnitAlicloudInstance_powerState(t *testing.T) {
+	var instance ecs.Instance
+	var instanceId string
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	checkInstanceId := func(replaced bool) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			id := s.RootModule().Resources["alicloud_instance.foo"].Primary.ID
+			if replaced == (id == instanceId) {
+				return fmt.Errorf("expected the instance %s to be replaced: %t, got %s", instanceId, replaced, id)
+			}
+			instanceId = id
+			return nil
+		}
+	}
+	checkStoppedMode := func(expected StoppedMode) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+			instance := server.instances[s.RootModule().Resources["alicloud_instance.foo"].Primary.ID]
+			if instance["StoppedMode"] != string(expected) {
+				return fmt.Errorf("expected the instance to be stopped in %s mode, got %v", expected, instance["StoppedMode"])
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigPowerState(EcsInstanceCommonTestCase, "Stopped", "echo foo", "stopped_mode = \"StopCharging\""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					checkInstanceId(true),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(Stopped)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "stopped_mode", string(StopCharging)),
+					checkStoppedMode(StopCharging),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigPowerState(EcsInstanceCommonTestCase, "Running", "echo foo", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					checkInstanceId(false),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(Running)),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigPowerState(EcsInstanceCommonTestCase, "Running", "echo bar", "reboot_on_user_data_change = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					checkInstanceId(false),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(Running)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "user_data", "echo bar"),
+					checkStoppedMode(KeepCharging),
+				),
+			},
+			{
+				Config: testAccCheckInstanceConfigPowerState(EcsInstanceCommonTestCase, "Stopped", "echo baz", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					checkInstanceId(true),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(Stopped)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "user_data", "echo baz"),
+					checkStoppedMode(KeepCharging),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudInstance_spotInterruption reclaims the spot instance on the fake API server, and the next apply
+// replaces it instead of failing to read it. The spot price history of its zone is listed by the data source.
+func TestUnitAlicloudInstance_spotInterruption(t *testing.T) {
+	var instance ecs.Instance
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_instance.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckInstanceConfigSpot(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "spot_strategy", string(SpotAsPriceGo)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "spot_interruption", ""),
+					resource.TestCheckResourceAttr("data.alicloud_spot_price_history.default", "prices.#", "3"),
+					resource.TestCheckResourceAttr("data.alicloud_spot_price_history.default", "prices.0.zone_id", fakeApiZones[0]),
+					resource.TestCheckResourceAttr("data.alicloud_spot_price_history.default", "prices.0.spot_price", "0.25"),
+					resource.TestCheckResourceAttr("data.alicloud_spot_price_history.default", "currency", "CNY"),
+					resource.TestCheckResourceAttr("data.alicloud_spot_price_history.default", "min_price", "0.25"),
+					resource.TestCheckResourceAttr("data.alicloud_spot_price_history.default", "avg_price", "0.5"),
+					resource.TestCheckResourceAttr("data.alicloud_spot_price_history.default", "max_price", "0.75"),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.mutex.Lock()
+					defer server.mutex.Unlock()
+					reclaimed := server.instances[instance.InstanceId]
+					reclaimed["Status"] = string(Stopped)
+					reclaimed["OperationLocks"] = fakeObject{"LockReason": []fakeObject{{"LockReason": SpotInstanceRecycling}}}
+				},
+				Config: testAccCheckInstanceConfigSpot(EcsInstanceCommonTestCase),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["alicloud_instance.foo"].Primary.ID; id == instance.InstanceId {
+							return fmt.Errorf("expected the reclaimed instance %s to be replaced", id)
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "status", string(Running)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "spot_interruption", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAlicloudInstanceImage_update(t *testing.T) {
 	var instance ecs.Instance
 
@@ -2083,3 +2514,155 @@ func testAccCheckInstanceDisableDeletionProtection(common string) string {
 	}
 	`, common)
 }
+
+func testAccCheckInstanceConfigNetworkInterfaces(common string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccCheckInstanceConfigNetworkInterfaces"
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		system_disk_category = "cloud_efficiency"
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		instance_name = "tf-testAccCheckInstanceConfigOrigin-foo"
+		host_name = "host-foo"
+		secondary_private_ip_address_count = 2
+
+		network_interfaces {
+			vswitch_id = "${alicloud_vswitch.default.id}"
+			name = "${var.name}"
+			primary_ip = "172.16.0.20"
+			secondary_private_ips = ["172.16.0.21"]
+		}
+	}
+	`, common)
+}
+
+func testAccCheckInstanceConfigIpv6(common, args string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccCheckInstanceConfigIpv6"
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		system_disk_category = "cloud_efficiency"
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		instance_name = "${var.name}"
+		%s
+	}
+	`, common, args)
+}
+
+func testAccCheckInstanceConfigNetworkInterfacesUpdate(common string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccCheckInstanceConfigNetworkInterfaces"
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		system_disk_category = "cloud_efficiency"
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		instance_name = "tf-testAccCheckInstanceConfigOrigin-foo"
+		host_name = "host-foo"
+		secondary_private_ip_address_count = 1
+
+		network_interfaces {
+			vswitch_id = "${alicloud_vswitch.default.id}"
+			name = "${var.name}-bar"
+			primary_ip = "172.16.0.20"
+			secondary_private_ips = ["172.16.0.21", "172.16.0.22"]
+		}
+
+		network_interfaces {
+			vswitch_id = "${alicloud_vswitch.default.id}"
+			secondary_private_ip_address_count = 3
+		}
+	}
+	`, common)
+}
+
+const testAccCheckInstancePrePaidArgs = `
+		instance_charge_type = "PrePaid"
+		force_delete = true
+`
+
+func testAccCheckInstanceConfigSpec(common, instanceType, imageId, args string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccCheckInstanceConfigSpec"
+	}
+
+	resource "alicloud_image" "foo" {
+		snapshot_id = "s-fake-source"
+		name = "${var.name}"
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "%s"
+		instance_type = "%s"
+		system_disk_category = "cloud_efficiency"
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		instance_name = "${var.name}"
+		%s
+	}
+	`, common, imageId, instanceType, args)
+}
+
+func testAccCheckInstanceConfigPowerState(common, status, userData, args string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccCheckInstanceConfigPowerState"
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		instance_name = "${var.name}"
+		status = "%s"
+		user_data = "%s"
+		%s
+	}
+	`, common, status, userData, args)
+}
+
+func testAccCheckInstanceConfigSpot(common string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccCheckInstanceConfigSpot"
+	}
+
+	resource "alicloud_instance" "foo" {
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		security_groups = ["${alicloud_security_group.default.id}"]
+		instance_name = "${var.name}"
+		spot_strategy = "SpotAsPriceGo"
+	}
+
+	data "alicloud_spot_price_history" "default" {
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		zone_id = "${alicloud_vswitch.default.availability_zone}"
+		start_time = "2019-01-01T00:00:00Z"
+		end_time = "2019-01-01T02:00:00Z"
+	}
+	`, common)
+}
@@ -0,0 +1,160 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunIpv6EgressRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunIpv6EgressRuleCreate,
+		Read:   resourceAliyunIpv6EgressRuleRead,
+		Delete: resourceAliyunIpv6EgressRuleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ipv6_gateway_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ipv6_address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIpAddress,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateInstanceName,
+			},
+
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+
+			"ipv6_address_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAliyunIpv6EgressRuleCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	gatewayId := d.Get("ipv6_gateway_id").(string)
+	address, err := vpcService.DescribeIpv6Address(d.Get("ipv6_address").(string), "")
+	if err != nil {
+		return WrapError(err)
+	}
+
+	request, err := vpcService.buildVpcCommonRequest("CreateIpv6EgressOnlyRule")
+	if err != nil {
+		return err
+	}
+	request.QueryParams["Ipv6GatewayId"] = gatewayId
+	request.QueryParams["InstanceType"] = Ipv6EgressRuleInstanceType
+	request.QueryParams["InstanceId"] = address.Ipv6AddressId
+	if v, ok := d.GetOk("name"); ok {
+		request.QueryParams["Name"] = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.QueryParams["Description"] = v.(string)
+	}
+	request.QueryParams["ClientToken"] = buildClientToken("TF-CreateIpv6EgressOnlyRule")
+
+	response, err := vpcService.ProcessIpv6GatewayRequest(gatewayId, request)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Ipv6EgressOnlyRuleId string
+	}
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &resp); err != nil {
+		return WrapError(err)
+	}
+	if resp.Ipv6EgressOnlyRuleId == "" {
+		return WrapError(fmt.Errorf("CreateIpv6EgressOnlyRule got an empty rule ID: %s", response.GetHttpContentString()))
+	}
+	d.SetId(gatewayId + COLON_SEPARATED + resp.Ipv6EgressOnlyRuleId)
+
+	if err := vpcService.WaitForIpv6EgressRule(gatewayId, resp.Ipv6EgressOnlyRuleId, Available, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunIpv6EgressRuleRead(d, meta)
+}
+
+func resourceAliyunIpv6EgressRuleRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	parts := strings.Split(d.Id(), COLON_SEPARATED)
+	if len(parts) != 2 {
+		return WrapError(fmt.Errorf("Invalid IPv6 egress rule ID %s, and it should be <ipv6_gateway_id>:<ipv6_egress_rule_id>.", d.Id()))
+	}
+
+	rule, err := vpcService.DescribeIpv6EgressRule(parts[0], parts[1])
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	address, err := vpcService.DescribeIpv6Address("", rule.InstanceId)
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("ipv6_gateway_id", parts[0])
+	d.Set("ipv6_address", address.Ipv6Address)
+	d.Set("ipv6_address_id", rule.InstanceId)
+	d.Set("name", rule.Name)
+	d.Set("description", rule.Description)
+
+	return nil
+}
+
+func resourceAliyunIpv6EgressRuleDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	parts := strings.Split(d.Id(), COLON_SEPARATED)
+	if len(parts) != 2 {
+		return WrapError(fmt.Errorf("Invalid IPv6 egress rule ID %s, and it should be <ipv6_gateway_id>:<ipv6_egress_rule_id>.", d.Id()))
+	}
+
+	request, err := vpcService.buildVpcCommonRequest("DeleteIpv6EgressOnlyRule")
+	if err != nil {
+		return err
+	}
+	request.QueryParams["Ipv6EgressOnlyRuleId"] = parts[1]
+	if _, err := vpcService.ProcessIpv6GatewayRequest(d.Id(), request); err != nil {
+		if IsExceptedError(err, InvalidIpv6EgressOnlyRuleIdNotFound) || IsExceptedError(err, InvalidIpv6GatewayIdNotFound) {
+			return nil
+		}
+		return err
+	}
+	return WrapError(vpcService.WaitForIpv6EgressRule(parts[0], parts[1], Deleted, DefaultTimeout))
+}
@@ -0,0 +1,151 @@
+package alicloud
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudIpv6EgressRule_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_ipv6_egress_rule.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIpv6EgressRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6EgressRuleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIpv6EgressRuleExists("alicloud_ipv6_egress_rule.foo"),
+					resource.TestCheckResourceAttrPair("alicloud_ipv6_egress_rule.foo", "ipv6_gateway_id", "alicloud_ipv6_gateway.foo", "id"),
+					resource.TestCheckResourceAttrSet("alicloud_ipv6_egress_rule.foo", "ipv6_address_id"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudIpv6EgressRule_basic runs the egress-only rule of the IPv6 address assigned to a network interface
+// against the fake API server, which reports the rule Pending until it is described.
+func TestUnitAlicloudIpv6EgressRule_basic(t *testing.T) {
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_ipv6_egress_rule.foo",
+		Providers:     testAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := testAccCheckIpv6EgressRuleDestroy(s); err != nil {
+				return err
+			}
+			server.mutex.Lock()
+			defer server.mutex.Unlock()
+			if len(server.ipv6Gateways) > 0 {
+				return fmt.Errorf("expected the IPv6 gateways to be deleted, got %d", len(server.ipv6Gateways))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6EgressRuleConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIpv6EgressRuleExists("alicloud_ipv6_egress_rule.foo"),
+					resource.TestCheckResourceAttrPair("alicloud_ipv6_egress_rule.foo", "ipv6_gateway_id", "alicloud_ipv6_gateway.foo", "id"),
+					resource.TestCheckResourceAttrSet("alicloud_ipv6_egress_rule.foo", "ipv6_address_id"),
+					resource.TestMatchResourceAttr("alicloud_ipv6_egress_rule.foo", "ipv6_address", regexp.MustCompile(`^2408:4000:[0-9a-f]+:1::[0-9a-f]+$`)),
+					resource.TestCheckResourceAttr("alicloud_ipv6_egress_rule.foo", "name", "tf-testAccIpv6EgressRuleConfig"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_egress_rule.foo", "description", "Deny the inbound traffic"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIpv6EgressRuleExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Ipv6 Egress Rule ID is set")
+		}
+
+		parts := strings.Split(rs.Primary.ID, COLON_SEPARATED)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid resource id")
+		}
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		vpcService := VpcService{client}
+		rule, err := vpcService.DescribeIpv6EgressRule(parts[0], parts[1])
+		if err != nil {
+			return WrapError(err)
+		}
+		if rule.Status != string(Available) {
+			return WrapError(fmt.Errorf("Ipv6 Egress Rule %s is %s", rs.Primary.ID, rule.Status))
+		}
+		return nil
+	}
+}
+
+func testAccCheckIpv6EgressRuleDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_ipv6_egress_rule" {
+			continue
+		}
+
+		parts := strings.Split(rs.Primary.ID, COLON_SEPARATED)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid resource id")
+		}
+		if _, err := vpcService.DescribeIpv6EgressRule(parts[0], parts[1]); err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("Ipv6 Egress Rule %s still exist", rs.Primary.ID))
+	}
+
+	return testAccCheckIpv6GatewayDestroy(s)
+}
+
+var testAccIpv6EgressRuleConfig = fmt.Sprintf(`
+%s
+variable "name" {
+	default = "tf-testAccIpv6EgressRuleConfig"
+}
+
+resource "alicloud_network_interface" "foo" {
+	name = "${var.name}"
+	vswitch_id = "${alicloud_vswitch.default.id}"
+	security_groups = ["${alicloud_security_group.default.id}"]
+	ipv6_address_count = 1
+}
+
+resource "alicloud_ipv6_gateway" "foo" {
+	vpc_id = "${alicloud_vpc.default.id}"
+	name = "${var.name}"
+}
+
+resource "alicloud_ipv6_egress_rule" "foo" {
+	ipv6_gateway_id = "${alicloud_ipv6_gateway.foo.id}"
+	ipv6_address = "${element(alicloud_network_interface.foo.ipv6_addresses, 0)}"
+	name = "${var.name}"
+	description = "Deny the inbound traffic"
+}
+`, EcsInstanceIpv6CommonTestCase)
@@ -0,0 +1,182 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunIpv6Gateway() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunIpv6GatewayCreate,
+		Read:   resourceAliyunIpv6GatewayRead,
+		Update: resourceAliyunIpv6GatewayUpdate,
+		Delete: resourceAliyunIpv6GatewayDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"spec": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      Ipv6GatewaySmallSpec,
+				ValidateFunc: validateAllowedStringValue([]string{string(Ipv6GatewaySmallSpec), string(Ipv6GatewayMediumSpec), string(Ipv6GatewayLargeSpec)}),
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceName,
+			},
+
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+		},
+	}
+}
+
+func resourceAliyunIpv6GatewayCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request, err := vpcService.buildVpcCommonRequest("CreateIpv6Gateway")
+	if err != nil {
+		return err
+	}
+	request.QueryParams["VpcId"] = d.Get("vpc_id").(string)
+	request.QueryParams["Spec"] = d.Get("spec").(string)
+	if v, ok := d.GetOk("name"); ok {
+		request.QueryParams["Name"] = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.QueryParams["Description"] = v.(string)
+	}
+	request.QueryParams["ClientToken"] = buildClientToken("TF-CreateIpv6Gateway")
+
+	response, err := vpcService.ProcessIpv6GatewayRequest("ipv6_gateway", request)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		Ipv6GatewayId string
+	}
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &resp); err != nil {
+		return WrapError(err)
+	}
+	if resp.Ipv6GatewayId == "" {
+		return WrapError(fmt.Errorf("CreateIpv6Gateway got an empty IPv6 gateway ID: %s", response.GetHttpContentString()))
+	}
+	d.SetId(resp.Ipv6GatewayId)
+
+	if err := vpcService.WaitForIpv6Gateway(d.Id(), Available, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunIpv6GatewayRead(d, meta)
+}
+
+func resourceAliyunIpv6GatewayRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	gateway, err := vpcService.DescribeIpv6Gateway(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("vpc_id", gateway.VpcId)
+	d.Set("spec", gateway.Spec)
+	d.Set("name", gateway.Name)
+	d.Set("description", gateway.Description)
+
+	return nil
+}
+
+func resourceAliyunIpv6GatewayUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	if d.HasChange("name") || d.HasChange("description") {
+		request, err := vpcService.buildVpcCommonRequest("ModifyIpv6GatewayAttribute")
+		if err != nil {
+			return err
+		}
+		request.QueryParams["Ipv6GatewayId"] = d.Id()
+		request.QueryParams["Name"] = d.Get("name").(string)
+		request.QueryParams["Description"] = d.Get("description").(string)
+		if _, err := vpcService.ProcessIpv6GatewayRequest(d.Id(), request); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("spec") {
+		request, err := vpcService.buildVpcCommonRequest("ModifyIpv6GatewaySpec")
+		if err != nil {
+			return err
+		}
+		request.QueryParams["Ipv6GatewayId"] = d.Id()
+		request.QueryParams["Spec"] = d.Get("spec").(string)
+		if _, err := vpcService.ProcessIpv6GatewayRequest(d.Id(), request); err != nil {
+			return err
+		}
+		if err := vpcService.WaitForIpv6Gateway(d.Id(), Available, DefaultTimeout); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	return resourceAliyunIpv6GatewayRead(d, meta)
+}
+
+func resourceAliyunIpv6GatewayDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request, err := vpcService.buildVpcCommonRequest("DeleteIpv6Gateway")
+	if err != nil {
+		return err
+	}
+	request.QueryParams["Ipv6GatewayId"] = d.Id()
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ProcessCommonRequest(request)
+		})
+		if err != nil {
+			if IsExceptedError(err, InvalidIpv6GatewayIdNotFound) {
+				return nil
+			}
+			// The IPv6 gateway can not be deleted until all of its egress-only rules have been deleted.
+			if IsExceptedErrors(err, append(Ipv6GatewayInvalidOperations, DependencyViolation)) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := vpcService.DescribeIpv6Gateway(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("Ipv6 Gateway", "Deleted")), DeleteTimeoutMsg, d.Id(), request.GetActionName(), ProviderERROR))
+	})
+}
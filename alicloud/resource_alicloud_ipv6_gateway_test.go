@@ -0,0 +1,144 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudIpv6Gateway_basic(t *testing.T) {
+	var gateway ipv6Gateway
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_ipv6_gateway.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIpv6GatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6GatewayConfig("tf-testAccIpv6GatewayConfig", "Small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIpv6GatewayExists("alicloud_ipv6_gateway.foo", &gateway),
+					resource.TestCheckResourceAttrPair("alicloud_ipv6_gateway.foo", "vpc_id", "alicloud_vpc.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "name", "tf-testAccIpv6GatewayConfig"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "spec", "Small"),
+				),
+			},
+			{
+				Config: testAccIpv6GatewayConfig("tf-testAccIpv6GatewayConfig-update", "Medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIpv6GatewayExists("alicloud_ipv6_gateway.foo", &gateway),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "name", "tf-testAccIpv6GatewayConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "spec", "Medium"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudIpv6Gateway_update runs the CRUD of the IPv6 gateway against the fake API server, which reports the
+// gateway Creating after it is created or its spec is changed so that the waiters are run.
+func TestUnitAlicloudIpv6Gateway_update(t *testing.T) {
+	var gateway ipv6Gateway
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_ipv6_gateway.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckIpv6GatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIpv6GatewayConfig("tf-testAccIpv6GatewayConfig", "Small"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIpv6GatewayExists("alicloud_ipv6_gateway.foo", &gateway),
+					resource.TestCheckResourceAttrPair("alicloud_ipv6_gateway.foo", "vpc_id", "alicloud_vpc.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "name", "tf-testAccIpv6GatewayConfig"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "description", "The IPv6 gateway"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "spec", "Small"),
+				),
+			},
+			{
+				Config: testAccIpv6GatewayConfig("tf-testAccIpv6GatewayConfig-update", "Large"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIpv6GatewayExists("alicloud_ipv6_gateway.foo", &gateway),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "name", "tf-testAccIpv6GatewayConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_ipv6_gateway.foo", "spec", "Large"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIpv6GatewayExists(n string, gateway *ipv6Gateway) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Ipv6 Gateway ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		vpcService := VpcService{client}
+		v, err := vpcService.DescribeIpv6Gateway(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+		if v.Status != string(Available) {
+			return WrapError(fmt.Errorf("Ipv6 Gateway %s is %s", v.Ipv6GatewayId, v.Status))
+		}
+
+		*gateway = v
+		return nil
+	}
+}
+
+func testAccCheckIpv6GatewayDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_ipv6_gateway" {
+			continue
+		}
+
+		gateway, err := vpcService.DescribeIpv6Gateway(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("Ipv6 Gateway %s still exist", gateway.Ipv6GatewayId))
+	}
+
+	return nil
+}
+
+func testAccIpv6GatewayConfig(name, spec string) string {
+	return fmt.Sprintf(`
+	resource "alicloud_vpc" "foo" {
+		name = "tf-testAccIpv6GatewayConfig"
+		cidr_block = "172.16.0.0/12"
+		enable_ipv6 = true
+	}
+
+	resource "alicloud_ipv6_gateway" "foo" {
+		vpc_id = "${alicloud_vpc.foo.id}"
+		name = "%s"
+		description = "The IPv6 gateway"
+		spec = "%s"
+	}
+	`, name, spec)
+}
@@ -27,6 +27,12 @@ func resourceAlicloudKVStoreInstance() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(8 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"instance_name": {
 				Type:         schema.TypeString,
@@ -133,6 +139,7 @@ func resourceAlicloudKVStoreInstance() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -157,7 +164,7 @@ func resourceAlicloudKVStoreInstanceCreate(d *schema.ResourceData, meta interfac
 	d.SetId(resp.InstanceId)
 
 	// wait instance status change from Creating to Normal
-	if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+	if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
 		return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 	}
 
@@ -189,7 +196,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 
 	if d.HasChange("security_ips") {
 		// wait instance status is Normal before modifying
-		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 		}
 		request := r_kvstore.CreateModifySecurityIpsRequest()
@@ -208,7 +215,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 		}
 		d.SetPartial("security_ips")
 		// wait instance status is Normal after modifying
-		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 		}
 	}
@@ -219,7 +226,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 			instanceType := d.Get("instance_type").(string)
 			if string(KVStoreRedis) == instanceType {
 				// wait instance status is Normal before modifying
-				if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+				if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 					return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 				}
 
@@ -237,13 +244,18 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 				d.SetPartial("vpc_auth_mode")
 
 				// The auth mode take some time to be effective, so wait to ensure the state !
-				if err := kvstoreService.WaitForRKVInstanceVpcAuthMode(d.Id(), d.Get("vpc_auth_mode").(string), DefaultLongTimeout); err != nil {
+				if err := kvstoreService.WaitForRKVInstanceVpcAuthMode(d.Id(), d.Get("vpc_auth_mode").(string), int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 					return fmt.Errorf("ModifyInstanceVpcAuthMode %s got error: %#v", Normal, err)
 				}
 			}
 		}
 	}
 
+	tagService := TagService{client}
+	if err := tagService.SetResourceTags(d, connectivity.KVSTORECode, TagResourceKVStoreInstance); err != nil {
+		return WrapError(err)
+	}
+
 	if d.IsNewResource() {
 		d.Partial(false)
 		return resourceAlicloudKVStoreInstanceRead(d, meta)
@@ -251,7 +263,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 
 	if d.HasChange("instance_class") {
 		// wait instance status is Normal before modifying
-		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 		}
 		request := r_kvstore.CreateModifyInstanceSpecRequest()
@@ -265,7 +277,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 			return err
 		}
 		// wait instance status is Normal after modifying
-		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 		}
 		// There needs more time to sync instance class update
@@ -301,7 +313,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 
 	if update {
 		// wait instance status is Normal before modifying
-		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 		}
 		_, err := client.WithRkvClient(func(rkvClient *r_kvstore.Client) (interface{}, error) {
@@ -312,7 +324,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 		}
 
 		// wait instance status is Normal after modifying
-		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+		if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 			return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 		}
 		d.SetPartial("instance_name")
@@ -334,7 +346,7 @@ func resourceAlicloudKVStoreInstanceUpdate(d *schema.ResourceData, meta interfac
 				return fmt.Errorf("TransformToPrePaid got an error: %#v", err)
 			}
 			// wait instance status is Normal after modifying
-			if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, DefaultLongTimeout); err != nil {
+			if err := kvstoreService.WaitForRKVInstance(d.Id(), Normal, int(d.Timeout(schema.TimeoutUpdate).Seconds())); err != nil {
 				return fmt.Errorf("WaitForInstance %s got error: %#v", Normal, err)
 			}
 			d.SetPartial("instance_charge_type")
@@ -369,6 +381,13 @@ func resourceAlicloudKVStoreInstanceRead(d *schema.ResourceData, meta interface{
 	d.Set("security_ips", strings.Split(instance.SecurityIPList, COMMA_SEPARATED))
 	d.Set("vpc_auth_mode", instance.VpcAuthMode)
 
+	tagService := TagService{client}
+	tags, err := tagService.DescribeTags(connectivity.KVSTORECode, TagResourceKVStoreInstance, d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tags))
+
 	//refresh parameters
 	if err = refreshParameters(d, meta); err != nil {
 		return err
@@ -394,7 +413,7 @@ func resourceAlicloudKVStoreInstanceDelete(d *schema.ResourceData, meta interfac
 	request := r_kvstore.CreateDeleteInstanceRequest()
 	request.InstanceId = d.Id()
 
-	return resource.Retry(8*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := client.WithRkvClient(func(rkvClient *r_kvstore.Client) (interface{}, error) {
 			return rkvClient.DeleteInstance(request)
 		})
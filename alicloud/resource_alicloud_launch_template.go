@@ -0,0 +1,610 @@
+package alicloud
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// The arguments which are saved in the versions of the launch template. Changing any of them creates a new version.
+var launchTemplateDataKeys = []string{
+	"image_id", "instance_type", "instance_name", "description", "host_name", "availability_zone",
+	"security_group_id", "vswitch_id", "internet_charge_type", "internet_max_bandwidth_in", "internet_max_bandwidth_out",
+	"instance_charge_type", "system_disk_category", "system_disk_size", "system_disk_name", "system_disk_description",
+	"data_disks", "network_interfaces", "user_data", "role_name", "key_name", "spot_strategy", "spot_price_limit",
+	"security_enhancement_strategy", "tags", "version_description",
+}
+
+func resourceAliyunLaunchTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunLaunchTemplateCreate,
+		Read:   resourceAliyunLaunchTemplateRead,
+		Update: resourceAliyunLaunchTemplateUpdate,
+		Delete: resourceAliyunLaunchTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"version_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"image_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"instance_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceType,
+			},
+
+			"instance_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceName,
+			},
+
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+
+			"host_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"availability_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"security_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vswitch_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"internet_charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInternetChargeType,
+			},
+
+			"internet_max_bandwidth_in": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntegerInRange(1, 200),
+			},
+
+			"internet_max_bandwidth_out": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntegerInRange(0, 100),
+			},
+
+			"instance_charge_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceChargeType,
+			},
+
+			"system_disk_category": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDiskCategory,
+			},
+
+			"system_disk_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validateIntegerInRange(20, 500),
+			},
+
+			"system_disk_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDiskName,
+			},
+
+			"system_disk_description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDiskDescription,
+			},
+
+			"data_disks": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 16,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDiskName,
+						},
+						"size": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"category": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDiskCategory,
+							Default:      DiskCloudEfficiency,
+						},
+						"encrypted": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"snapshot_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"delete_with_instance": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"description": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDiskDescription,
+						},
+					},
+				},
+			},
+
+			"network_interfaces": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"vswitch_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"security_group_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"primary_ip": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
+			"user_data": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"role_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"key_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"spot_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceSpotStrategy,
+			},
+
+			"spot_price_limit": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+
+			"security_enhancement_strategy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validateAllowedStringValue([]string{
+					string(ActiveSecurityEnhancementStrategy),
+					string(DeactiveSecurityEnhancementStrategy),
+				}),
+			},
+
+			// The tags of the instances launched from the template, rather than the template itself.
+			"tags": tagsSchema(),
+
+			"default_version_number": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateIntegerInRange(1, 30),
+				ConflictsWith: []string{"update_default_version"},
+			},
+
+			"update_default_version": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"latest_version_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAliyunLaunchTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := buildLaunchTemplateArgs(d)
+	args.LaunchTemplateName = d.Get("name").(string)
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateLaunchTemplate(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "launch_template", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.CreateLaunchTemplateResponse)
+	if resp == nil {
+		return WrapError(fmt.Errorf("CreateLaunchTemplate got a nil response: %#v", resp))
+	}
+
+	d.SetId(resp.LaunchTemplateId)
+
+	return resourceAliyunLaunchTemplateUpdate(d, meta)
+}
+
+func resourceAliyunLaunchTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	template, err := ecsService.DescribeLaunchTemplateById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	// The arguments reflect the latest version, which is the one created from the configuration.
+	version, err := ecsService.DescribeLaunchTemplateVersion(d.Id(), template.LatestVersionNumber)
+	if err != nil {
+		return WrapError(err)
+	}
+	data := version.LaunchTemplateData
+
+	d.Set("name", template.LaunchTemplateName)
+	d.Set("default_version_number", template.DefaultVersionNumber)
+	d.Set("latest_version_number", template.LatestVersionNumber)
+	d.Set("version_description", version.VersionDescription)
+
+	d.Set("image_id", data.ImageId)
+	d.Set("instance_type", data.InstanceType)
+	d.Set("instance_name", data.InstanceName)
+	d.Set("description", data.Description)
+	d.Set("host_name", data.HostName)
+	d.Set("availability_zone", data.ZoneId)
+	d.Set("security_group_id", data.SecurityGroupId)
+	d.Set("vswitch_id", data.VSwitchId)
+	d.Set("internet_charge_type", data.InternetChargeType)
+	d.Set("internet_max_bandwidth_in", data.InternetMaxBandwidthIn)
+	d.Set("internet_max_bandwidth_out", data.InternetMaxBandwidthOut)
+	d.Set("instance_charge_type", data.InstanceChargeType)
+	d.Set("system_disk_category", data.SystemDiskCategory)
+	d.Set("system_disk_size", data.SystemDiskSize)
+	d.Set("system_disk_name", data.SystemDiskDiskName)
+	d.Set("system_disk_description", data.SystemDiskDescription)
+	d.Set("user_data", userDataHashSum(data.UserData))
+	d.Set("role_name", data.RamRoleName)
+	d.Set("key_name", data.KeyPairName)
+	d.Set("spot_strategy", data.SpotStrategy)
+	d.Set("spot_price_limit", data.SpotPriceLimit)
+	d.Set("security_enhancement_strategy", data.SecurityEnhancementStrategy)
+
+	var disks []map[string]interface{}
+	for _, disk := range data.DataDisks.DataDisk {
+		encrypted, _ := strconv.ParseBool(disk.Encrypted)
+		disks = append(disks, map[string]interface{}{
+			"name":                 disk.DiskName,
+			"size":                 disk.Size,
+			"category":             disk.Category,
+			"encrypted":            encrypted,
+			"snapshot_id":          disk.SnapshotId,
+			"delete_with_instance": disk.DeleteWithInstance,
+			"description":          disk.Description,
+		})
+	}
+	if err := d.Set("data_disks", disks); err != nil {
+		return WrapError(err)
+	}
+
+	var interfaces []map[string]interface{}
+	for _, eni := range data.NetworkInterfaces.NetworkInterface {
+		interfaces = append(interfaces, map[string]interface{}{
+			"name":              eni.NetworkInterfaceName,
+			"vswitch_id":        eni.VSwitchId,
+			"security_group_id": eni.SecurityGroupId,
+			"primary_ip":        eni.PrimaryIpAddress,
+			"description":       eni.Description,
+		})
+	}
+	if err := d.Set("network_interfaces", interfaces); err != nil {
+		return WrapError(err)
+	}
+
+	tags := make(map[string]string)
+	for _, tag := range data.Tags.InstanceTag {
+		tags[tag.Key] = tag.Value
+	}
+	d.Set("tags", tags)
+
+	return nil
+}
+
+func resourceAliyunLaunchTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	d.Partial(true)
+
+	// The first version has been created along with the template.
+	if !d.IsNewResource() {
+		changed := false
+		for _, key := range launchTemplateDataKeys {
+			if d.HasChange(key) {
+				changed = true
+				break
+			}
+		}
+		if changed {
+			args := launchTemplateVersionArgs(buildLaunchTemplateArgs(d))
+			args.LaunchTemplateId = d.Id()
+			raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+				return ecsClient.CreateLaunchTemplateVersion(args)
+			})
+			if err != nil {
+				return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+			}
+			resp, _ := raw.(*ecs.CreateLaunchTemplateVersionResponse)
+			if resp == nil {
+				return WrapError(fmt.Errorf("CreateLaunchTemplateVersion got a nil response: %#v", resp))
+			}
+			for _, key := range launchTemplateDataKeys {
+				d.SetPartial(key)
+			}
+
+			if d.Get("update_default_version").(bool) {
+				if err := modifyLaunchTemplateDefaultVersion(client, d.Id(), resp.LaunchTemplateVersionNumber); err != nil {
+					return WrapError(err)
+				}
+			}
+		}
+	}
+
+	if d.HasChange("default_version_number") {
+		if v, ok := d.GetOk("default_version_number"); ok {
+			if err := modifyLaunchTemplateDefaultVersion(client, d.Id(), v.(int)); err != nil {
+				return WrapError(err)
+			}
+		}
+		d.SetPartial("default_version_number")
+	}
+
+	d.Partial(false)
+
+	return resourceAliyunLaunchTemplateRead(d, meta)
+}
+
+func resourceAliyunLaunchTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateDeleteLaunchTemplateRequest()
+	args.LaunchTemplateId = d.Id()
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteLaunchTemplate(args)
+		})
+		if err != nil {
+			if NotFoundError(err) || IsExceptedError(err, InvalidLaunchTemplateNotFound) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := ecsService.DescribeLaunchTemplateById(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("ECS Launch Template", "Deleted")), DeleteTimeoutMsg, d.Id(), args.GetActionName(), ProviderERROR))
+	})
+}
+
+func modifyLaunchTemplateDefaultVersion(client *connectivity.AliyunClient, id string, version int) error {
+	args := ecs.CreateModifyLaunchTemplateDefaultVersionRequest()
+	args.LaunchTemplateId = id
+	args.DefaultVersionNumber = requests.NewInteger(version)
+	_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ModifyLaunchTemplateDefaultVersion(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, id, args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	return nil
+}
+
+// buildLaunchTemplateArgs builds the launch data of the template from the arguments, and it leaves the unset ones
+// empty so that they can be specified when launching instances.
+func buildLaunchTemplateArgs(d *schema.ResourceData) *ecs.CreateLaunchTemplateRequest {
+	args := ecs.CreateCreateLaunchTemplateRequest()
+	args.VersionDescription = d.Get("version_description").(string)
+	args.ImageId = d.Get("image_id").(string)
+	args.InstanceType = d.Get("instance_type").(string)
+	args.InstanceName = d.Get("instance_name").(string)
+	args.Description = d.Get("description").(string)
+	args.HostName = d.Get("host_name").(string)
+	args.ZoneId = d.Get("availability_zone").(string)
+	args.SecurityGroupId = d.Get("security_group_id").(string)
+	args.VSwitchId = d.Get("vswitch_id").(string)
+	args.InternetChargeType = d.Get("internet_charge_type").(string)
+	args.InstanceChargeType = d.Get("instance_charge_type").(string)
+	args.SystemDiskCategory = d.Get("system_disk_category").(string)
+	args.SystemDiskDiskName = d.Get("system_disk_name").(string)
+	args.SystemDiskDescription = d.Get("system_disk_description").(string)
+	args.RamRoleName = d.Get("role_name").(string)
+	args.KeyPairName = d.Get("key_name").(string)
+	args.SpotStrategy = d.Get("spot_strategy").(string)
+	args.SecurityEnhancementStrategy = d.Get("security_enhancement_strategy").(string)
+
+	if v, ok := d.GetOk("internet_max_bandwidth_in"); ok {
+		args.InternetMaxBandwidthIn = requests.NewInteger(v.(int))
+	}
+	if v, ok := d.GetOk("internet_max_bandwidth_out"); ok {
+		args.InternetMaxBandwidthOut = requests.NewInteger(v.(int))
+	}
+	if v, ok := d.GetOk("system_disk_size"); ok {
+		args.SystemDiskSize = requests.NewInteger(v.(int))
+	}
+	if v, ok := d.GetOk("spot_price_limit"); ok {
+		args.SpotPriceLimit = requests.NewFloat(v.(float64))
+	}
+	if v := d.Get("user_data").(string); v != "" {
+		args.UserData = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+
+	if v, ok := d.GetOk("data_disks"); ok {
+		var disks []ecs.CreateLaunchTemplateDataDisk
+		for _, raw := range v.([]interface{}) {
+			disk := raw.(map[string]interface{})
+			req := ecs.CreateLaunchTemplateDataDisk{
+				DiskName:           disk["name"].(string),
+				Category:           disk["category"].(string),
+				Encrypted:          strconv.FormatBool(disk["encrypted"].(bool)),
+				SnapshotId:         disk["snapshot_id"].(string),
+				DeleteWithInstance: strconv.FormatBool(disk["delete_with_instance"].(bool)),
+				Description:        disk["description"].(string),
+			}
+			if size := disk["size"].(int); size > 0 {
+				req.Size = strconv.Itoa(size)
+			}
+			disks = append(disks, req)
+		}
+		args.DataDisk = &disks
+	}
+
+	if v, ok := d.GetOk("network_interfaces"); ok {
+		var interfaces []ecs.CreateLaunchTemplateNetworkInterface
+		for _, raw := range v.([]interface{}) {
+			eni := raw.(map[string]interface{})
+			interfaces = append(interfaces, ecs.CreateLaunchTemplateNetworkInterface{
+				NetworkInterfaceName: eni["name"].(string),
+				VSwitchId:            eni["vswitch_id"].(string),
+				SecurityGroupId:      eni["security_group_id"].(string),
+				PrimaryIpAddress:     eni["primary_ip"].(string),
+				Description:          eni["description"].(string),
+			})
+		}
+		args.NetworkInterface = &interfaces
+	}
+
+	if v, ok := d.GetOk("tags"); ok {
+		var tags []ecs.CreateLaunchTemplateTag
+		for key, value := range v.(map[string]interface{}) {
+			tags = append(tags, ecs.CreateLaunchTemplateTag{Key: key, Value: value.(string)})
+		}
+		args.Tag = &tags
+	}
+
+	return args
+}
+
+// launchTemplateVersionArgs converts the launch data of the template to the request of a new version.
+func launchTemplateVersionArgs(template *ecs.CreateLaunchTemplateRequest) *ecs.CreateLaunchTemplateVersionRequest {
+	args := ecs.CreateCreateLaunchTemplateVersionRequest()
+	args.VersionDescription = template.VersionDescription
+	args.ImageId = template.ImageId
+	args.InstanceType = template.InstanceType
+	args.InstanceName = template.InstanceName
+	args.Description = template.Description
+	args.HostName = template.HostName
+	args.ZoneId = template.ZoneId
+	args.SecurityGroupId = template.SecurityGroupId
+	args.VSwitchId = template.VSwitchId
+	args.InternetChargeType = template.InternetChargeType
+	args.InternetMaxBandwidthIn = template.InternetMaxBandwidthIn
+	args.InternetMaxBandwidthOut = template.InternetMaxBandwidthOut
+	args.InstanceChargeType = template.InstanceChargeType
+	args.SystemDiskCategory = template.SystemDiskCategory
+	args.SystemDiskSize = template.SystemDiskSize
+	args.SystemDiskDiskName = template.SystemDiskDiskName
+	args.SystemDiskDescription = template.SystemDiskDescription
+	args.UserData = template.UserData
+	args.RamRoleName = template.RamRoleName
+	args.KeyPairName = template.KeyPairName
+	args.SpotStrategy = template.SpotStrategy
+	args.SpotPriceLimit = template.SpotPriceLimit
+	args.SecurityEnhancementStrategy = template.SecurityEnhancementStrategy
+
+	if template.DataDisk != nil {
+		var disks []ecs.CreateLaunchTemplateVersionDataDisk
+		for _, disk := range *template.DataDisk {
+			disks = append(disks, ecs.CreateLaunchTemplateVersionDataDisk(disk))
+		}
+		args.DataDisk = &disks
+	}
+	if template.NetworkInterface != nil {
+		var interfaces []ecs.CreateLaunchTemplateVersionNetworkInterface
+		for _, eni := range *template.NetworkInterface {
+			interfaces = append(interfaces, ecs.CreateLaunchTemplateVersionNetworkInterface(eni))
+		}
+		args.NetworkInterface = &interfaces
+	}
+	if template.Tag != nil {
+		var tags []ecs.CreateLaunchTemplateVersionTag
+		for _, tag := range *template.Tag {
+			tags = append(tags, ecs.CreateLaunchTemplateVersionTag(tag))
+		}
+		args.Tag = &tags
+	}
+	return args
+}
@@ -0,0 +1,191 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudLaunchTemplate_basic(t *testing.T) {
+	var template ecs.LaunchTemplateSet
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_launch_template.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckLaunchTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLaunchTemplateConfig(EcsInstanceCommonTestCase, "tf-testAccLaunchTemplateConfig-v1", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLaunchTemplateExists("alicloud_launch_template.foo", &template),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "name", "tf-testAccLaunchTemplateConfig"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v1"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "system_disk_size", "60"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "data_disks.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "latest_version_number", "1"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "default_version_number", "1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v1"),
+				),
+			},
+			{
+				Config: testAccLaunchTemplateConfig(EcsInstanceCommonTestCase, "tf-testAccLaunchTemplateConfig-v2", "update_default_version = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLaunchTemplateExists("alicloud_launch_template.foo", &template),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v2"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "latest_version_number", "2"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "default_version_number", "2"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudLaunchTemplate_update runs the CRUD of the launch template against the fake API server, including
+// creating new versions, moving the default version and launching an instance from the template.
+func TestUnitAlicloudLaunchTemplate_update(t *testing.T) {
+	var template ecs.LaunchTemplateSet
+	var instance ecs.Instance
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_launch_template.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckLaunchTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLaunchTemplateConfig(EcsInstanceCommonTestCase, "tf-testAccLaunchTemplateConfig-v1", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLaunchTemplateExists("alicloud_launch_template.foo", &template),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "image_id", fakeApiImage),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "instance_type", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v1"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "system_disk_category", string(DiskCloudSSD)),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "system_disk_size", "60"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "data_disks.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "data_disks.0.size", "20"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "tags.foo", "foo"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "latest_version_number", "1"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "default_version_number", "1"),
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "image_id", fakeApiImage),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_type", fakeApiInstanceTypes[0]),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v1"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_category", string(DiskCloudSSD)),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "system_disk_size", "60"),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "security_groups.#", "1"),
+					resource.TestCheckResourceAttrSet("alicloud_instance.foo", "vswitch_id"),
+				),
+			},
+			{
+				Config: testAccLaunchTemplateConfig(EcsInstanceCommonTestCase, "tf-testAccLaunchTemplateConfig-v2", "update_default_version = true"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLaunchTemplateExists("alicloud_launch_template.foo", &template),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v2"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "latest_version_number", "2"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "default_version_number", "2"),
+					// The instance launched from the previous default version is left as it is.
+					testAccCheckInstanceExists("alicloud_instance.foo", &instance),
+					resource.TestCheckResourceAttr("alicloud_instance.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v1"),
+				),
+			},
+			{
+				Config: testAccLaunchTemplateConfig(EcsInstanceCommonTestCase, "tf-testAccLaunchTemplateConfig-v2", "default_version_number = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckLaunchTemplateExists("alicloud_launch_template.foo", &template),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "instance_name", "tf-testAccLaunchTemplateConfig-v2"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "latest_version_number", "2"),
+					resource.TestCheckResourceAttr("alicloud_launch_template.foo", "default_version_number", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckLaunchTemplateExists(n string, template *ecs.LaunchTemplateSet) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Launch Template ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		ecsService := EcsService{client}
+		v, err := ecsService.DescribeLaunchTemplateById(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+
+		*template = v
+		return nil
+	}
+}
+
+func testAccCheckLaunchTemplateDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_launch_template" {
+			continue
+		}
+
+		template, err := ecsService.DescribeLaunchTemplateById(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("Launch template %s still exist", template.LaunchTemplateId))
+	}
+
+	return testAccCheckInstanceDestroy(s)
+}
+
+func testAccLaunchTemplateConfig(common, instanceName, versionArgs string) string {
+	return fmt.Sprintf(`
+	%s
+	variable "name" {
+		default = "tf-testAccLaunchTemplateConfig"
+	}
+
+	resource "alicloud_launch_template" "foo" {
+		name = "${var.name}"
+		image_id = "${data.alicloud_images.default.images.0.id}"
+		instance_type = "${data.alicloud_instance_types.default.instance_types.0.id}"
+		instance_name = "%s"
+		security_group_id = "${alicloud_security_group.default.id}"
+		vswitch_id = "${alicloud_vswitch.default.id}"
+		system_disk_category = "cloud_ssd"
+		system_disk_size = 60
+		data_disks {
+			name = "${var.name}"
+			size = 20
+		}
+		tags {
+			foo = "foo"
+		}
+		%s
+	}
+
+	resource "alicloud_instance" "foo" {
+		launch_template_id = "${alicloud_launch_template.foo.id}"
+	}
+	`, common, instanceName, versionArgs)
+}
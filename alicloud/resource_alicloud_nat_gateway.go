@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -92,6 +93,75 @@ func resourceAliyunNatGateway() *schema.Resource {
 				MaxItems: 4,
 				Optional: true,
 			},
+			"tags": tagsSchema(),
+
+			// The DNAT and SNAT entries are authoritative when they are set, and the entries of the tables which are not
+			// declared are deleted. The entries are changed concurrently in batches, so a table of hundreds of entries
+			// is applied by several waits instead of one for every entry.
+			"forward_entries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"external_ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"external_port": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateForwardPort,
+						},
+						"ip_protocol": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateAllowedStringValue([]string{"tcp", "udp", "any"}),
+						},
+						"internal_ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"internal_port": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateForwardPort,
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateInstanceName,
+						},
+					},
+				},
+			},
+			"snat_entries": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_vswitch_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"source_cidr": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCIDRNetworkAddress,
+						},
+						"snat_ip": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateInstanceName,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -145,6 +215,15 @@ func resourceAliyunNatGatewayCreate(d *schema.ResourceData, meta interface{}) er
 		return WrapErrorf(err, DefaultErrorMsg, "nat_gateway", args.GetActionName(), AlibabaCloudSdkGoERROR)
 	}
 
+	tagService := TagService{client}
+	if err := tagService.SetResourceTags(d, connectivity.VPCCode, TagResourceNatGateway); err != nil {
+		return WrapError(err)
+	}
+
+	if err := modifyNatGatewayEntries(d, meta); err != nil {
+		return err
+	}
+
 	return resourceAliyunNatGatewayRead(d, meta)
 }
 
@@ -170,6 +249,13 @@ func resourceAliyunNatGatewayRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("description", natGateway.Description)
 	d.Set("vpc_id", natGateway.VpcId)
 
+	tagService := TagService{client}
+	tags, err := tagService.DescribeTags(connectivity.VPCCode, TagResourceNatGateway, d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tags))
+
 	bindWidthPackages, err := flattenBandWidthPackages(natGateway.BandwidthPackageIds.BandwidthPackageId, meta, d)
 	if err != nil {
 		log.Printf("[ERROR] bindWidthPackages flattenBandWidthPackages failed. natgateway id is %#v", d.Id())
@@ -177,6 +263,29 @@ func resourceAliyunNatGatewayRead(d *schema.ResourceData, meta interface{}) erro
 		d.Set("bandwidth_packages", bindWidthPackages)
 	}
 
+	// Only the first forward and SNAT tables are read, which are the ones the entries are reconciled with.
+	var forwardEntries []forwardEntry
+	if tableIds := natGateway.ForwardTableIds.ForwardTableId; len(tableIds) > 0 {
+		forwardEntries, err = vpcService.DescribeForwardTableEntries(tableIds[0])
+		if err != nil {
+			return WrapError(err)
+		}
+	}
+	if err := d.Set("forward_entries", flattenNatGatewayForwardEntries(forwardEntries)); err != nil {
+		return WrapError(err)
+	}
+
+	var snatEntries []snatEntry
+	if tableIds := natGateway.SnatTableIds.SnatTableId; len(tableIds) > 0 {
+		snatEntries, err = vpcService.DescribeSnatTableEntries(tableIds[0])
+		if err != nil {
+			return WrapError(err)
+		}
+	}
+	if err := d.Set("snat_entries", flattenNatGatewaySnatEntries(snatEntries)); err != nil {
+		return WrapError(err)
+	}
+
 	return nil
 }
 
@@ -191,6 +300,11 @@ func resourceAliyunNatGatewayUpdate(d *schema.ResourceData, meta interface{}) er
 	}
 
 	d.Partial(true)
+	tagService := TagService{client}
+	if err := tagService.SetResourceTags(d, connectivity.VPCCode, TagResourceNatGateway); err != nil {
+		return WrapError(err)
+	}
+
 	attributeUpdate := false
 	args := vpc.CreateModifyNatGatewayAttributeRequest()
 	args.RegionId = natGateway.RegionId
@@ -247,16 +361,288 @@ func resourceAliyunNatGatewayUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 
 	}
+
+	if err := modifyNatGatewayEntries(d, meta); err != nil {
+		return err
+	}
 	d.Partial(false)
 
 	return resourceAliyunNatGatewayRead(d, meta)
 }
 
+// modifyNatGatewayEntries reconciles the DNAT and SNAT tables of the NAT gateway with the entries when they have been
+// changed. The entries of a table are compared with the ones in the cloud rather than the state, so an entry changed
+// out of band is also corrected.
+func modifyNatGatewayEntries(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("forward_entries") && !d.HasChange("snat_entries") {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	natGateway, err := vpcService.DescribeNatGateway(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+
+	if d.HasChange("forward_entries") {
+		if len(natGateway.ForwardTableIds.ForwardTableId) < 1 {
+			return WrapError(fmt.Errorf("The NAT gateway %s has no forward table.", d.Id()))
+		}
+		tableId := natGateway.ForwardTableIds.ForwardTableId[0]
+		entries, err := expandNatGatewayForwardEntries(tableId, d.Get("forward_entries").(*schema.Set).List())
+		if err != nil {
+			return WrapError(err)
+		}
+		if err := applyNatGatewayForwardEntries(&vpcService, tableId, entries); err != nil {
+			return err
+		}
+		d.SetPartial("forward_entries")
+	}
+
+	if d.HasChange("snat_entries") {
+		if len(natGateway.SnatTableIds.SnatTableId) < 1 {
+			return WrapError(fmt.Errorf("The NAT gateway %s has no SNAT table.", d.Id()))
+		}
+		tableId := natGateway.SnatTableIds.SnatTableId[0]
+		entries, err := expandNatGatewaySnatEntries(tableId, d.Get("snat_entries").(*schema.Set).List())
+		if err != nil {
+			return WrapError(err)
+		}
+		if err := applyNatGatewaySnatEntries(&vpcService, tableId, entries); err != nil {
+			return err
+		}
+		d.SetPartial("snat_entries")
+	}
+	return nil
+}
+
+// applyNatGatewayForwardEntries deletes the DNAT entries of the forward table which are not declared, and then modifies
+// and creates the declared ones in batches.
+func applyNatGatewayForwardEntries(vpcService *VpcService, tableId string, entries map[string]forwardEntry) error {
+	current, err := vpcService.DescribeForwardTableEntries(tableId)
+	if err != nil {
+		return WrapError(err)
+	}
+	existing := make(map[string]forwardEntry)
+	for _, entry := range current {
+		existing[forwardEntryKey(entry)] = entry
+	}
+
+	var deleted, changed []func() error
+	for _, key := range sortedNatEntryKeys(existing) {
+		entry := existing[key]
+		want, ok := entries[key]
+		if !ok {
+			deleted = append(deleted, func() error {
+				return vpcService.DeleteForwardEntry(tableId, entry.ForwardEntryId)
+			})
+		} else if want.InternalIp != entry.InternalIp || !strings.EqualFold(want.InternalPort, entry.InternalPort) ||
+			want.ForwardEntryName != entry.ForwardEntryName {
+			want.ForwardEntryId = entry.ForwardEntryId
+			changed = append(changed, func() error {
+				return vpcService.ModifyForwardEntry(want)
+			})
+		}
+	}
+	for _, key := range sortedNatEntryKeys(entries) {
+		if _, ok := existing[key]; !ok {
+			entry := entries[key]
+			changed = append(changed, func() error {
+				_, err := vpcService.CreateForwardEntry(entry)
+				return err
+			})
+		}
+	}
+
+	wait := func() error {
+		return vpcService.WaitForAllForwardEntries(tableId, Available, DefaultTimeout)
+	}
+	if err := processNatEntryBatches(deleted, wait); err != nil {
+		return err
+	}
+	return processNatEntryBatches(changed, wait)
+}
+
+// applyNatGatewaySnatEntries deletes the SNAT entries of the SNAT table which are not declared, and then modifies and
+// creates the declared ones in batches.
+func applyNatGatewaySnatEntries(vpcService *VpcService, tableId string, entries map[string]snatEntry) error {
+	current, err := vpcService.DescribeSnatTableEntries(tableId)
+	if err != nil {
+		return WrapError(err)
+	}
+	existing := make(map[string]snatEntry)
+	for _, entry := range current {
+		existing[snatEntryKey(entry)] = entry
+	}
+
+	var deleted, changed []func() error
+	for _, key := range sortedNatEntryKeys(existing) {
+		entry := existing[key]
+		want, ok := entries[key]
+		if !ok {
+			deleted = append(deleted, func() error {
+				return vpcService.DeleteSnatEntry(tableId, entry.SnatEntryId)
+			})
+		} else if want.SnatIp != entry.SnatIp || want.SnatEntryName != entry.SnatEntryName {
+			want.SnatEntryId = entry.SnatEntryId
+			changed = append(changed, func() error {
+				return vpcService.ModifySnatEntry(want)
+			})
+		}
+	}
+	for _, key := range sortedNatEntryKeys(entries) {
+		if _, ok := existing[key]; !ok {
+			entry := entries[key]
+			changed = append(changed, func() error {
+				_, err := vpcService.CreateSnatEntry(entry)
+				return err
+			})
+		}
+	}
+
+	wait := func() error {
+		return vpcService.WaitForAllSnatEntries(tableId, Available, DefaultTimeout)
+	}
+	if err := processNatEntryBatches(deleted, wait); err != nil {
+		return err
+	}
+	return processNatEntryBatches(changed, wait)
+}
+
+// forwardEntryKey identifies a DNAT entry by its external IP, external port and protocol, which can not be modified.
+func forwardEntryKey(entry forwardEntry) string {
+	return strings.ToLower(fmt.Sprintf("%s:%s:%s", entry.ExternalIp, entry.ExternalPort, entry.IpProtocol))
+}
+
+// snatEntryKey identifies a SNAT entry by its source vswitch or its source CIDR block, which can not be modified.
+func snatEntryKey(entry snatEntry) string {
+	if entry.SourceVSwitchId != "" {
+		return entry.SourceVSwitchId
+	}
+	return entry.SourceCIDR
+}
+
+// sortedNatEntryKeys returns the keys of the DNAT or SNAT entries in order, so the entries are changed in order.
+func sortedNatEntryKeys(entries interface{}) []string {
+	var keys []string
+	switch v := entries.(type) {
+	case map[string]forwardEntry:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	case map[string]snatEntry:
+		for key := range v {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func expandNatGatewayForwardEntries(tableId string, raw []interface{}) (map[string]forwardEntry, error) {
+	entries := make(map[string]forwardEntry)
+	for _, v := range raw {
+		item := v.(map[string]interface{})
+		entry := forwardEntry{ForwardEntryName: item["name"].(string)}
+		entry.ForwardTableId = tableId
+		entry.ExternalIp = item["external_ip"].(string)
+		entry.ExternalPort = item["external_port"].(string)
+		entry.IpProtocol = item["ip_protocol"].(string)
+		entry.InternalIp = item["internal_ip"].(string)
+		entry.InternalPort = item["internal_port"].(string)
+		if err := checkForwardEntryPorts(entry); err != nil {
+			return nil, err
+		}
+		key := forwardEntryKey(entry)
+		if _, ok := entries[key]; ok {
+			return nil, fmt.Errorf("The forward entry %s:%s/%s is duplicated.", entry.ExternalIp, entry.ExternalPort, entry.IpProtocol)
+		}
+		entries[key] = entry
+	}
+	return entries, nil
+}
+
+func expandNatGatewaySnatEntries(tableId string, raw []interface{}) (map[string]snatEntry, error) {
+	entries := make(map[string]snatEntry)
+	for _, v := range raw {
+		item := v.(map[string]interface{})
+		entry := snatEntry{SnatEntryName: item["name"].(string)}
+		entry.SnatTableId = tableId
+		entry.SourceVSwitchId = item["source_vswitch_id"].(string)
+		entry.SourceCIDR = item["source_cidr"].(string)
+		entry.SnatIp = item["snat_ip"].(string)
+		if (entry.SourceVSwitchId == "") == (entry.SourceCIDR == "") {
+			return nil, fmt.Errorf("The snat entry of %s must set one of 'source_vswitch_id' and 'source_cidr'.", entry.SnatIp)
+		}
+		key := snatEntryKey(entry)
+		if _, ok := entries[key]; ok {
+			return nil, fmt.Errorf("The snat entry of %s is duplicated.", key)
+		}
+		entries[key] = entry
+	}
+	return entries, nil
+}
+
+func flattenNatGatewayForwardEntries(entries []forwardEntry) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, entry := range entries {
+		result = append(result, map[string]interface{}{
+			"external_ip":   entry.ExternalIp,
+			"external_port": entry.ExternalPort,
+			"ip_protocol":   entry.IpProtocol,
+			"internal_ip":   entry.InternalIp,
+			"internal_port": entry.InternalPort,
+			"name":          entry.ForwardEntryName,
+		})
+	}
+	return result
+}
+
+func flattenNatGatewaySnatEntries(entries []snatEntry) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, entry := range entries {
+		item := map[string]interface{}{
+			"snat_ip": entry.SnatIp,
+			"name":    entry.SnatEntryName,
+		}
+		// The source CIDR block is also returned for the entry of a vswitch, and it is the CIDR block of the vswitch
+		if entry.SourceVSwitchId != "" {
+			item["source_vswitch_id"] = entry.SourceVSwitchId
+		} else {
+			item["source_cidr"] = entry.SourceCIDR
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
 func resourceAliyunNatGatewayDelete(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*connectivity.AliyunClient)
 	vpcService := VpcService{client}
 
+	// The NAT gateway can not be deleted until all of its DNAT and SNAT entries have been deleted
+	if d.Get("forward_entries").(*schema.Set).Len() > 0 || d.Get("snat_entries").(*schema.Set).Len() > 0 {
+		natGateway, err := vpcService.DescribeNatGateway(d.Id())
+		if err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return WrapError(err)
+		}
+		for _, tableId := range natGateway.ForwardTableIds.ForwardTableId {
+			if err := applyNatGatewayForwardEntries(&vpcService, tableId, nil); err != nil {
+				return err
+			}
+		}
+		for _, tableId := range natGateway.SnatTableIds.SnatTableId {
+			if err := applyNatGatewaySnatEntries(&vpcService, tableId, nil); err != nil {
+				return err
+			}
+		}
+	}
+
 	packRequest := vpc.CreateDescribeBandwidthPackagesRequest()
 	packRequest.RegionId = string(client.Region)
 	packRequest.NatGatewayId = d.Id()
@@ -198,6 +198,97 @@ func TestAccAlicloudNatGateway_specification(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudNatGateway_entries runs the inline DNAT and SNAT entries against the fake API server. There are more
+// DNAT entries than a batch, and a DNAT entry added out of band is deleted by the next apply.
+func TestUnitAlicloudNatGateway_entries(t *testing.T) {
+	var nat vpc.NatGateway
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_nat_gateway.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckNatGatewayDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNatGatewayEntriesConfig(10001, 10025, "80", "47.0.0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNatGatewayExists("alicloud_nat_gateway.foo", &nat),
+					resource.TestCheckResourceAttr("alicloud_nat_gateway.foo", "forward_entries.#", "25"),
+					resource.TestCheckResourceAttr("alicloud_nat_gateway.foo", "snat_entries.#", "2"),
+					testAccCheckNatGatewayEntries("alicloud_nat_gateway.foo", 25, "80", 2, "47.0.0.1"),
+				),
+			},
+			{
+				PreConfig: func() {
+					server.mutex.Lock()
+					defer server.mutex.Unlock()
+					for _, gateway := range server.natGateways {
+						tableId := gateway["ForwardTableIds"].(fakeObject)["ForwardTableId"].([]string)[0]
+						server.natEntries[tableId] = append(server.natEntries[tableId], fakeObject{
+							"ForwardTableId": tableId,
+							"ForwardEntryId": "fwd-abc123456",
+							"ExternalIp":     "47.0.0.1",
+							"ExternalPort":   "22",
+							"IpProtocol":     "tcp",
+							"InternalIp":     "172.16.0.100",
+							"InternalPort":   "22",
+							"Status":         string(Available),
+						})
+					}
+				},
+				Config: testAccNatGatewayEntriesConfig(10004, 10026, "8080", "47.0.0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNatGatewayExists("alicloud_nat_gateway.foo", &nat),
+					resource.TestCheckResourceAttr("alicloud_nat_gateway.foo", "forward_entries.#", "23"),
+					resource.TestCheckResourceAttr("alicloud_nat_gateway.foo", "snat_entries.#", "2"),
+					testAccCheckNatGatewayEntries("alicloud_nat_gateway.foo", 23, "8080", 2, "47.0.0.2"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNatGatewayEntries checks the number of the DNAT and SNAT entries of the NAT gateway in the cloud, and
+// that all of them have the internal port and the SNAT IP.
+func testAccCheckNatGatewayEntries(n string, forwardCount int, internalPort string, snatCount int, snatIp string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		vpcService := VpcService{client}
+		forwardEntries, err := vpcService.DescribeForwardTableEntries(rs.Primary.Attributes["forward_table_ids"])
+		if err != nil {
+			return WrapError(err)
+		}
+		if len(forwardEntries) != forwardCount {
+			return fmt.Errorf("expected %d forward entries, got %d", forwardCount, len(forwardEntries))
+		}
+		for _, entry := range forwardEntries {
+			if entry.InternalPort != internalPort {
+				return fmt.Errorf("expected the internal port of the forward entry %s to be %s, got %s", entry.ExternalPort, internalPort, entry.InternalPort)
+			}
+		}
+
+		snatEntries, err := vpcService.DescribeSnatTableEntries(rs.Primary.Attributes["snat_table_ids"])
+		if err != nil {
+			return WrapError(err)
+		}
+		if len(snatEntries) != snatCount {
+			return fmt.Errorf("expected %d snat entries, got %d", snatCount, len(snatEntries))
+		}
+		for _, entry := range snatEntries {
+			if entry.SnatIp != snatIp {
+				return fmt.Errorf("expected the snat ip of the snat entry %s to be %s, got %s", entry.SourceCIDR, snatIp, entry.SnatIp)
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckNatGatewayExists(n string, nat *vpc.NatGateway) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -385,3 +476,54 @@ resource "alicloud_nat_gateway" "foo" {
 	name = "${var.name}"
 }
 `
+
+// testAccNatGatewayEntriesConfig returns the NAT gateway with the DNAT entries of the external ports from first to last,
+// which are all forwarded to the internal port, and the SNAT entries of a vswitch and a CIDR block.
+func testAccNatGatewayEntriesConfig(first, last int, internalPort, snatIp string) string {
+	var forwardEntries []string
+	for port := first; port <= last; port++ {
+		forwardEntries = append(forwardEntries, fmt.Sprintf(`
+	forward_entries {
+		external_ip = "47.0.0.1"
+		external_port = "%d"
+		ip_protocol = "tcp"
+		internal_ip = "172.16.0.%d"
+		internal_port = "%s"
+		name = "tf-testAccNatGatewayEntries-%d"
+	}`, port, port%200+1, internalPort, port))
+	}
+	return fmt.Sprintf(`
+data "alicloud_zones" "default" {
+	"available_resource_creation"= "VSwitch"
+}
+
+resource "alicloud_vpc" "foo" {
+	name = "tf-testAccNatGatewayEntries"
+	cidr_block = "172.16.0.0/12"
+}
+
+resource "alicloud_vswitch" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	cidr_block = "172.16.0.0/21"
+	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+}
+
+resource "alicloud_nat_gateway" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	specification = "Small"
+	name = "tf-testAccNatGatewayEntries"
+	%s
+
+	snat_entries {
+		source_vswitch_id = "${alicloud_vswitch.foo.id}"
+		snat_ip = "%s"
+	}
+
+	snat_entries {
+		source_cidr = "172.16.8.0/24"
+		snat_ip = "%s"
+		name = "tf-testAccNatGatewayEntries"
+	}
+}
+`, strings.Join(forwardEntries, "\n"), snatIp, snatIp)
+}
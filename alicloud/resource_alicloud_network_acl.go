@@ -0,0 +1,158 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunNetworkAcl() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunNetworkAclCreate,
+		Read:   resourceAliyunNetworkAclRead,
+		Update: resourceAliyunNetworkAclUpdate,
+		Delete: resourceAliyunNetworkAclDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceName,
+			},
+
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+		},
+	}
+}
+
+func resourceAliyunNetworkAclCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request, err := vpcService.buildVpcCommonRequest("CreateNetworkAcl")
+	if err != nil {
+		return err
+	}
+	request.QueryParams["VpcId"] = d.Get("vpc_id").(string)
+	if v, ok := d.GetOk("name"); ok {
+		request.QueryParams["NetworkAclName"] = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.QueryParams["Description"] = v.(string)
+	}
+	request.QueryParams["ClientToken"] = buildClientToken("TF-CreateNetworkAcl")
+
+	response, err := vpcService.ProcessNetworkAclRequest("network_acl", request)
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		NetworkAclId string
+	}
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &resp); err != nil {
+		return WrapError(err)
+	}
+	if resp.NetworkAclId == "" {
+		return WrapError(fmt.Errorf("CreateNetworkAcl got an empty network ACL ID: %s", response.GetHttpContentString()))
+	}
+	d.SetId(resp.NetworkAclId)
+
+	if err := vpcService.WaitForNetworkAcl(d.Id(), Available, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunNetworkAclRead(d, meta)
+}
+
+func resourceAliyunNetworkAclRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	acl, err := vpcService.DescribeNetworkAcl(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("vpc_id", acl.VpcId)
+	d.Set("name", acl.NetworkAclName)
+	d.Set("description", acl.Description)
+
+	return nil
+}
+
+func resourceAliyunNetworkAclUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	if d.HasChange("name") || d.HasChange("description") {
+		request, err := vpcService.buildVpcCommonRequest("ModifyNetworkAclAttributes")
+		if err != nil {
+			return err
+		}
+		request.QueryParams["NetworkAclId"] = d.Id()
+		request.QueryParams["NetworkAclName"] = d.Get("name").(string)
+		request.QueryParams["Description"] = d.Get("description").(string)
+		if _, err := vpcService.ProcessNetworkAclRequest(d.Id(), request); err != nil {
+			return err
+		}
+		if err := vpcService.WaitForNetworkAcl(d.Id(), Available, DefaultTimeout); err != nil {
+			return WrapError(err)
+		}
+	}
+
+	return resourceAliyunNetworkAclRead(d, meta)
+}
+
+func resourceAliyunNetworkAclDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request, err := vpcService.buildVpcCommonRequest("DeleteNetworkAcl")
+	if err != nil {
+		return err
+	}
+	request.QueryParams["NetworkAclId"] = d.Id()
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ProcessCommonRequest(request)
+		})
+		if err != nil {
+			// The network ACL can not be deleted until all of the vswitches have been unassociated from it.
+			if IsExceptedErrors(err, append(NetworkAclInvalidOperations, DependencyViolation)) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := vpcService.DescribeNetworkAcl(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("Network Acl", "Deleted")), DeleteTimeoutMsg, d.Id(), request.GetActionName(), ProviderERROR))
+	})
+}
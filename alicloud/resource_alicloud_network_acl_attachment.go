@@ -0,0 +1,104 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunNetworkAclAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunNetworkAclAttachmentCreate,
+		Read:   resourceAliyunNetworkAclAttachmentRead,
+		Delete: resourceAliyunNetworkAclAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"network_acl_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"vswitch_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAliyunNetworkAclAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	aclId := d.Get("network_acl_id").(string)
+	vswitchId := d.Get("vswitch_id").(string)
+	if err := processNetworkAclAttachment(vpcService, "AssociateNetworkAcl", aclId, vswitchId); err != nil {
+		return err
+	}
+	if err := vpcService.WaitForNetworkAclAttachment(aclId, vswitchId, NetworkAclResourceBinded, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+
+	d.SetId(aclId + COLON_SEPARATED + vswitchId)
+
+	return resourceAliyunNetworkAclAttachmentRead(d, meta)
+}
+
+func resourceAliyunNetworkAclAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	parts := strings.Split(d.Id(), COLON_SEPARATED)
+	if len(parts) != 2 {
+		return WrapError(fmt.Errorf("Invalid network ACL attachment ID %s, and it should be <network_acl_id>:<vswitch_id>.", d.Id()))
+	}
+
+	if _, err := vpcService.DescribeNetworkAclAttachment(parts[0], parts[1]); err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("network_acl_id", parts[0])
+	d.Set("vswitch_id", parts[1])
+	return nil
+}
+
+func resourceAliyunNetworkAclAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	aclId := d.Get("network_acl_id").(string)
+	vswitchId := d.Get("vswitch_id").(string)
+	if err := processNetworkAclAttachment(vpcService, "UnassociateNetworkAcl", aclId, vswitchId); err != nil {
+		return err
+	}
+	if err := vpcService.WaitForNetworkAclAttachment(aclId, vswitchId, Deleted, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+// processNetworkAclAttachment associates the vswitch with the network ACL or unassociates it by the API.
+func processNetworkAclAttachment(vpcService VpcService, apiName, aclId, vswitchId string) error {
+	request, err := vpcService.buildVpcCommonRequest(apiName)
+	if err != nil {
+		return err
+	}
+	request.QueryParams["NetworkAclId"] = aclId
+	request.QueryParams["Resource.1.ResourceId"] = vswitchId
+	request.QueryParams["Resource.1.ResourceType"] = "VSwitch"
+	request.QueryParams["ClientToken"] = buildClientToken("TF-" + apiName)
+
+	_, err = vpcService.ProcessNetworkAclRequest(aclId+COLON_SEPARATED+vswitchId, request)
+	return err
+}
@@ -0,0 +1,183 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudNetworkAclAttachment_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_network_acl_attachment.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkAclAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclAttachmentConfig("foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclAttachmentExists("alicloud_network_acl_attachment.foo"),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl_attachment.foo", "vswitch_id", "alicloud_vswitch.foo", "id"),
+				),
+			},
+			{
+				Config: testAccNetworkAclAttachmentConfig("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclAttachmentExists("alicloud_network_acl_attachment.foo"),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl_attachment.foo", "vswitch_id", "alicloud_vswitch.bar", "id"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudNetworkAclAttachment_update runs the network ACL attachment against the fake API server, which reports
+// the vswitch BINDING or UNBINDING until it is described, and moves the ACL from one vswitch to the other.
+func TestUnitAlicloudNetworkAclAttachment_update(t *testing.T) {
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_network_acl_attachment.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckNetworkAclAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclAttachmentConfig("foo"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclAttachmentExists("alicloud_network_acl_attachment.foo"),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl_attachment.foo", "network_acl_id", "alicloud_network_acl.foo", "id"),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl_attachment.foo", "vswitch_id", "alicloud_vswitch.foo", "id"),
+				),
+			},
+			{
+				Config: testAccNetworkAclAttachmentConfig("bar"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclAttachmentExists("alicloud_network_acl_attachment.foo"),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl_attachment.foo", "vswitch_id", "alicloud_vswitch.bar", "id"),
+					testAccCheckNetworkAclAttachmentCount("alicloud_network_acl.foo", 1),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkAclAttachmentExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Network Acl Attachment ID is set")
+		}
+
+		parts := strings.Split(rs.Primary.ID, COLON_SEPARATED)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid resource id")
+		}
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		vpcService := VpcService{client}
+		attachment, err := vpcService.DescribeNetworkAclAttachment(parts[0], parts[1])
+		if err != nil {
+			return WrapError(err)
+		}
+		if attachment.Status != string(NetworkAclResourceBinded) {
+			return WrapError(fmt.Errorf("Network Acl Attachment %s is %s", rs.Primary.ID, attachment.Status))
+		}
+		return nil
+	}
+}
+
+// testAccCheckNetworkAclAttachmentCount checks the number of the vswitches associated with the network ACL.
+func testAccCheckNetworkAclAttachmentCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		vpcService := VpcService{client}
+		acl, err := vpcService.DescribeNetworkAcl(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+		if len(acl.Resources.Resource) != count {
+			return WrapError(fmt.Errorf("Network Acl %s has %d vswitches, expected %d", acl.NetworkAclId, len(acl.Resources.Resource), count))
+		}
+		return nil
+	}
+}
+
+func testAccCheckNetworkAclAttachmentDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_network_acl_attachment" {
+			continue
+		}
+
+		parts := strings.Split(rs.Primary.ID, COLON_SEPARATED)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid resource id")
+		}
+		if _, err := vpcService.DescribeNetworkAclAttachment(parts[0], parts[1]); err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("Network Acl Attachment %s still exist", rs.Primary.ID))
+	}
+
+	return testAccCheckNetworkAclDestroy(s)
+}
+
+func testAccNetworkAclAttachmentConfig(vswitch string) string {
+	return fmt.Sprintf(`
+	data "alicloud_zones" "default" {
+		available_resource_creation = "VSwitch"
+	}
+
+	resource "alicloud_vpc" "foo" {
+		name = "tf-testAccNetworkAclAttachmentConfig"
+		cidr_block = "172.16.0.0/12"
+	}
+
+	resource "alicloud_vswitch" "foo" {
+		vpc_id = "${alicloud_vpc.foo.id}"
+		cidr_block = "172.16.0.0/21"
+		availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+		name = "tf-testAccNetworkAclAttachmentConfig"
+	}
+
+	resource "alicloud_vswitch" "bar" {
+		vpc_id = "${alicloud_vpc.foo.id}"
+		cidr_block = "172.16.8.0/21"
+		availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+		name = "tf-testAccNetworkAclAttachmentConfig"
+	}
+
+	resource "alicloud_network_acl" "foo" {
+		vpc_id = "${alicloud_vpc.foo.id}"
+		name = "tf-testAccNetworkAclAttachmentConfig"
+	}
+
+	resource "alicloud_network_acl_attachment" "foo" {
+		network_acl_id = "${alicloud_network_acl.foo.id}"
+		vswitch_id = "${alicloud_vswitch.%s.id}"
+	}
+	`, vswitch)
+}
@@ -0,0 +1,207 @@
+package alicloud
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunNetworkAclEntries() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunNetworkAclEntriesCreate,
+		Read:   resourceAliyunNetworkAclEntriesRead,
+		Update: resourceAliyunNetworkAclEntriesUpdate,
+		Delete: resourceAliyunNetworkAclEntriesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"network_acl_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ingress": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     networkAclEntryResource(DirectionIngress),
+			},
+
+			"egress": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     networkAclEntryResource(DirectionEgress),
+			},
+		},
+	}
+}
+
+// networkAclEntryResource returns the schema of the entries in the direction, and the peer of the entry is the source
+// of the ingress one and the destination of the egress one.
+func networkAclEntryResource(direction Direction) *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(Icmp), string(Gre), string(Tcp), string(Udp), string(All)}),
+			},
+
+			"port": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  AllPortRange,
+			},
+
+			networkAclEntryPeerKey(direction): {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCIDRNetworkAddress,
+			},
+
+			"policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      NetworkAclAccept,
+				ValidateFunc: validateAllowedStringValue([]string{string(NetworkAclAccept), string(NetworkAclDrop)}),
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceName,
+			},
+
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+		},
+	}
+}
+
+func networkAclEntryPeerKey(direction Direction) string {
+	if direction == DirectionEgress {
+		return "destination_cidr_ip"
+	}
+	return "source_cidr_ip"
+}
+
+func resourceAliyunNetworkAclEntriesCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(d.Get("network_acl_id").(string))
+
+	for _, direction := range []Direction{DirectionIngress, DirectionEgress} {
+		if err := updateNetworkAclEntries(d, meta, direction); err != nil {
+			return err
+		}
+	}
+
+	return resourceAliyunNetworkAclEntriesRead(d, meta)
+}
+
+func resourceAliyunNetworkAclEntriesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	acl, err := vpcService.DescribeNetworkAcl(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("network_acl_id", acl.NetworkAclId)
+	if err := d.Set("ingress", flattenNetworkAclEntries(acl.IngressAclEntries.IngressAclEntry, DirectionIngress)); err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("egress", flattenNetworkAclEntries(acl.EgressAclEntries.EgressAclEntry, DirectionEgress)); err != nil {
+		return WrapError(err)
+	}
+
+	return nil
+}
+
+func resourceAliyunNetworkAclEntriesUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("ingress") {
+		if err := updateNetworkAclEntries(d, meta, DirectionIngress); err != nil {
+			return err
+		}
+	}
+	if d.HasChange("egress") {
+		if err := updateNetworkAclEntries(d, meta, DirectionEgress); err != nil {
+			return err
+		}
+	}
+
+	return resourceAliyunNetworkAclEntriesRead(d, meta)
+}
+
+func resourceAliyunNetworkAclEntriesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	for _, direction := range []Direction{DirectionIngress, DirectionEgress} {
+		if err := vpcService.UpdateNetworkAclEntries(d.Id(), direction, nil); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return WrapError(err)
+		}
+	}
+	return nil
+}
+
+// updateNetworkAclEntries replaces the custom entries of the network ACL in the direction with the configured ones.
+func updateNetworkAclEntries(d *schema.ResourceData, meta interface{}, direction Direction) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	var entries []map[string]string
+	for _, e := range d.Get(string(direction)).([]interface{}) {
+		entry := e.(map[string]interface{})
+		peer := "SourceCidrIp"
+		if direction == DirectionEgress {
+			peer = "DestinationCidrIp"
+		}
+		entries = append(entries, map[string]string{
+			"Protocol":            entry["protocol"].(string),
+			"Port":                entry["port"].(string),
+			peer:                  entry[networkAclEntryPeerKey(direction)].(string),
+			"Policy":              entry["policy"].(string),
+			"NetworkAclEntryName": entry["name"].(string),
+			"Description":         entry["description"].(string),
+		})
+	}
+
+	if err := vpcService.UpdateNetworkAclEntries(d.Id(), direction, entries); err != nil {
+		return WrapError(err)
+	}
+	return nil
+}
+
+// flattenNetworkAclEntries returns the custom entries in order, and the system entries created by the cloud are skipped.
+func flattenNetworkAclEntries(entries []networkAclEntry, direction Direction) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, entry := range entries {
+		if entry.EntryType != NetworkAclEntryCustom {
+			continue
+		}
+		peer := entry.SourceCidrIp
+		if direction == DirectionEgress {
+			peer = entry.DestinationCidrIp
+		}
+		result = append(result, map[string]interface{}{
+			"protocol":                        entry.Protocol,
+			"port":                            entry.Port,
+			networkAclEntryPeerKey(direction): peer,
+			"policy":                          entry.Policy,
+			"name":                            entry.NetworkAclEntryName,
+			"description":                     entry.Description,
+		})
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAlicloudNetworkAclEntries_basic(t *testing.T) {
+	var acl networkAcl
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_network_acl_entries.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkAclDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclEntriesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					testAccCheckNetworkAclEntries(&acl, []string{"22/22", AllPortRange}, []string{AllPortRange}),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "egress.#", "1"),
+				),
+			},
+			{
+				Config: testAccNetworkAclEntriesConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					testAccCheckNetworkAclEntries(&acl, []string{"443/443", "22/22", AllPortRange}, nil),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.#", "3"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "egress.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudNetworkAclEntries_update runs the network ACL entries against the fake API server. It checks the
+// entries are kept in order, the removed ones are deleted, and the entries added out of band are removed.
+func TestUnitAlicloudNetworkAclEntries_update(t *testing.T) {
+	var acl networkAcl
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_network_acl_entries.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckNetworkAclDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclEntriesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					testAccCheckNetworkAclEntries(&acl, []string{"22/22", AllPortRange}, []string{AllPortRange}),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl_entries.foo", "network_acl_id", "alicloud_network_acl.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.0.protocol", "tcp"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.0.port", "22/22"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.0.source_cidr_ip", "10.0.0.0/8"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.0.policy", "accept"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.0.name", "ssh"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.1.protocol", "all"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.1.port", AllPortRange),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.1.policy", "drop"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "egress.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "egress.0.destination_cidr_ip", "0.0.0.0/0"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "egress.0.description", "all outbound"),
+				),
+			},
+			{
+				Config: testAccNetworkAclEntriesConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					testAccCheckNetworkAclEntries(&acl, []string{"443/443", "22/22", AllPortRange}, nil),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.#", "3"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.0.port", "443/443"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.1.port", "22/22"),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "egress.#", "0"),
+				),
+			},
+			{
+				// An entry added out of band is removed by the next apply
+				PreConfig: func() {
+					server.mutex.Lock()
+					defer server.mutex.Unlock()
+					for _, acl := range server.networkAcls {
+						entries := acl["IngressAclEntries"].(fakeObject)["IngressAclEntry"].([]fakeObject)
+						acl["IngressAclEntries"] = fakeObject{"IngressAclEntry": append([]fakeObject{{
+							"NetworkAclEntryId": server.newId("nae"),
+							"Policy":            string(NetworkAclAccept),
+							"Protocol":          string(Udp),
+							"Port":              "53/53",
+							"SourceCidrIp":      "0.0.0.0/0",
+							"EntryType":         NetworkAclEntryCustom,
+						}}, entries...)}
+					}
+				},
+				Config: testAccNetworkAclEntriesConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					testAccCheckNetworkAclEntries(&acl, []string{"443/443", "22/22", AllPortRange}, nil),
+					resource.TestCheckResourceAttr("alicloud_network_acl_entries.foo", "ingress.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckNetworkAclEntries checks the ports of the custom entries of the network ACL in order.
+func testAccCheckNetworkAclEntries(acl *networkAcl, ingress, egress []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		check := func(direction Direction, entries []networkAclEntry, expected []string) error {
+			var ports []string
+			for _, entry := range entries {
+				if entry.EntryType == NetworkAclEntryCustom {
+					ports = append(ports, entry.Port)
+				}
+			}
+			if fmt.Sprint(ports) != fmt.Sprint(expected) {
+				return WrapError(fmt.Errorf("The %s entries of the network ACL %s have the ports %v, expected %v", direction, acl.NetworkAclId, ports, expected))
+			}
+			return nil
+		}
+		if err := check(DirectionIngress, acl.IngressAclEntries.IngressAclEntry, ingress); err != nil {
+			return err
+		}
+		return check(DirectionEgress, acl.EgressAclEntries.EgressAclEntry, egress)
+	}
+}
+
+const testAccNetworkAclEntriesConfig = `
+resource "alicloud_vpc" "foo" {
+	name = "tf-testAccNetworkAclEntriesConfig"
+	cidr_block = "172.16.0.0/12"
+}
+
+resource "alicloud_network_acl" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	name = "tf-testAccNetworkAclEntriesConfig"
+}
+
+resource "alicloud_network_acl_entries" "foo" {
+	network_acl_id = "${alicloud_network_acl.foo.id}"
+
+	ingress {
+		protocol = "tcp"
+		port = "22/22"
+		source_cidr_ip = "10.0.0.0/8"
+		name = "ssh"
+	}
+
+	ingress {
+		protocol = "all"
+		source_cidr_ip = "0.0.0.0/0"
+		policy = "drop"
+	}
+
+	egress {
+		protocol = "all"
+		destination_cidr_ip = "0.0.0.0/0"
+		description = "all outbound"
+	}
+}
+`
+
+const testAccNetworkAclEntriesConfigUpdate = `
+resource "alicloud_vpc" "foo" {
+	name = "tf-testAccNetworkAclEntriesConfig"
+	cidr_block = "172.16.0.0/12"
+}
+
+resource "alicloud_network_acl" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	name = "tf-testAccNetworkAclEntriesConfig"
+}
+
+resource "alicloud_network_acl_entries" "foo" {
+	network_acl_id = "${alicloud_network_acl.foo.id}"
+
+	ingress {
+		protocol = "tcp"
+		port = "443/443"
+		source_cidr_ip = "0.0.0.0/0"
+		name = "https"
+	}
+
+	ingress {
+		protocol = "tcp"
+		port = "22/22"
+		source_cidr_ip = "10.0.0.0/8"
+		name = "ssh"
+	}
+
+	ingress {
+		protocol = "all"
+		source_cidr_ip = "0.0.0.0/0"
+		policy = "drop"
+	}
+}
+`
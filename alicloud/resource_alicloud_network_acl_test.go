@@ -0,0 +1,141 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudNetworkAcl_basic(t *testing.T) {
+	var acl networkAcl
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_network_acl.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkAclDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclConfig("tf-testAccNetworkAclConfig", "The web tier"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl.foo", "vpc_id", "alicloud_vpc.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "name", "tf-testAccNetworkAclConfig"),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "description", "The web tier"),
+				),
+			},
+			{
+				Config: testAccNetworkAclConfig("tf-testAccNetworkAclConfig-update", "The app tier"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "name", "tf-testAccNetworkAclConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "description", "The app tier"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudNetworkAcl_update runs the CRUD of the network ACL against the fake API server, which reports the ACL
+// Modifying after each change so that the waiters are run.
+func TestUnitAlicloudNetworkAcl_update(t *testing.T) {
+	var acl networkAcl
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_network_acl.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckNetworkAclDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkAclConfig("tf-testAccNetworkAclConfig", "The web tier"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					resource.TestCheckResourceAttrPair("alicloud_network_acl.foo", "vpc_id", "alicloud_vpc.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "name", "tf-testAccNetworkAclConfig"),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "description", "The web tier"),
+				),
+			},
+			{
+				Config: testAccNetworkAclConfig("tf-testAccNetworkAclConfig-update", "The app tier"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkAclExists("alicloud_network_acl.foo", &acl),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "name", "tf-testAccNetworkAclConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_network_acl.foo", "description", "The app tier"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkAclExists(n string, acl *networkAcl) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Network Acl ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		vpcService := VpcService{client}
+		v, err := vpcService.DescribeNetworkAcl(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+		if v.Status != string(Available) {
+			return WrapError(fmt.Errorf("Network Acl %s is %s", v.NetworkAclId, v.Status))
+		}
+
+		*acl = v
+		return nil
+	}
+}
+
+func testAccCheckNetworkAclDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_network_acl" {
+			continue
+		}
+
+		acl, err := vpcService.DescribeNetworkAcl(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("Network Acl %s still exist", acl.NetworkAclId))
+	}
+
+	return nil
+}
+
+func testAccNetworkAclConfig(name, description string) string {
+	return fmt.Sprintf(`
+	resource "alicloud_vpc" "foo" {
+		name = "tf-testAccNetworkAclConfig"
+		cidr_block = "172.16.0.0/12"
+	}
+
+	resource "alicloud_network_acl" "foo" {
+		vpc_id = "${alicloud_vpc.foo.id}"
+		name = "%s"
+		description = "%s"
+	}
+	`, name, description)
+}
@@ -62,6 +62,22 @@ func resourceAliyunNetworkInterface() *schema.Resource {
 				ValidateFunc:  validateIntegerInRange(1, 10),
 				ConflictsWith: []string{"private_ips"},
 			},
+			"ipv6_addresses": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Set:           schema.HashString,
+				MaxItems:      10,
+				ConflictsWith: []string{"ipv6_address_count"},
+			},
+			"ipv6_address_count": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validateIntegerInRange(1, 10),
+				ConflictsWith: []string{"ipv6_addresses"},
+			},
 			"description": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -136,15 +152,16 @@ func resourceAliyunNetworkInterfaceRead(d *schema.ResourceData, meta interface{}
 		}
 	}
 	d.Set("private_ips", privateIps)
+	ipv6s := ipv6Addresses(eni)
+	d.Set("ipv6_addresses", ipv6s)
+	d.Set("ipv6_address_count", len(ipv6s))
 
 	tags, err := ecsService.DescribeTags(d.Id(), TagResourceEni)
 	if err != nil && !NotFoundError(err) {
 		return fmt.Errorf("DescribeTags of NetworkInterface(%s) failed, %#v", d.Id(), err)
 	}
 
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
 
 	return nil
 }
@@ -306,6 +323,14 @@ func resourceAliyunNetworkInterfaceUpdate(d *schema.ResourceData, meta interface
 		}
 	}
 
+	if d.HasChange("ipv6_addresses") || d.HasChange("ipv6_address_count") {
+		if err := modifyNetworkInterfaceIpv6Addresses(d, meta, d.Id(), "ipv6_addresses", "ipv6_address_count"); err != nil {
+			return err
+		}
+		d.SetPartial("ipv6_addresses")
+		d.SetPartial("ipv6_address_count")
+	}
+
 	if err := setTags(client, TagResourceEni, d); err != nil {
 		return fmt.Errorf("SetTags of NetworkInterface(%s) failed, %#v", d.Id(), err)
 	} else {
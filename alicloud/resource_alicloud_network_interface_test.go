@@ -284,6 +284,80 @@ func TestAccAlicloudNetworkInterfaceWithoutPrimaryIpAddress(t *testing.T) {
 	})
 }
 
+func TestAccAlicloudNetworkInterfaceWithIpv6Addresses(t *testing.T) {
+	var eni ecs.NetworkInterfaceSet
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		IDRefreshName: "alicloud_network_interface.eni",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkInterfaceDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkInterfaceConfigWithIpv6Addresses("ipv6_address_count = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEniExists("alicloud_network_interface.eni", &eni),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_address_count", "2"),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccNetworkInterfaceConfigWithIpv6Addresses("ipv6_address_count = 1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEniExists("alicloud_network_interface.eni", &eni),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_address_count", "1"),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_addresses.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudNetworkInterface_ipv6 runs assigning and unassigning the IPv6 addresses of the network interface by
+// the count and by the list against the fake API server.
+func TestUnitAlicloudNetworkInterface_ipv6(t *testing.T) {
+	var eni ecs.NetworkInterfaceSet
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_network_interface.eni",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckNetworkInterfaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetworkInterfaceConfigWithIpv6Addresses("ipv6_address_count = 2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEniExists("alicloud_network_interface.eni", &eni),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_address_count", "2"),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_addresses.#", "2"),
+				),
+			},
+			{
+				Config: testAccNetworkInterfaceConfigWithIpv6Addresses("ipv6_address_count = 3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEniExists("alicloud_network_interface.eni", &eni),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_address_count", "3"),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_addresses.#", "3"),
+				),
+			},
+			{
+				// The address list replaces the count, and the addresses out of the list are unassigned
+				Config: testAccNetworkInterfaceConfigWithIpv6Addresses(`ipv6_addresses = ["2408:4000:1:1::100", "2408:4000:1:1::101"]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEniExists("alicloud_network_interface.eni", &eni),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_address_count", "2"),
+					resource.TestCheckResourceAttr("alicloud_network_interface.eni", "ipv6_addresses.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckEniExists(n string, eni *ecs.NetworkInterfaceSet) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -572,3 +646,19 @@ resource "alicloud_network_interface" "eni" {
 	}
 }
 `
+
+func testAccNetworkInterfaceConfigWithIpv6Addresses(args string) string {
+	return fmt.Sprintf(`
+%s
+variable "name" {
+	default = "tf-testAccNetworkInterfaceConfigWithIpv6Addresses"
+}
+
+resource "alicloud_network_interface" "eni" {
+	name = "${var.name}"
+	vswitch_id = "${alicloud_vswitch.default.id}"
+	security_groups = ["${alicloud_security_group.default.id}"]
+	%s
+}
+`, EcsInstanceIpv6CommonTestCase, args)
+}
@@ -203,6 +203,7 @@ func resourceAlicloudOssBucket() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -419,6 +420,13 @@ func resourceAlicloudOssBucketRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	ossService := OssService{client}
+	tags, err := ossService.DescribeOssBucketTags(d.Id())
+	if err != nil {
+		return err
+	}
+	d.Set("tags", providerTags(client, d, tags))
+
 	return nil
 }
 
@@ -472,6 +480,11 @@ func resourceAlicloudOssBucketUpdate(d *schema.ResourceData, meta interface{}) e
 		d.SetPartial("lifecycle_rule")
 	}
 
+	ossService := OssService{client}
+	if err := ossService.SetOssBucketTags(d); err != nil {
+		return err
+	}
+
 	d.Partial(false)
 	return resourceAlicloudOssBucketRead(d, meta)
 }
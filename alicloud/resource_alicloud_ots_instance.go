@@ -114,7 +114,7 @@ func resourceAliyunOtsInstanceRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("accessed_by", convertInstanceAccessedByRevert(inst.Network))
 	d.Set("instance_type", convertInstanceTypeRevert(inst.ClusterType))
 	d.Set("description", inst.Description)
-	d.Set("tags", otsTagsToMap(inst.TagInfos.TagInfo))
+	d.Set("tags", providerTags(client, d, otsTagsToMap(inst.TagInfos.TagInfo)))
 	return nil
 }
 
@@ -137,12 +137,7 @@ func resourceAliyunOtsInstanceUpdate(d *schema.ResourceData, meta interface{}) e
 		d.SetPartial("accessed_by")
 	}
 
-	if d.HasChange("tags") {
-		oraw, nraw := d.GetChange("tags")
-		o := oraw.(map[string]interface{})
-		n := nraw.(map[string]interface{})
-		create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
-
+	if create, remove, changed := tagsChange(client, d); changed {
 		if len(remove) > 0 {
 			args := ots.CreateDeleteTagsRequest()
 			args.InstanceName = d.Id()
@@ -69,6 +69,10 @@ func resourceAliyunRouteEntryCreate(d *schema.ResourceData, meta interface{}) er
 		}
 		return WrapError(err)
 	}
+	// The route entries of the router are changed one by one, including the ones declared in alicloud_route_table
+	routerMutexKV.Lock(table.VRouterId)
+	defer routerMutexKV.Unlock(table.VRouterId)
+
 	request := vpc.CreateCreateRouteEntryRequest()
 	request.RouteTableId = rtId
 	request.DestinationCidrBlock = cidr
@@ -164,6 +168,9 @@ func resourceAliyunRouteEntryDelete(d *schema.ResourceData, meta interface{}) er
 	nexthop_type := parts[3]
 	nexthop_id := parts[4]
 
+	routerMutexKV.Lock(parts[1])
+	defer routerMutexKV.Unlock(parts[1])
+
 	retryTimes := 7
 	return resource.Retry(10*time.Minute, func() *resource.RetryError {
 		en, err := vpcService.QueryRouteEntry(rtId, cidr, nexthop_type, nexthop_id)
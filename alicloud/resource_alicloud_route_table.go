@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -49,10 +50,69 @@ func resourceAliyunRouteTable() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+
+			// The routes are authoritative when they are set, and the custom route entries which are not declared are
+			// deleted. The system route entries are left out.
+			"route": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination_cidrblock": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCIDRNetworkAddress,
+						},
+						"nexthop_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateRouteNextHopType,
+						},
+						"nexthop_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						// The next hops of an ECMP route entry
+						"nexthop": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     routeTableNextHopResource(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func routeTableNextHopResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"nexthop_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateRouteNextHopType,
+			},
+			"nexthop_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"weight": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      DefaultRouteNextHopWeight,
+				ValidateFunc: validateIntegerInRange(0, 255),
+			},
 		},
 	}
 }
 
+var validateRouteNextHopType = validateAllowedStringValue([]string{
+	string(NextHopIntance), string(NextHopHaVip), string(NextHopRouterInterface), string(NextHopNetworkInterface),
+	string(NextHopVpnGateway), string(NextHopNatGateway),
+})
+
 func resourceAliyunRouteTableCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	routeTableService := RouteTableService{client}
@@ -78,6 +138,10 @@ func resourceAliyunRouteTableCreate(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("Wait for route table got error: %#v", err)
 	}
 
+	if err := modifyRouteTableRoutes(d, meta); err != nil {
+		return err
+	}
+
 	return resourceAliyunRouteTableRead(d, meta)
 }
 
@@ -98,6 +162,14 @@ func resourceAliyunRouteTableRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("name", resp.RouteTableName)
 	d.Set("description", resp.Description)
 
+	routes, err := routeTableService.DescribeRouteTableRoutes(d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	if err := d.Set("route", flattenRouteTableRoutes(routes)); err != nil {
+		return WrapError(err)
+	}
+
 	return nil
 }
 
@@ -127,13 +199,171 @@ func resourceAliyunRouteTableUpdate(d *schema.ResourceData, meta interface{}) er
 		}
 	}
 
+	if d.HasChange("route") {
+		if err := modifyRouteTableRoutes(d, meta); err != nil {
+			return err
+		}
+	}
+
 	return resourceAliyunRouteTableRead(d, meta)
 }
 
+// modifyRouteTableRoutes deletes the route entries removed from the routes and then creates the added ones, so that an
+// entry whose next hop is changed is replaced.
+func modifyRouteTableRoutes(d *schema.ResourceData, meta interface{}) error {
+	o, n := d.GetChange("route")
+	oldRoutes, err := expandRouteTableRoutes(o.(*schema.Set).List())
+	if err != nil {
+		return WrapError(err)
+	}
+	newRoutes, err := expandRouteTableRoutes(n.(*schema.Set).List())
+	if err != nil {
+		return WrapError(err)
+	}
+
+	var deleted, created []routeTableRoute
+	for _, key := range sortedRouteKeys(oldRoutes) {
+		if _, ok := newRoutes[key]; !ok {
+			deleted = append(deleted, oldRoutes[key])
+		}
+	}
+	for _, key := range sortedRouteKeys(newRoutes) {
+		if _, ok := oldRoutes[key]; !ok {
+			created = append(created, newRoutes[key])
+		}
+	}
+	return applyRouteTableRoutes(d.Id(), meta, deleted, created)
+}
+
+// applyRouteTableRoutes deletes and then creates the route entries one by one while the router of the route table is
+// locked.
+func applyRouteTableRoutes(routeTableId string, meta interface{}, deleted, created []routeTableRoute) error {
+	if len(deleted) < 1 && len(created) < 1 {
+		return nil
+	}
+	client := meta.(*connectivity.AliyunClient)
+	routeTableService := RouteTableService{client}
+
+	table, err := routeTableService.DescribeRouteTable(routeTableId)
+	if err != nil {
+		return WrapError(err)
+	}
+	routerMutexKV.Lock(table.RouterId)
+	defer routerMutexKV.Unlock(table.RouterId)
+
+	for _, route := range deleted {
+		if err := routeTableService.DeleteRouteTableRoute(routeTableId, route); err != nil {
+			return err
+		}
+	}
+	for _, route := range created {
+		if err := routeTableService.CreateRouteTableRoute(routeTableId, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandRouteTableRoutes converts the routes to the route entries, keyed by the destination CIDR block and the next hops
+// which together identify an entry.
+func expandRouteTableRoutes(routes []interface{}) (map[string]routeTableRoute, error) {
+	entries := make(map[string]routeTableRoute)
+	for _, raw := range routes {
+		route := raw.(map[string]interface{})
+		entry := routeTableRoute{DestinationCidrBlock: route["destination_cidrblock"].(string)}
+		nextHops := route["nexthop"].(*schema.Set).List()
+		if len(nextHops) > 0 {
+			if route["nexthop_type"].(string) != "" || route["nexthop_id"].(string) != "" {
+				return nil, fmt.Errorf("The route %s can not specify both 'nexthop' and 'nexthop_type' or 'nexthop_id'.", entry.DestinationCidrBlock)
+			}
+			if len(nextHops) < 2 {
+				return nil, fmt.Errorf("The ECMP route %s must have at least 2 'nexthop'.", entry.DestinationCidrBlock)
+			}
+			for _, rawNextHop := range nextHops {
+				nextHop := rawNextHop.(map[string]interface{})
+				entry.NextHops = append(entry.NextHops, vpc.NextHop{
+					NextHopType: nextHop["nexthop_type"].(string),
+					NextHopId:   nextHop["nexthop_id"].(string),
+					Weight:      nextHop["weight"].(int),
+				})
+			}
+			sort.Slice(entry.NextHops, func(i, j int) bool {
+				return entry.NextHops[i].NextHopId < entry.NextHops[j].NextHopId
+			})
+		} else {
+			if route["nexthop_type"].(string) == "" || route["nexthop_id"].(string) == "" {
+				return nil, fmt.Errorf("The route %s must specify 'nexthop_type' and 'nexthop_id', or at least 2 'nexthop'.", entry.DestinationCidrBlock)
+			}
+			entry.NextHops = []vpc.NextHop{{NextHopType: route["nexthop_type"].(string), NextHopId: route["nexthop_id"].(string)}}
+		}
+
+		key := entry.DestinationCidrBlock
+		for _, nextHop := range entry.NextHops {
+			key += fmt.Sprintf(";%s:%s:%d", nextHop.NextHopType, nextHop.NextHopId, nextHop.Weight)
+		}
+		if _, ok := entries[key]; ok {
+			return nil, fmt.Errorf("The route %s is duplicated.", entry.DestinationCidrBlock)
+		}
+		entries[key] = entry
+	}
+	return entries, nil
+}
+
+func sortedRouteKeys(routes map[string]routeTableRoute) []string {
+	var keys []string
+	for key := range routes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func flattenRouteTableRoutes(routes []routeTableRoute) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, route := range routes {
+		r := map[string]interface{}{
+			"destination_cidrblock": route.DestinationCidrBlock,
+		}
+		if len(route.NextHops) > 1 {
+			var nextHops []interface{}
+			for _, nextHop := range route.NextHops {
+				nextHops = append(nextHops, map[string]interface{}{
+					"nexthop_type": nextHop.NextHopType,
+					"nexthop_id":   nextHop.NextHopId,
+					"weight":       nextHop.Weight,
+				})
+			}
+			// The nested set can not be written from a list, so it is built as a set
+			r["nexthop"] = schema.NewSet(schema.HashResource(routeTableNextHopResource()), nextHops)
+		} else {
+			r["nexthop_type"] = route.NextHops[0].NextHopType
+			r["nexthop_id"] = route.NextHops[0].NextHopId
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
 func resourceAliyunRouteTableDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	routeTableService := RouteTableService{client}
 
+	// The route table can not be deleted until all of its custom route entries have been deleted
+	routes, err := expandRouteTableRoutes(d.Get("route").(*schema.Set).List())
+	if err != nil {
+		return WrapError(err)
+	}
+	var deleted []routeTableRoute
+	for _, key := range sortedRouteKeys(routes) {
+		deleted = append(deleted, routes[key])
+	}
+	if err := applyRouteTableRoutes(d.Id(), meta, deleted, nil); err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
 	request := vpc.CreateDeleteRouteTableRequest()
 	request.RouteTableId = d.Id()
 
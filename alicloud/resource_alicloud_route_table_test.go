@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"testing"
 
@@ -124,6 +125,112 @@ func TestAccAlicloudRouteTable_basic(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudRouteTable_routes runs the routes of the route table against the fake API server, which rejects the
+// changes of the route entries while any entry of the router is not available. It checks the plain and the ECMP routes
+// are replaced when changed, and a route added out of band shows up in the plan and is deleted by the next apply.
+func TestUnitAlicloudRouteTable_routes(t *testing.T) {
+	var routeTable vpc.DescribeRouteTableListResponse
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_route_table.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckRouteTableDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRouteTableRoutesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRouteTableListExists("alicloud_route_table.foo", &routeTable),
+					testAccCheckRouteTableRoutes("alicloud_route_table.foo", []string{
+						"10.0.0.0/8 HaVip:havip-abc123456",
+						"192.168.0.0/16 RouterInterface:ri-abc123456 RouterInterface:ri-def123456",
+					}),
+					resource.TestCheckResourceAttr("alicloud_route_table.foo", "route.#", "2"),
+				),
+			},
+			{
+				Config: testAccRouteTableRoutesConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRouteTableListExists("alicloud_route_table.foo", &routeTable),
+					testAccCheckRouteTableRoutes("alicloud_route_table.foo", []string{
+						"10.0.0.0/8 NetworkInterface:eni-abc123456",
+						"47.100.0.0/16 VpnGateway:vpn-abc123456",
+						"47.101.0.0/16 NatGateway:ngw-abc123456",
+					}),
+					resource.TestCheckResourceAttr("alicloud_route_table.foo", "route.#", "3"),
+				),
+			},
+			{
+				// A route added out of band shows up in the plan
+				PreConfig: func() {
+					server.mutex.Lock()
+					defer server.mutex.Unlock()
+					for id, table := range server.routeTables {
+						entries := table["RouteEntrys"].(fakeObject)["RouteEntry"].([]fakeObject)
+						table["RouteEntrys"] = fakeObject{"RouteEntry": append(entries, fakeObject{
+							"RouteTableId":         id,
+							"DestinationCidrBlock": "8.8.8.0/24",
+							"Type":                 RouteEntryCustom,
+							"Status":               string(Available),
+							"NextHopType":          string(NextHopIntance),
+							"InstanceId":           "i-abc123456",
+							"NextHops":             fakeObject{"NextHop": []fakeObject{{"NextHopType": string(NextHopIntance), "NextHopId": "i-abc123456"}}},
+						})}
+					}
+				},
+				Config:             testAccRouteTableRoutesConfigUpdate,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRouteTableRoutesConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRouteTableRoutes("alicloud_route_table.foo", []string{
+						"10.0.0.0/8 NetworkInterface:eni-abc123456",
+						"47.100.0.0/16 VpnGateway:vpn-abc123456",
+						"47.101.0.0/16 NatGateway:ngw-abc123456",
+					}),
+					resource.TestCheckResourceAttr("alicloud_route_table.foo", "route.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckRouteTableRoutes checks the custom route entries of the route table, and each of them is formatted as the
+// destination CIDR block followed by the next hops sorted by their IDs.
+func testAccCheckRouteTableRoutes(n string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		routeTableService := RouteTableService{client}
+		routes, err := routeTableService.DescribeRouteTableRoutes(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+		var actual []string
+		for _, route := range routes {
+			var nextHops []string
+			for _, nextHop := range route.NextHops {
+				nextHops = append(nextHops, nextHop.NextHopType+":"+nextHop.NextHopId)
+			}
+			sort.Slice(nextHops, func(i, j int) bool {
+				return strings.Split(nextHops[i], ":")[1] < strings.Split(nextHops[j], ":")[1]
+			})
+			actual = append(actual, strings.Join(append([]string{route.DestinationCidrBlock}, nextHops...), " "))
+		}
+		sort.Strings(actual)
+		if fmt.Sprint(actual) != fmt.Sprint(expected) {
+			return WrapError(fmt.Errorf("The route table %s has the routes %v, expected %v", rs.Primary.ID, actual, expected))
+		}
+		return nil
+	}
+}
+
 func testAccCheckRouteTableListExists(n string, routeTable *vpc.DescribeRouteTableListResponse) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -182,3 +289,66 @@ resource "alicloud_route_table" "foo" {
 }
 
 `
+
+const testAccRouteTableRoutesConfig = `
+resource "alicloud_vpc" "foo" {
+	cidr_block = "172.16.0.0/12"
+	name = "tf-testAccRouteTableRoutesConfig"
+}
+
+resource "alicloud_route_table" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	name = "tf-testAccRouteTableRoutesConfig"
+
+	route {
+		destination_cidrblock = "10.0.0.0/8"
+		nexthop_type = "HaVip"
+		nexthop_id = "havip-abc123456"
+	}
+
+	route {
+		destination_cidrblock = "192.168.0.0/16"
+
+		nexthop {
+			nexthop_type = "RouterInterface"
+			nexthop_id = "ri-abc123456"
+		}
+
+		nexthop {
+			nexthop_type = "RouterInterface"
+			nexthop_id = "ri-def123456"
+			weight = 50
+		}
+	}
+}
+`
+
+const testAccRouteTableRoutesConfigUpdate = `
+resource "alicloud_vpc" "foo" {
+	cidr_block = "172.16.0.0/12"
+	name = "tf-testAccRouteTableRoutesConfig"
+}
+
+resource "alicloud_route_table" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	name = "tf-testAccRouteTableRoutesConfig"
+
+	route {
+		destination_cidrblock = "10.0.0.0/8"
+		nexthop_type = "NetworkInterface"
+		nexthop_id = "eni-abc123456"
+	}
+
+	route {
+		destination_cidrblock = "47.100.0.0/16"
+		nexthop_type = "VpnGateway"
+		nexthop_id = "vpn-abc123456"
+	}
+
+	route {
+		destination_cidrblock = "47.101.0.0/16"
+		nexthop_type = "NatGateway"
+		nexthop_id = "ngw-abc123456"
+	}
+}
+`
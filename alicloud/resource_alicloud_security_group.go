@@ -2,6 +2,9 @@ package alicloud
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
@@ -43,11 +46,83 @@ func resourceAliyunSecurityGroup() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			// The rules are authoritative when they are set, and the rules which are not declared are revoked.
+			"ingress": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     securityGroupPermissionResource(),
+			},
+			"egress": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     securityGroupPermissionResource(),
+			},
 			"tags": tagsSchema(),
 		},
 	}
 }
 
+func securityGroupPermissionResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ip_protocol": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateSecurityRuleIpProtocol,
+			},
+			"port_range": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  AllPortRange,
+			},
+			"nic_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(GroupRuleIntranet),
+				ValidateFunc: validateSecurityRuleNicType,
+			},
+			"policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(GroupRulePolicyAccept),
+				ValidateFunc: validateSecurityRulePolicy,
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validateSecurityPriority,
+			},
+			"cidr_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ipv6_cidr_ip": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"prefix_list_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_security_group_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source_group_owner_account": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
 func resourceAliyunSecurityGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 
@@ -110,13 +185,21 @@ func resourceAliyunSecurityGroupRead(d *schema.ResourceData, meta interface{}) e
 	d.Set("vpc_id", sg.VpcId)
 	d.Set("inner_access", sg.InnerAccessPolicy == string(GroupInnerAccept))
 
+	for _, direction := range []Direction{DirectionIngress, DirectionEgress} {
+		permissions, err := ecsService.DescribeSecurityGroupPermissions(d.Id(), direction)
+		if err != nil {
+			return WrapError(err)
+		}
+		if err := d.Set(string(direction), flattenSecurityGroupPermissions(permissions, direction)); err != nil {
+			return WrapError(err)
+		}
+	}
+
 	tags, err := ecsService.DescribeTags(d.Id(), TagResourceSecurityGroup)
 	if err != nil && !NotFoundError(err) {
 		return WrapError(err)
 	}
-	if len(tags) > 0 {
-		d.Set("tags", tagsToMap(tags))
-	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
 
 	return nil
 }
@@ -173,11 +256,159 @@ func resourceAliyunSecurityGroupUpdate(d *schema.ResourceData, meta interface{})
 		d.SetPartial("inner_access")
 	}
 
+	for _, direction := range []Direction{DirectionIngress, DirectionEgress} {
+		if d.HasChange(string(direction)) {
+			if err := modifySecurityGroupPermissions(d, meta, direction); err != nil {
+				return WrapError(err)
+			}
+			d.SetPartial(string(direction))
+		}
+	}
+
 	d.Partial(false)
 
 	return resourceAliyunSecurityGroupRead(d, meta)
 }
 
+// modifySecurityGroupPermissions revokes the rules removed from the direction and authorizes the ones added to it.
+// The rules whose description is the only change are modified in place.
+func modifySecurityGroupPermissions(d *schema.ResourceData, meta interface{}, direction Direction) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	o, n := d.GetChange(string(direction))
+	oldPermissions, err := expandSecurityGroupPermissions(o.(*schema.Set).List(), direction)
+	if err != nil {
+		return WrapError(err)
+	}
+	newPermissions, err := expandSecurityGroupPermissions(n.(*schema.Set).List(), direction)
+	if err != nil {
+		return WrapError(err)
+	}
+
+	var revoked, authorized []map[string]string
+	for key, permission := range oldPermissions {
+		if _, ok := newPermissions[key]; !ok {
+			delete(permission, "Description")
+			revoked = append(revoked, permission)
+		}
+	}
+	for key, permission := range newPermissions {
+		old, ok := oldPermissions[key]
+		if !ok {
+			authorized = append(authorized, permission)
+			continue
+		}
+		if old["Description"] != permission["Description"] {
+			if err := ecsService.ModifySecurityGroupPermissionDescription(d.Id(), direction, permission); err != nil {
+				return WrapError(err)
+			}
+		}
+	}
+
+	authorizeApi, revokeApi := "AuthorizeSecurityGroup", "RevokeSecurityGroup"
+	if direction == DirectionEgress {
+		authorizeApi, revokeApi = "AuthorizeSecurityGroupEgress", "RevokeSecurityGroupEgress"
+	}
+	// The rules are revoked first, so that a rule replaced by one with the same peer does not conflict with it.
+	if err := ecsService.ModifySecurityGroupPermissions(d.Id(), revokeApi, revoked); err != nil {
+		return WrapError(err)
+	}
+	return ecsService.ModifySecurityGroupPermissions(d.Id(), authorizeApi, authorized)
+}
+
+// expandSecurityGroupPermissions converts the rules to the request parameters, keyed by all of the parameters except
+// the description, which together identify a rule.
+func expandSecurityGroupPermissions(rules []interface{}, direction Direction) (map[string]map[string]string, error) {
+	peer := "Source"
+	if direction == DirectionEgress {
+		peer = "Dest"
+	}
+	permissions := make(map[string]map[string]string)
+	for _, raw := range rules {
+		rule := raw.(map[string]interface{})
+		protocol := rule["ip_protocol"].(string)
+		portRange := rule["port_range"].(string)
+		if protocol == string(Tcp) || protocol == string(Udp) {
+			if portRange == AllPortRange {
+				return nil, fmt.Errorf("'tcp' and 'udp' can support port range: [1, 65535]. Please correct it and try again.")
+			}
+		} else if portRange != AllPortRange {
+			return nil, fmt.Errorf("'icmp', 'gre' and 'all' only support port range '-1/-1'. Please correct it and try again.")
+		}
+
+		permission := map[string]string{
+			"IpProtocol": protocol,
+			"PortRange":  portRange,
+			"NicType":    rule["nic_type"].(string),
+			"Policy":     rule["policy"].(string),
+			"Priority":   strconv.Itoa(rule["priority"].(int)),
+		}
+		peers := 0
+		for field, param := range map[string]string{
+			"cidr_ip":                  peer + "CidrIp",
+			"ipv6_cidr_ip":             "Ipv6" + peer + "CidrIp",
+			"prefix_list_id":           peer + "PrefixListId",
+			"source_security_group_id": peer + "GroupId",
+		} {
+			if v := rule[field].(string); v != "" {
+				permission[param] = v
+				peers++
+			}
+		}
+		if peers != 1 {
+			return nil, fmt.Errorf("Exactly one of 'cidr_ip', 'ipv6_cidr_ip', 'prefix_list_id' and 'source_security_group_id' must be specified in the %s rule.", direction)
+		}
+		if v := rule["source_group_owner_account"].(string); v != "" {
+			permission[peer+"GroupOwnerAccount"] = v
+		}
+		if permission[peer+"GroupId"] != "" && permission["NicType"] != string(GroupRuleIntranet) {
+			return nil, fmt.Errorf("When authorizing permission for source/destination security group, the nic_type must be 'intranet'.")
+		}
+
+		var keys []string
+		for key, value := range permission {
+			keys = append(keys, key+"="+value)
+		}
+		sort.Strings(keys)
+		if v := rule["description"].(string); v != "" {
+			permission["Description"] = v
+		}
+		permissions[strings.Join(keys, ";")] = permission
+	}
+	return permissions, nil
+}
+
+func flattenSecurityGroupPermissions(permissions []securityGroupPermission, direction Direction) []map[string]interface{} {
+	var rules []map[string]interface{}
+	for _, permission := range permissions {
+		priority, _ := strconv.Atoi(permission.Priority)
+		rule := map[string]interface{}{
+			"ip_protocol": strings.ToLower(permission.IpProtocol),
+			"port_range":  permission.PortRange,
+			"nic_type":    permission.NicType,
+			"policy":      strings.ToLower(permission.Policy),
+			"priority":    priority,
+			"description": permission.Description,
+		}
+		if direction == DirectionIngress {
+			rule["cidr_ip"] = permission.SourceCidrIp
+			rule["ipv6_cidr_ip"] = permission.Ipv6SourceCidrIp
+			rule["prefix_list_id"] = permission.SourcePrefixListId
+			rule["source_security_group_id"] = permission.SourceGroupId
+			rule["source_group_owner_account"] = permission.SourceGroupOwnerAccount
+		} else {
+			rule["cidr_ip"] = permission.DestCidrIp
+			rule["ipv6_cidr_ip"] = permission.Ipv6DestCidrIp
+			rule["prefix_list_id"] = permission.DestPrefixListId
+			rule["source_security_group_id"] = permission.DestGroupId
+			rule["source_group_owner_account"] = permission.DestGroupOwnerAccount
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
 func resourceAliyunSecurityGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
@@ -79,6 +79,13 @@ func resourceAliyunSecurityGroupRule() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"ipv6_cidr_ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cidr_ip", "source_security_group_id"},
+			},
+
 			"source_security_group_id": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -109,10 +116,10 @@ func resourceAliyunSecurityGroupRuleCreate(d *schema.ResourceData, meta interfac
 	policy := d.Get("policy").(string)
 	priority := d.Get("priority").(int)
 
-	if _, ok := d.GetOk("cidr_ip"); !ok {
-		if _, ok := d.GetOk("source_security_group_id"); !ok {
-			return WrapError(fmt.Errorf("Either 'cidr_ip' or 'source_security_group_id' must be specified."))
-		}
+	_, cidrOk := d.GetOk("cidr_ip")
+	_, ipv6CidrOk := d.GetOk("ipv6_cidr_ip")
+	if _, ok := d.GetOk("source_security_group_id"); !ok && !cidrOk && !ipv6CidrOk {
+		return WrapError(fmt.Errorf("One of 'cidr_ip', 'ipv6_cidr_ip' and 'source_security_group_id' must be specified."))
 	}
 
 	request, err := buildAliyunSGRuleRequest(d, meta)
@@ -138,6 +145,8 @@ func resourceAliyunSecurityGroupRuleCreate(d *schema.ResourceData, meta interfac
 	var cidr_ip string
 	if ip, ok := d.GetOk("cidr_ip"); ok {
 		cidr_ip = ip.(string)
+	} else if ip, ok := d.GetOk("ipv6_cidr_ip"); ok {
+		cidr_ip = ip.(string)
 	} else {
 		cidr_ip = d.Get("source_security_group_id").(string)
 	}
@@ -149,7 +158,7 @@ func resourceAliyunSecurityGroupRuleCreate(d *schema.ResourceData, meta interfac
 func resourceAliyunSecurityGroupRuleRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
-	parts := strings.Split(d.Id(), ":")
+	parts := securityGroupRuleIdParts(d.Id())
 	policy := parseSecurityRuleId(d, meta, 6)
 	strPriority := parseSecurityRuleId(d, meta, 7)
 	var priority int
@@ -190,10 +199,12 @@ func resourceAliyunSecurityGroupRuleRead(d *schema.ResourceData, meta interface{
 	//support source and desc by type
 	if direction == string(DirectionIngress) {
 		d.Set("cidr_ip", rule.SourceCidrIp)
+		d.Set("ipv6_cidr_ip", rule.Ipv6SourceCidrIp)
 		d.Set("source_security_group_id", rule.SourceGroupId)
 		d.Set("source_group_owner_account", rule.SourceGroupOwnerAccount)
 	} else {
 		d.Set("cidr_ip", rule.DestCidrIp)
+		d.Set("ipv6_cidr_ip", rule.Ipv6DestCidrIp)
 		d.Set("source_security_group_id", rule.DestGroupId)
 		d.Set("source_group_owner_account", rule.DestGroupOwnerAccount)
 	}
@@ -227,7 +238,7 @@ func deleteSecurityGroupRule(d *schema.ResourceData, meta interface{}) error {
 func resourceAliyunSecurityGroupRuleDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
 	ecsService := EcsService{client}
-	parts := strings.Split(d.Id(), ":")
+	parts := securityGroupRuleIdParts(d.Id())
 	policy := parseSecurityRuleId(d, meta, 6)
 	strPriority := parseSecurityRuleId(d, meta, 7)
 	var priority int
@@ -308,6 +319,14 @@ func buildAliyunSGRuleRequest(d *schema.ResourceData, meta interface{}) (*reques
 		}
 	}
 
+	if v, ok := d.GetOk("ipv6_cidr_ip"); ok {
+		if direction == string(DirectionIngress) {
+			request.QueryParams["Ipv6SourceCidrIp"] = v.(string)
+		} else {
+			request.QueryParams["Ipv6DestCidrIp"] = v.(string)
+		}
+	}
+
 	var targetGroupId string
 	if v, ok := d.GetOk("source_security_group_id"); ok {
 		targetGroupId = v.(string)
@@ -349,7 +368,7 @@ func buildAliyunSGRuleRequest(d *schema.ResourceData, meta interface{}) (*reques
 }
 
 func parseSecurityRuleId(d *schema.ResourceData, meta interface{}, index int) (result string) {
-	parts := strings.Split(d.Id(), ":")
+	parts := securityGroupRuleIdParts(d.Id())
 	defer func() {
 		if e := recover(); e != nil {
 			fmt.Printf("Panicing %s\r\n", e)
@@ -358,3 +377,14 @@ func parseSecurityRuleId(d *schema.ResourceData, meta interface{}, index int) (r
 	}()
 	return parts[index]
 }
+
+// securityGroupRuleIdParts splits the rule ID into the parts. The IPv6 CIDR block of the rule contains colons as well,
+// and it is joined back when the ID has more than 8 parts.
+func securityGroupRuleIdParts(id string) []string {
+	parts := strings.Split(id, ":")
+	if len(parts) <= 8 {
+		return parts
+	}
+	peerEnd := len(parts) - 2
+	return append(append(parts[:5:5], strings.Join(parts[5:peerEnd], ":")), parts[peerEnd:]...)
+}
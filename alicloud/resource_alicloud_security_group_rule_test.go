@@ -5,7 +5,6 @@ import (
 	"log"
 	"regexp"
 	"strconv"
-	"strings"
 	"testing"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
@@ -345,6 +344,62 @@ func TestAccAlicloudSecurityGroupRule_MultiAttri(t *testing.T) {
 
 }
 
+func TestAccAlicloudSecurityGroupRule_Ipv6(t *testing.T) {
+	var pt ecs.Permission
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_security_group_rule.ingress",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSecurityGroupRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupRuleIpv6,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleExists("alicloud_security_group_rule.ingress", &pt),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.ingress", "ipv6_cidr_ip", "2408:4000:1::/48"),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.ingress", "cidr_ip", ""),
+					testAccCheckSecurityGroupRuleExists("alicloud_security_group_rule.egress", &pt),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.egress", "ipv6_cidr_ip", "::/0"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudSecurityGroupRule_Ipv6 runs the ingress and egress rules with the IPv6 CIDR blocks against the fake API
+// server. The IPv6 CIDR blocks contain colons, which are the separator of the rule ID as well.
+func TestUnitAlicloudSecurityGroupRule_Ipv6(t *testing.T) {
+	var pt ecs.Permission
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_security_group_rule.ingress",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSecurityGroupRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSecurityGroupRuleIpv6,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupRuleExists("alicloud_security_group_rule.ingress", &pt),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.ingress", "type", "ingress"),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.ingress", "port_range", "22/22"),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.ingress", "ipv6_cidr_ip", "2408:4000:1::/48"),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.ingress", "cidr_ip", ""),
+					testAccCheckSecurityGroupRuleExists("alicloud_security_group_rule.egress", &pt),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.egress", "type", "egress"),
+					resource.TestCheckResourceAttr("alicloud_security_group_rule.egress", "ipv6_cidr_ip", "::/0"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSecurityGroupRuleExists(n string, m *ecs.Permission) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -359,7 +414,7 @@ func testAccCheckSecurityGroupRuleExists(n string, m *ecs.Permission) resource.T
 		client := testAccProvider.Meta().(*connectivity.AliyunClient)
 		ecsService := EcsService{client}
 		log.Printf("[WARN]get sg rule %s", rs.Primary.ID)
-		parts := strings.Split(rs.Primary.ID, ":")
+		parts := securityGroupRuleIdParts(rs.Primary.ID)
 		prior, err := strconv.Atoi(parts[7])
 		if err != nil {
 			return fmt.Errorf("testSecrityGroupRuleExists parse rule id gets an error: %#v", err)
@@ -384,7 +439,7 @@ func testAccCheckSecurityGroupRuleDestroy(s *terraform.State) error {
 			continue
 		}
 
-		parts := strings.Split(rs.Primary.ID, ":")
+		parts := securityGroupRuleIdParts(rs.Primary.ID)
 		prior, err := strconv.Atoi(parts[7])
 		if err != nil {
 			return fmt.Errorf("testSecrityGroupRuleDestroy parse rule id gets an error: %#v", err)
@@ -746,3 +801,36 @@ resource "alicloud_security_group_rule" "gre" {
   cidr_ip = "0.0.0.0/0"
 }
 `
+
+const testAccSecurityGroupRuleIpv6 = `
+variable "name" {
+  default = "tf-testAccSecurityGroupRuleIpv6"
+}
+
+resource "alicloud_vpc" "vpc" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/24"
+  enable_ipv6 = true
+}
+
+resource "alicloud_security_group" "foo" {
+  name = "${var.name}"
+  vpc_id = "${alicloud_vpc.vpc.id}"
+}
+
+resource "alicloud_security_group_rule" "ingress" {
+  type = "ingress"
+  ip_protocol = "tcp"
+  port_range = "22/22"
+  ipv6_cidr_ip = "2408:4000:1::/48"
+  security_group_id = "${alicloud_security_group.foo.id}"
+}
+
+resource "alicloud_security_group_rule" "egress" {
+  type = "egress"
+  ip_protocol = "all"
+  port_range = "-1/-1"
+  ipv6_cidr_ip = "::/0"
+  security_group_id = "${alicloud_security_group.foo.id}"
+}
+`
@@ -3,6 +3,8 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
 	"testing"
 
 	"strings"
@@ -251,6 +253,193 @@ func TestAccAlicloudSecurityGroup_tags(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudSecurityGroup_tags runs the CRUD of the security group against the fake API server.
+func TestUnitAlicloudSecurityGroup_tags(t *testing.T) {
+	var group ecs.DescribeSecurityGroupAttributeResponse
+	var vpc vpc.DescribeVpcAttributeResponse
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_security_group.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSecurityGroupConfigTags,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists("alicloud_security_group.foo", &group),
+					testAccCheckVpcExists("alicloud_vpc.vpc", &vpc),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "name", "tf-testAccCheckSecurityGroupConfigTags"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "inner_access", "true"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.%", "2"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.foo", "foo"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.bar", "bar"),
+				),
+			},
+			{
+				Config: testAccCheckSecurityGroupConfigTagsDecrease,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists("alicloud_security_group.foo", &group),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.%", "2"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.foo", "foo"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.test", "test"),
+				),
+			},
+			{
+				Config: testAccCheckSecurityGroupConfigInner,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists("alicloud_security_group.foo", &group),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "inner_access", "false"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.%", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitAlicloudSecurityGroup_defaultTags(t *testing.T) {
+	var group ecs.DescribeSecurityGroupAttributeResponse
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	// The tag added by the other systems is ignored by the provider.
+	create := server.handlers["ECS.CreateSecurityGroup"]
+	server.Handle("ECS.CreateSecurityGroup", func(request *fakeApiRequest) (interface{}, error) {
+		response, err := create(request)
+		if err == nil {
+			server.setTag(response.(fakeObject)["SecurityGroupId"].(string), "external", "external")
+		}
+		return response, err
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_security_group.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSecurityGroupConfigDefaultTags,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists("alicloud_security_group.foo", &group),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.%", "2"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.foo", "foo"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.env", "prod"),
+					server.CheckTags("alicloud_security_group.foo", map[string]string{
+						"foo": "foo", "env": "prod", "owner": "team-a", "external": "external",
+					}),
+				),
+			},
+			{
+				Config: testAccCheckSecurityGroupConfigDefaultTagsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists("alicloud_security_group.foo", &group),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.%", "1"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "tags.foo", "foo"),
+					server.CheckTags("alicloud_security_group.foo", map[string]string{
+						"foo": "foo", "env": "test", "owner": "team-b", "cost-center": "cc", "external": "external",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudSecurityGroup_rules manages the rules of the security group authoritatively against the fake API
+// server, including the batched authorization, the description change and the removal of the unknown rules.
+func TestUnitAlicloudSecurityGroup_rules(t *testing.T) {
+	var group ecs.DescribeSecurityGroupAttributeResponse
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	authorizations := 0
+	authorize := server.handlers["ECS.AuthorizeSecurityGroup"]
+	server.Handle("ECS.AuthorizeSecurityGroup", func(request *fakeApiRequest) (interface{}, error) {
+		authorizations++
+		return authorize(request)
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_security_group.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckSecurityGroupConfigRules,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists("alicloud_security_group.foo", &group),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "ingress.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "egress.#", "1"),
+					testAccCheckSecurityGroupPermissions(server, "alicloud_security_group.foo", []string{
+						"ingress/TCP/22/22/10.0.0.0/8/ssh",
+						"ingress/TCP/443/443/2001:db8::/32/",
+						"egress/ALL/-1/-1/pl-foo/",
+					}),
+					func(*terraform.State) error {
+						if authorizations != 1 {
+							return fmt.Errorf("expected the ingress rules to be authorized in one request, got %d", authorizations)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// The rule added out of band is revoked by the next apply.
+				PreConfig: func() {
+					server.mutex.Lock()
+					defer server.mutex.Unlock()
+					permissions := server.securityGroups[group.SecurityGroupId]["Permissions"].(fakeObject)
+					permissions["Permission"] = append(permissions["Permission"].([]fakeObject), fakeObject{
+						"Direction": string(DirectionIngress), "IpProtocol": "TCP", "PortRange": "3389/3389",
+						"SourceCidrIp": "0.0.0.0/0", "NicType": string(GroupRuleIntranet), "Policy": "Accept", "Priority": "1",
+					})
+				},
+				Config: testAccCheckSecurityGroupConfigRulesUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSecurityGroupExists("alicloud_security_group.foo", &group),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "ingress.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_security_group.foo", "egress.#", "1"),
+					testAccCheckSecurityGroupPermissions(server, "alicloud_security_group.foo", []string{
+						"ingress/TCP/22/22/10.0.0.0/8/ssh from the office",
+						"egress/ALL/-1/-1/pl-foo/",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckSecurityGroupPermissions checks the permissions of the security group kept by the fake API server, and
+// each of them is formatted as "direction/protocol/port range/peer/description".
+func testAccCheckSecurityGroupPermissions(server *fakeApiServer, n string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+
+		var permissions []string
+		for _, permission := range server.securityGroups[rs.Primary.ID]["Permissions"].(fakeObject)["Permission"].([]fakeObject) {
+			peer := ""
+			for _, key := range []string{"SourceCidrIp", "Ipv6SourceCidrIp", "SourcePrefixListId", "SourceGroupId", "DestCidrIp", "Ipv6DestCidrIp", "DestPrefixListId", "DestGroupId"} {
+				if v, _ := permission[key].(string); v != "" {
+					peer = v
+				}
+			}
+			description, _ := permission["Description"].(string)
+			permissions = append(permissions, fmt.Sprintf("%s/%s/%s/%s/%s", permission["Direction"], permission["IpProtocol"], permission["PortRange"], peer, description))
+		}
+		sort.Strings(permissions)
+		sort.Strings(expected)
+		if !reflect.DeepEqual(permissions, expected) {
+			return fmt.Errorf("expected the permissions %v, got %v", expected, permissions)
+		}
+		return nil
+	}
+}
+
 func TestAccAlicloudSecurityGroup_inner_access(t *testing.T) {
 	var group ecs.DescribeSecurityGroupAttributeResponse
 	var vpc vpc.DescribeVpcAttributeResponse
@@ -467,3 +656,101 @@ resource "alicloud_vpc" "vpc" {
   cidr_block = "10.1.0.0/21"
 }
 `
+
+const testAccCheckSecurityGroupConfigDefaultTags = `
+provider "alicloud" {
+  default_tags = {
+    owner = "team-a"
+    env   = "test"
+  }
+  ignore_tags {
+    keys = ["external"]
+  }
+}
+
+variable "name" {
+  default = "tf-testAccCheckSecurityGroupConfigDefaultTags"
+}
+
+resource "alicloud_vpc" "vpc" {
+  cidr_block = "10.1.0.0/21"
+  name = "${var.name}"
+}
+
+resource "alicloud_security_group" "foo" {
+  vpc_id = "${alicloud_vpc.vpc.id}"
+  name = "${var.name}"
+  tags {
+    foo = "foo"
+    env = "prod"
+  }
+}
+`
+
+const testAccCheckSecurityGroupConfigDefaultTagsUpdate = `
+provider "alicloud" {
+  default_tags = {
+    owner       = "team-b"
+    env         = "test"
+    cost-center = "cc"
+  }
+  ignore_tags {
+    keys = ["external"]
+  }
+}
+
+variable "name" {
+  default = "tf-testAccCheckSecurityGroupConfigDefaultTags"
+}
+
+resource "alicloud_vpc" "vpc" {
+  cidr_block = "10.1.0.0/21"
+  name = "${var.name}"
+}
+
+resource "alicloud_security_group" "foo" {
+  vpc_id = "${alicloud_vpc.vpc.id}"
+  name = "${var.name}"
+  tags {
+    foo = "foo"
+  }
+}
+`
+
+const testAccCheckSecurityGroupConfigRules = `
+resource "alicloud_security_group" "foo" {
+  name = "tf-testAccCheckSecurityGroupConfigRules"
+  ingress {
+    ip_protocol = "tcp"
+    port_range = "22/22"
+    cidr_ip = "10.0.0.0/8"
+    description = "ssh"
+  }
+  ingress {
+    ip_protocol = "tcp"
+    port_range = "443/443"
+    ipv6_cidr_ip = "2001:db8::/32"
+    priority = 10
+  }
+  egress {
+    ip_protocol = "all"
+    prefix_list_id = "pl-foo"
+  }
+}
+`
+
+const testAccCheckSecurityGroupConfigRulesUpdate = `
+resource "alicloud_security_group" "foo" {
+  name = "tf-testAccCheckSecurityGroupConfigRules"
+  ingress {
+    ip_protocol = "tcp"
+    port_range = "22/22"
+    cidr_ip = "10.0.0.0/8"
+    description = "ssh from the office"
+  }
+  egress {
+    ip_protocol = "all"
+    prefix_list_id = "pl-foo"
+  }
+}
+`
@@ -45,6 +45,14 @@ func resourceAliyunSlb() *schema.Resource {
 				DiffSuppressFunc: slbInternetDiffSuppressFunc,
 			},
 
+			"address_ip_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      IPVersion4,
+				ValidateFunc: validateAllowedStringValue([]string{string(IPVersion4), string(IPVersion6)}),
+			},
+
 			"internet_charge_type": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -247,6 +255,14 @@ func resourceAliyunSlbCreate(d *schema.ResourceData, meta interface{}) error {
 		args.VSwitchId = v.(string)
 	}
 
+	// The IPv6 load balancer only supports the internet address
+	if v := d.Get("address_ip_version").(string); v == string(IPVersion6) {
+		if !d.Get("internet").(bool) {
+			return fmt.Errorf("The 'address_ip_version' %s is only supported when 'internet' is true.", IPVersion6)
+		}
+		args.AddressIPVersion = v
+	}
+
 	if v, ok := d.GetOk("bandwidth"); ok && v.(int) != 0 {
 		args.Bandwidth = requests.NewInteger(v.(int))
 	}
@@ -308,12 +324,15 @@ func resourceAliyunSlbRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("bandwidth", loadBalancer.Bandwidth)
 	d.Set("vswitch_id", loadBalancer.VSwitchId)
 	d.Set("address", loadBalancer.Address)
+	if loadBalancer.AddressIPVersion != "" {
+		d.Set("address_ip_version", loadBalancer.AddressIPVersion)
+	} else {
+		d.Set("address_ip_version", IPVersion4)
+	}
 	d.Set("specification", loadBalancer.LoadBalancerSpec)
 
 	tags, _ := slbService.describeTags(d.Id())
-	if len(tags) > 0 {
-		d.Set("tags", slbService.slbTagsToMap(tags))
-	}
+	d.Set("tags", providerTags(client, d, slbService.slbTagsToMap(tags)))
 	return nil
 }
 
@@ -23,6 +23,8 @@ func resourceAliyunSlbListener() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
+		CustomizeDiff: slbListenerCustomizeDiffFunc,
+
 		Schema: map[string]*schema.Schema{
 			"load_balancer_id": {
 				Type:     schema.TypeString,
@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"testing"
 
 	"strings"
@@ -197,6 +198,86 @@ func TestAccAlicloudSlb_spec(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudSlb_vpc runs the CRUD of the load balancer against the fake API server.
+func TestUnitAlicloudSlb_vpc(t *testing.T) {
+	var slb slb.DescribeLoadBalancerAttributeResponse
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_slb.vpc",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSlbDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlb4Vpc,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSlbExists("alicloud_slb.vpc", &slb),
+					resource.TestCheckResourceAttr("alicloud_slb.vpc", "name", "tf-testAccSlb4Vpc"),
+					resource.TestCheckResourceAttr("alicloud_slb.vpc", "specification", "slb.s2.small"),
+					resource.TestCheckResourceAttrSet("alicloud_slb.vpc", "vswitch_id"),
+					resource.TestCheckResourceAttrSet("alicloud_slb.vpc", "address"),
+					resource.TestCheckResourceAttr("alicloud_slb.vpc", "tags.%", "10"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlicloudSlb_ipv6(t *testing.T) {
+	var slb slb.DescribeLoadBalancerAttributeResponse
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "alicloud_slb.ipv6",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSlbDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlbIpv6,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSlbExists("alicloud_slb.ipv6", &slb),
+					resource.TestCheckResourceAttr("alicloud_slb.ipv6", "address_ip_version", "ipv6"),
+					resource.TestCheckResourceAttrSet("alicloud_slb.ipv6", "address"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudSlb_ipv6 runs the IPv6 internet load balancer against the fake API server, and the IPv4 one is the
+// default.
+func TestUnitAlicloudSlb_ipv6(t *testing.T) {
+	var slb slb.DescribeLoadBalancerAttributeResponse
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_slb.ipv6",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSlbDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSlbIpv6,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSlbExists("alicloud_slb.ipv6", &slb),
+					resource.TestCheckResourceAttr("alicloud_slb.ipv6", "internet", "true"),
+					resource.TestCheckResourceAttr("alicloud_slb.ipv6", "address_ip_version", "ipv6"),
+					resource.TestCheckResourceAttr("alicloud_slb.ipv6", "address", "2408:4000:1::100"),
+					testAccCheckSlbExists("alicloud_slb.ipv4", &slb),
+					resource.TestCheckResourceAttr("alicloud_slb.ipv4", "address_ip_version", "ipv4"),
+				),
+			},
+			{
+				Config:      testAccSlbIpv6Intranet,
+				ExpectError: regexp.MustCompile("only supported when 'internet' is true"),
+			},
+		},
+	})
+}
+
 func testAccCheckSlbExists(n string, slb *slb.DescribeLoadBalancerAttributeResponse) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -377,3 +458,23 @@ resource "alicloud_slb" "spec" {
   }
 }
 `
+
+const testAccSlbIpv6 = `
+resource "alicloud_slb" "ipv6" {
+  name = "tf-testAccSlbIpv6"
+  internet = true
+  address_ip_version = "ipv6"
+}
+
+resource "alicloud_slb" "ipv4" {
+  name = "tf-testAccSlbIpv6"
+  internet = true
+}
+`
+
+const testAccSlbIpv6Intranet = `
+resource "alicloud_slb" "ipv6" {
+  name = "tf-testAccSlbIpv6"
+  address_ip_version = "ipv6"
+}
+`
@@ -0,0 +1,181 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunSnapshotCreate,
+		Read:   resourceAliyunSnapshotRead,
+		Update: resourceAliyunSnapshotUpdate,
+		Delete: resourceAliyunSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"disk_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tags": tagsSchema(),
+		},
+	}
+}
+
+func resourceAliyunSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateCreateSnapshotRequest()
+	args.DiskId = d.Get("disk_id").(string)
+	args.SnapshotName = d.Get("name").(string)
+	args.Description = d.Get("description").(string)
+	args.ClientToken = buildClientToken("TF-CreateSnapshot")
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateSnapshot(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "snapshot", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.CreateSnapshotResponse)
+	if resp == nil {
+		return WrapError(fmt.Errorf("CreateSnapshot got a nil response: %#v", resp))
+	}
+
+	d.SetId(resp.SnapshotId)
+
+	if err := ecsService.WaitForSnapshot(d.Id(), SnapshotAccomplished, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunSnapshotUpdate(d, meta)
+}
+
+func resourceAliyunSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	snapshot, err := ecsService.DescribeSnapshotById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("disk_id", snapshot.SourceDiskId)
+	d.Set("name", snapshot.SnapshotName)
+	d.Set("description", snapshot.Description)
+	d.Set("status", snapshot.Status)
+
+	tags, err := ecsService.DescribeTags(d.Id(), TagResourceSnapshot)
+	if err != nil && !NotFoundError(err) {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tagsToMap(tags)))
+
+	return nil
+}
+
+func resourceAliyunSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	d.Partial(true)
+
+	if err := setTags(client, TagResourceSnapshot, d); err != nil {
+		return WrapError(err)
+	} else {
+		d.SetPartial("tags")
+	}
+
+	// The name and description have been set when creating the snapshot.
+	if !d.IsNewResource() && (d.HasChange("name") || d.HasChange("description")) {
+		args := ecs.CreateModifySnapshotAttributeRequest()
+		args.SnapshotId = d.Id()
+		args.SnapshotName = d.Get("name").(string)
+		args.Description = d.Get("description").(string)
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifySnapshotAttribute(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		d.SetPartial("name")
+		d.SetPartial("description")
+	}
+
+	d.Partial(false)
+
+	return resourceAliyunSnapshotRead(d, meta)
+}
+
+func resourceAliyunSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateDeleteSnapshotRequest()
+	args.SnapshotId = d.Id()
+	args.Force = requests.NewBoolean(d.Get("force").(bool))
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteSnapshot(args)
+		})
+		if err != nil {
+			if NotFoundError(err) || IsExceptedError(err, InvalidSnapshotIdNotFound) {
+				return nil
+			}
+			if IsExceptedError(err, IncorrectSnapshotStatus) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := ecsService.DescribeSnapshotById(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("ECS Snapshot", "Deleted")), DeleteTimeoutMsg, d.Id(), args.GetActionName(), ProviderERROR))
+	})
+}
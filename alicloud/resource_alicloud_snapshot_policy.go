@@ -0,0 +1,173 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunSnapshotPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunSnapshotPolicyCreate,
+		Read:   resourceAliyunSnapshotPolicyRead,
+		Update: resourceAliyunSnapshotPolicyUpdate,
+		Delete: resourceAliyunSnapshotPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"time_points": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateSnapshotPolicyTimePoint,
+				},
+				Set:      schema.HashString,
+				MinItems: 1,
+				MaxItems: 24,
+			},
+
+			"repeat_weekdays": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateSnapshotPolicyRepeatWeekday,
+				},
+				Set:      schema.HashString,
+				MinItems: 1,
+				MaxItems: 7,
+			},
+
+			"retention_days": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validateSnapshotPolicyRetentionDays,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAliyunSnapshotPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := ecs.CreateCreateAutoSnapshotPolicyRequest()
+	args.AutoSnapshotPolicyName = d.Get("name").(string)
+	args.TimePoints = convertListToJsonString(d.Get("time_points").(*schema.Set).List())
+	args.RepeatWeekdays = convertListToJsonString(d.Get("repeat_weekdays").(*schema.Set).List())
+	args.RetentionDays = requests.NewInteger(d.Get("retention_days").(int))
+
+	raw, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CreateAutoSnapshotPolicy(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, "snapshot_policy", args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	resp, _ := raw.(*ecs.CreateAutoSnapshotPolicyResponse)
+	if resp == nil {
+		return WrapError(fmt.Errorf("CreateAutoSnapshotPolicy got a nil response: %#v", resp))
+	}
+
+	d.SetId(resp.AutoSnapshotPolicyId)
+
+	return resourceAliyunSnapshotPolicyRead(d, meta)
+}
+
+func resourceAliyunSnapshotPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+	policy, err := ecsService.DescribeSnapshotPolicyById(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	// The time points and weekdays are returned in the format of JSON string list, such as ["0", "12"].
+	var timePoints, repeatWeekdays []string
+	if err := json.Unmarshal([]byte(policy.TimePoints), &timePoints); err != nil {
+		return WrapError(err)
+	}
+	if err := json.Unmarshal([]byte(policy.RepeatWeekdays), &repeatWeekdays); err != nil {
+		return WrapError(err)
+	}
+
+	d.Set("name", policy.AutoSnapshotPolicyName)
+	d.Set("time_points", timePoints)
+	d.Set("repeat_weekdays", repeatWeekdays)
+	d.Set("retention_days", policy.RetentionDays)
+	d.Set("status", policy.Status)
+
+	return nil
+}
+
+func resourceAliyunSnapshotPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	if d.HasChange("name") || d.HasChange("time_points") || d.HasChange("repeat_weekdays") || d.HasChange("retention_days") {
+		args := ecs.CreateModifyAutoSnapshotPolicyExRequest()
+		args.AutoSnapshotPolicyId = d.Id()
+		args.AutoSnapshotPolicyName = d.Get("name").(string)
+		args.TimePoints = convertListToJsonString(d.Get("time_points").(*schema.Set).List())
+		args.RepeatWeekdays = convertListToJsonString(d.Get("repeat_weekdays").(*schema.Set).List())
+		args.RetentionDays = requests.NewInteger(d.Get("retention_days").(int))
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ModifyAutoSnapshotPolicyEx(args)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+	}
+
+	return resourceAliyunSnapshotPolicyRead(d, meta)
+}
+
+func resourceAliyunSnapshotPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	args := ecs.CreateDeleteAutoSnapshotPolicyRequest()
+	args.AutoSnapshotPolicyId = d.Id()
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteAutoSnapshotPolicy(args)
+		})
+		if err != nil {
+			// The policy can not be deleted until it is cancelled from all of the disks.
+			if IsExceptedErrors(err, []string{"OperationConflict", "InvalidOperation.Conflict"}) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := ecsService.DescribeSnapshotPolicyById(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("ECS Snapshot Policy", "Deleted")), DeleteTimeoutMsg, d.Id(), args.GetActionName(), ProviderERROR))
+	})
+}
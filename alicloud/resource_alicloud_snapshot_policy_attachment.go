@@ -0,0 +1,118 @@
+package alicloud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunSnapshotPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunSnapshotPolicyAttachmentCreate,
+		Read:   resourceAliyunSnapshotPolicyAttachmentRead,
+		Delete: resourceAliyunSnapshotPolicyAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"disk_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceAliyunSnapshotPolicyAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+
+	args := ecs.CreateApplyAutoSnapshotPolicyRequest()
+	args.AutoSnapshotPolicyId = d.Get("policy_id").(string)
+	args.DiskIds = convertListToJsonString([]interface{}{d.Get("disk_id").(string)})
+	_, err := client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ApplyAutoSnapshotPolicy(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, args.AutoSnapshotPolicyId, args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+
+	d.SetId(args.AutoSnapshotPolicyId + COLON_SEPARATED + d.Get("disk_id").(string))
+
+	return resourceAliyunSnapshotPolicyAttachmentRead(d, meta)
+}
+
+func resourceAliyunSnapshotPolicyAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	policyId, diskId, err := getSnapshotPolicyIdAndDiskId(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	disk, err := ecsService.DescribeDiskById("", diskId)
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+	// A disk has one policy at most, and the attachment is gone once the disk has been applied to another policy.
+	if disk.AutoSnapshotPolicyId != policyId {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("policy_id", policyId)
+	d.Set("disk_id", diskId)
+	return nil
+}
+
+func resourceAliyunSnapshotPolicyAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	policyId, diskId, err := getSnapshotPolicyIdAndDiskId(d)
+	if err != nil {
+		return WrapError(err)
+	}
+	disk, err := ecsService.DescribeDiskById("", diskId)
+	if err != nil {
+		if NotFoundError(err) {
+			return nil
+		}
+		return WrapError(err)
+	}
+	if disk.AutoSnapshotPolicyId != policyId {
+		return nil
+	}
+
+	args := ecs.CreateCancelAutoSnapshotPolicyRequest()
+	args.DiskIds = convertListToJsonString([]interface{}{diskId})
+	_, err = client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.CancelAutoSnapshotPolicy(args)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), args.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	return nil
+}
+
+func getSnapshotPolicyIdAndDiskId(d *schema.ResourceData) (string, string, error) {
+	parts := strings.Split(d.Id(), COLON_SEPARATED)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("invalid resource id %s, it should be <policy_id>:<disk_id>", d.Id())
+	}
+	return parts[0], parts[1], nil
+}
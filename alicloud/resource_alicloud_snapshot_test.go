@@ -0,0 +1,263 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudSnapshot_basic(t *testing.T) {
+	var snapshot ecs.Snapshot
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_snapshot.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapshotConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapshotExists("alicloud_snapshot.foo", &snapshot),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "name", "tf-testAccSnapshotConfig"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "description", "Snapshot of the disk."),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "status", string(SnapshotAccomplished)),
+					resource.TestCheckResourceAttrSet("alicloud_snapshot.foo", "disk_id"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "name", "tf-testAccSnapshotConfig"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "time_points.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "repeat_weekdays.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "retention_days", "7"),
+					resource.TestCheckResourceAttrSet("alicloud_snapshot_policy_attachment.foo", "disk_id"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudSnapshot_update runs the CRUD of the snapshot, the automatic snapshot policy and its attachment
+// against the fake API server, and reads the snapshot back by the data source.
+func TestUnitAlicloudSnapshot_update(t *testing.T) {
+	var snapshot ecs.Snapshot
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_snapshot.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapshotConfigFake,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapshotExists("alicloud_snapshot.foo", &snapshot),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "name", "tf-testAccSnapshotConfigFake"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "status", string(SnapshotAccomplished)),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "tags.%", "1"),
+					server.CheckTags("alicloud_snapshot.foo", map[string]string{"foo": "foo"}),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "time_points.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "repeat_weekdays.#", "2"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "retention_days", "7"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "status", "Normal"),
+					testAccCheckSnapshotPolicyApplied("alicloud_snapshot_policy_attachment.foo"),
+					resource.TestCheckResourceAttr("data.alicloud_snapshots.foo", "snapshots.#", "1"),
+					resource.TestCheckResourceAttr("data.alicloud_snapshots.foo", "snapshots.0.name", "tf-testAccSnapshotConfigFake"),
+					resource.TestCheckResourceAttr("data.alicloud_snapshots.foo", "snapshots.0.source_disk_size", "20"),
+					resource.TestCheckResourceAttr("data.alicloud_snapshots.foo", "snapshots.0.tags.foo", "foo"),
+				),
+			},
+			{
+				Config: testAccSnapshotConfigFakeUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnapshotExists("alicloud_snapshot.foo", &snapshot),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "name", "tf-testAccSnapshotConfigFakeUpdate"),
+					resource.TestCheckResourceAttr("alicloud_snapshot.foo", "description", "Snapshot updated."),
+					server.CheckTags("alicloud_snapshot.foo", map[string]string{"bar": "bar"}),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "time_points.#", "1"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "repeat_weekdays.#", "7"),
+					resource.TestCheckResourceAttr("alicloud_snapshot_policy.foo", "retention_days", "-1"),
+					testAccCheckSnapshotPolicyApplied("alicloud_snapshot_policy_attachment.foo"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSnapshotExists(n string, snapshot *ecs.Snapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Snapshot ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		ecsService := EcsService{client}
+		v, err := ecsService.DescribeSnapshotById(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+
+		*snapshot = v
+		return nil
+	}
+}
+
+func testAccCheckSnapshotPolicyApplied(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		ecsService := EcsService{client}
+		disk, err := ecsService.DescribeDiskById("", rs.Primary.Attributes["disk_id"])
+		if err != nil {
+			return WrapError(err)
+		}
+		if disk.AutoSnapshotPolicyId != rs.Primary.Attributes["policy_id"] {
+			return fmt.Errorf("expected the disk %s to be applied to policy %s, got %s", disk.DiskId, rs.Primary.Attributes["policy_id"], disk.AutoSnapshotPolicyId)
+		}
+		return nil
+	}
+}
+
+func testAccCheckSnapshotDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	ecsService := EcsService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		switch rs.Type {
+		case "alicloud_snapshot":
+			snapshot, err := ecsService.DescribeSnapshotById(rs.Primary.ID)
+			if err != nil {
+				if NotFoundError(err) {
+					continue
+				}
+				return WrapError(err)
+			}
+			return WrapError(fmt.Errorf("Snapshot %s still exist", snapshot.SnapshotId))
+		case "alicloud_snapshot_policy":
+			policy, err := ecsService.DescribeSnapshotPolicyById(rs.Primary.ID)
+			if err != nil {
+				if NotFoundError(err) {
+					continue
+				}
+				return WrapError(err)
+			}
+			return WrapError(fmt.Errorf("Snapshot policy %s still exist", policy.AutoSnapshotPolicyId))
+		}
+	}
+
+	return nil
+}
+
+const testAccSnapshotConfig = `
+data "alicloud_zones" "default" {
+	available_resource_creation = "VSwitch"
+}
+
+variable "name" {
+	default = "tf-testAccSnapshotConfig"
+}
+
+resource "alicloud_disk" "foo" {
+	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+	category = "cloud_efficiency"
+	size = "20"
+	name = "${var.name}"
+}
+
+resource "alicloud_snapshot" "foo" {
+	disk_id = "${alicloud_disk.foo.id}"
+	name = "${var.name}"
+	description = "Snapshot of the disk."
+}
+
+resource "alicloud_snapshot_policy" "foo" {
+	name = "${var.name}"
+	time_points = ["0", "12"]
+	repeat_weekdays = ["1", "7"]
+	retention_days = 7
+}
+
+resource "alicloud_snapshot_policy_attachment" "foo" {
+	policy_id = "${alicloud_snapshot_policy.foo.id}"
+	disk_id = "${alicloud_disk.foo.id}"
+}
+`
+
+const testAccSnapshotConfigFake = `
+resource "alicloud_disk" "foo" {
+	availability_zone = "cn-beijing-a"
+	category = "cloud_efficiency"
+	size = "20"
+	name = "tf-testAccSnapshotConfigFake"
+}
+
+resource "alicloud_snapshot" "foo" {
+	disk_id = "${alicloud_disk.foo.id}"
+	name = "tf-testAccSnapshotConfigFake"
+	tags {
+		foo = "foo"
+	}
+}
+
+resource "alicloud_snapshot_policy" "foo" {
+	name = "tf-testAccSnapshotConfigFake"
+	time_points = ["0", "12"]
+	repeat_weekdays = ["1", "7"]
+	retention_days = 7
+}
+
+resource "alicloud_snapshot_policy_attachment" "foo" {
+	policy_id = "${alicloud_snapshot_policy.foo.id}"
+	disk_id = "${alicloud_disk.foo.id}"
+}
+
+data "alicloud_snapshots" "foo" {
+	ids = ["${alicloud_snapshot.foo.id}"]
+}
+`
+
+const testAccSnapshotConfigFakeUpdate = `
+resource "alicloud_disk" "foo" {
+	availability_zone = "cn-beijing-a"
+	category = "cloud_efficiency"
+	size = "20"
+	name = "tf-testAccSnapshotConfigFake"
+}
+
+resource "alicloud_snapshot" "foo" {
+	disk_id = "${alicloud_disk.foo.id}"
+	name = "tf-testAccSnapshotConfigFakeUpdate"
+	description = "Snapshot updated."
+	tags {
+		bar = "bar"
+	}
+}
+
+resource "alicloud_snapshot_policy" "foo" {
+	name = "tf-testAccSnapshotConfigFake"
+	time_points = ["6"]
+	repeat_weekdays = ["1", "2", "3", "4", "5", "6", "7"]
+	retention_days = -1
+}
+
+resource "alicloud_snapshot_policy_attachment" "foo" {
+	policy_id = "${alicloud_snapshot_policy.foo.id}"
+	disk_id = "${alicloud_disk.foo.id}"
+}
+`
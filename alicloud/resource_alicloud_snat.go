@@ -1,8 +1,6 @@
 package alicloud
 
 import (
-	"fmt"
-
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
@@ -25,14 +23,27 @@ func resourceAliyunSnatEntry() *schema.Resource {
 				ForceNew: true,
 			},
 			"source_vswitch_id": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_cidr"},
+			},
+			"source_cidr": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validateCIDRNetworkAddress,
+				ConflictsWith: []string{"source_vswitch_id"},
 			},
 			"snat_ip": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceName,
+			},
 		},
 	}
 }
@@ -41,31 +52,22 @@ func resourceAliyunSnatEntryCreate(d *schema.ResourceData, meta interface{}) err
 	client := meta.(*connectivity.AliyunClient)
 	vpcService := VpcService{client}
 
-	request := vpc.CreateCreateSnatEntryRequest()
-	request.RegionId = string(client.Region)
-	request.SnatTableId = d.Get("snat_table_id").(string)
-	request.SourceVSwitchId = d.Get("source_vswitch_id").(string)
-	request.SnatIp = d.Get("snat_ip").(string)
+	entry := snatEntry{SnatEntryName: d.Get("name").(string)}
+	entry.SnatTableId = d.Get("snat_table_id").(string)
+	entry.SourceVSwitchId = d.Get("source_vswitch_id").(string)
+	entry.SourceCIDR = d.Get("source_cidr").(string)
+	entry.SnatIp = d.Get("snat_ip").(string)
+	if entry.SourceVSwitchId == "" && entry.SourceCIDR == "" {
+		return WrapError(Error("One of 'source_vswitch_id' and 'source_cidr' must be set."))
+	}
 
-	if err := resource.Retry(3*time.Minute, func() *resource.RetryError {
-		ar := request
-		raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
-			return vpcClient.CreateSnatEntry(ar)
-		})
-		if err != nil {
-			if IsExceptedError(err, EIP_NOT_IN_GATEWAY) {
-				return resource.RetryableError(fmt.Errorf("CreateSnatEntry timeout and got an error: %#v.", err))
-			}
-			return resource.NonRetryableError(fmt.Errorf("CreateSnatEntry got error: %#v.", err))
-		}
-		resp, _ := raw.(*vpc.CreateSnatEntryResponse)
-		d.SetId(resp.SnatEntryId)
-		return nil
-	}); err != nil {
+	id, err := vpcService.CreateSnatEntry(entry)
+	if err != nil {
 		return err
 	}
+	d.SetId(id)
 
-	if err := vpcService.WaitForSnatEntry(request.SnatTableId, d.Id(), Available, DefaultTimeout); err != nil {
+	if err := vpcService.WaitForSnatEntry(entry.SnatTableId, d.Id(), Available, DefaultTimeout); err != nil {
 		return WrapError(err)
 	}
 
@@ -88,13 +90,20 @@ func resourceAliyunSnatEntryRead(d *schema.ResourceData, meta interface{}) error
 
 	d.Set("snat_table_id", snatEntry.SnatTableId)
 	d.Set("source_vswitch_id", snatEntry.SourceVSwitchId)
+	// The source CIDR block is also returned for the entry of a vswitch, and it is the CIDR block of the vswitch
+	if snatEntry.SourceVSwitchId == "" {
+		d.Set("source_cidr", snatEntry.SourceCIDR)
+	} else {
+		d.Set("source_cidr", "")
+	}
 	d.Set("snat_ip", snatEntry.SnatIp)
+	d.Set("name", snatEntry.SnatEntryName)
 
 	return nil
 }
 
 func resourceAliyunSnatEntryUpdate(d *schema.ResourceData, meta interface{}) error {
-	if d.HasChange("snat_ip") {
+	if d.HasChange("snat_ip") || d.HasChange("name") {
 		client := meta.(*connectivity.AliyunClient)
 		vpcService := VpcService{client}
 
@@ -103,26 +112,18 @@ func resourceAliyunSnatEntryUpdate(d *schema.ResourceData, meta interface{}) err
 			return WrapError(err)
 		}
 
-		request := vpc.CreateModifySnatEntryRequest()
-		request.RegionId = string(client.Region)
-		request.SnatTableId = snatEntry.SnatTableId
-		request.SnatEntryId = snatEntry.SnatEntryId
-
-		var snat_ip string
 		if v, ok := d.GetOk("snat_ip"); ok {
-			snat_ip = v.(string)
+			snatEntry.SnatIp = v.(string)
 		} else {
 			return WrapError(Error("cann't change snap_ip to empty string"))
 		}
-		request.SnatIp = snat_ip
+		snatEntry.SnatEntryName = d.Get("name").(string)
 
-		if _, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
-			return vpcClient.ModifySnatEntry(request)
-		}); err != nil {
-			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
+		if err := vpcService.ModifySnatEntry(snatEntry); err != nil {
+			return err
 		}
 
-		if err := vpcService.WaitForSnatEntry(request.SnatTableId, d.Id(), Available, DefaultTimeout); err != nil {
+		if err := vpcService.WaitForSnatEntry(snatEntry.SnatTableId, d.Id(), Available, DefaultTimeout); err != nil {
 			return WrapError(err)
 		}
 	}
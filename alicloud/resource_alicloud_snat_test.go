@@ -11,7 +11,7 @@ import (
 )
 
 func TestAccAlicloudSnat_basic(t *testing.T) {
-	var snat vpc.SnatTableEntry
+	var snat snatEntry
 	var nat vpc.NatGateway
 	var eip vpc.EipAddress
 
@@ -47,7 +47,7 @@ func TestAccAlicloudSnat_basic(t *testing.T) {
 }
 
 func TestAccAlicloudSnat_multi(t *testing.T) {
-	var snat vpc.SnatTableEntry
+	var snat snatEntry
 	var nat vpc.NatGateway
 	var eip vpc.EipAddress
 
@@ -74,6 +74,45 @@ func TestAccAlicloudSnat_multi(t *testing.T) {
 
 }
 
+// TestUnitAlicloudSnat_update runs the SNAT entries of a vswitch and a CIDR block against the fake API server, which
+// does not serve EIPs, so the SNAT IP is given directly.
+func TestUnitAlicloudSnat_update(t *testing.T) {
+	var snat snatEntry
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_snat_entry.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckSnatEntryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnatEntryUnitConfig("tf-testAccSnatEntry", "47.0.0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnatEntryExists("alicloud_snat_entry.foo", &snat),
+					resource.TestCheckResourceAttrPair("alicloud_snat_entry.foo", "source_vswitch_id", "alicloud_vswitch.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo", "source_cidr", ""),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo", "snat_ip", "47.0.0.1"),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo", "name", "tf-testAccSnatEntry"),
+					testAccCheckSnatEntryExists("alicloud_snat_entry.cidr", &snat),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.cidr", "source_vswitch_id", ""),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.cidr", "source_cidr", "172.16.8.0/24"),
+				),
+			},
+			{
+				Config: testAccSnatEntryUnitConfig("tf-testAccSnatEntry-update", "47.0.0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSnatEntryExists("alicloud_snat_entry.foo", &snat),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo", "snat_ip", "47.0.0.2"),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.foo", "name", "tf-testAccSnatEntry-update"),
+					testAccCheckSnatEntryExists("alicloud_snat_entry.cidr", &snat),
+					resource.TestCheckResourceAttr("alicloud_snat_entry.cidr", "snat_ip", "47.0.0.2"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSnatEntryDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*connectivity.AliyunClient)
 	vpcService := VpcService{client}
@@ -102,7 +141,7 @@ func testAccCheckSnatEntryDestroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckSnatEntryExists(n string, snat *vpc.SnatTableEntry) resource.TestCheckFunc {
+func testAccCheckSnatEntryExists(n string, snat *snatEntry) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -264,3 +303,41 @@ resource "alicloud_snat_entry" "foo"{
 	snat_ip = "${alicloud_eip.foo.ip_address}"
 }
 `
+
+func testAccSnatEntryUnitConfig(name, snatIp string) string {
+	return fmt.Sprintf(`
+data "alicloud_zones" "default" {
+	"available_resource_creation"= "VSwitch"
+}
+
+resource "alicloud_vpc" "foo" {
+	name = "tf-testAccSnatEntryConfig"
+	cidr_block = "172.16.0.0/12"
+}
+
+resource "alicloud_vswitch" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	cidr_block = "172.16.0.0/21"
+	availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+}
+
+resource "alicloud_nat_gateway" "foo" {
+	vpc_id = "${alicloud_vpc.foo.id}"
+	specification = "Small"
+	name = "tf-testAccSnatEntryConfig"
+}
+
+resource "alicloud_snat_entry" "foo"{
+	snat_table_id = "${alicloud_nat_gateway.foo.snat_table_ids}"
+	source_vswitch_id = "${alicloud_vswitch.foo.id}"
+	snat_ip = "%s"
+	name = "%s"
+}
+
+resource "alicloud_snat_entry" "cidr"{
+	snat_table_id = "${alicloud_nat_gateway.foo.snat_table_ids}"
+	source_cidr = "172.16.8.0/24"
+	snat_ip = "%s"
+}
+`, snatIp, name, snatIp)
+}
@@ -2,6 +2,7 @@ package alicloud
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -62,6 +63,16 @@ func resourceAliyunVpc() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"enable_ipv6": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ipv6_cidr_block": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -101,6 +112,11 @@ func resourceAliyunVpcCreate(d *schema.ResourceData, meta interface{}) error {
 		return WrapError(err)
 	}
 
+	tagService := TagService{client}
+	if err := tagService.SetResourceTags(d, connectivity.VPCCode, TagResourceVpc); err != nil {
+		return WrapError(err)
+	}
+
 	return resourceAliyunVpcRead(d, meta)
 }
 
@@ -121,24 +137,22 @@ func resourceAliyunVpcRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", resp.VpcName)
 	d.Set("description", resp.Description)
 	d.Set("router_id", resp.VRouterId)
+	ipv6CidrBlock, err := parseIpv6CidrBlock(&resp)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("ipv6_cidr_block", ipv6CidrBlock)
+	d.Set("enable_ipv6", ipv6CidrBlock != "")
 	request := vpc.CreateDescribeVRoutersRequest()
 	request.RegionId = client.RegionId
 	request.VRouterId = resp.VRouterId
-	var response vpc.DescribeVRoutersResponse
-	if err := resource.Retry(6*time.Minute, func() *resource.RetryError {
-		raw, e := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
-			return vpcClient.DescribeVRouters(request)
-		})
-		if e != nil && IsExceptedErrors(e, []string{Throttling}) {
-			time.Sleep(10 * time.Second)
-			return resource.RetryableError(e)
-		}
-		r, _ := raw.(*vpc.DescribeVRoutersResponse)
-		response = *r
-		return resource.NonRetryableError(e)
-	}); err != nil {
+	raw, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeVRouters(request)
+	})
+	if err != nil {
 		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 	}
+	response, _ := raw.(*vpc.DescribeVRoutersResponse)
 	if len(response.VRouters.VRouter) > 0 && len(response.VRouters.VRouter[0].RouteTableIds.RouteTableId) > 0 {
 		d.Set("router_table_id", response.VRouters.VRouter[0].RouteTableIds.RouteTableId[0])
 		d.Set("route_table_id", response.VRouters.VRouter[0].RouteTableIds.RouteTableId[0])
@@ -147,11 +161,23 @@ func resourceAliyunVpcRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("route_table_id", "")
 	}
 
+	tagService := TagService{client}
+	tags, err := tagService.DescribeTags(connectivity.VPCCode, TagResourceVpc, d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tags))
+
 	return nil
 }
 
 func resourceAliyunVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*connectivity.AliyunClient)
+	tagService := TagService{client}
+
+	if err := tagService.SetResourceTags(d, connectivity.VPCCode, TagResourceVpc); err != nil {
+		return WrapError(err)
+	}
 
 	attributeUpdate := false
 	request := vpc.CreateModifyVpcAttributeRequest()
@@ -167,6 +193,12 @@ func resourceAliyunVpcUpdate(d *schema.ResourceData, meta interface{}) error {
 		attributeUpdate = true
 	}
 
+	if d.HasChange("enable_ipv6") {
+		// The parameter is not supported by the vendored SDK yet
+		request.QueryParams["EnableIPv6"] = strconv.FormatBool(d.Get("enable_ipv6").(bool))
+		attributeUpdate = true
+	}
+
 	if attributeUpdate {
 		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
 			return vpcClient.ModifyVpcAttribute(request)
@@ -220,6 +252,10 @@ func buildAliyunVpcArgs(d *schema.ResourceData, meta interface{}) *vpc.CreateVpc
 	if v := d.Get("description").(string); v != "" {
 		request.Description = v
 	}
+	if d.Get("enable_ipv6").(bool) {
+		// The parameter is not supported by the vendored SDK yet
+		request.QueryParams["EnableIpv6"] = "true"
+	}
 	request.ClientToken = buildClientToken("TF-CreateVpc")
 
 	return request
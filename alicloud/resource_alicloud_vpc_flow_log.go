@@ -0,0 +1,220 @@
+package alicloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func resourceAliyunVpcFlowLog() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAliyunVpcFlowLogCreate,
+		Read:   resourceAliyunVpcFlowLogRead,
+		Update: resourceAliyunVpcFlowLogUpdate,
+		Delete: resourceAliyunVpcFlowLogDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(FlowLogVpc), string(FlowLogVSwitch), string(FlowLogNetworkInterface)}),
+			},
+
+			"resource_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"traffic_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateAllowedStringValue([]string{string(FlowLogTrafficAll), string(FlowLogTrafficAllow), string(FlowLogTrafficDrop)}),
+			},
+
+			"project_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"log_store_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceName,
+			},
+
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateInstanceDescription,
+			},
+
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      Active,
+				ValidateFunc: validateAllowedStringValue([]string{string(Active), string(Inactive)}),
+			},
+		},
+	}
+}
+
+func resourceAliyunVpcFlowLogCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateCreateFlowLogRequest()
+	request.RegionId = client.RegionId
+	request.ResourceType = d.Get("resource_type").(string)
+	request.ResourceId = d.Get("resource_id").(string)
+	request.TrafficType = d.Get("traffic_type").(string)
+	request.ProjectName = d.Get("project_name").(string)
+	request.LogStoreName = d.Get("log_store_name").(string)
+	if v, ok := d.GetOk("name"); ok {
+		request.FlowLogName = v.(string)
+	}
+	if v, ok := d.GetOk("description"); ok {
+		request.Description = v.(string)
+	}
+
+	raw, err := vpcService.ProcessFlowLogRequest(request.ResourceId, func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.CreateFlowLog(request)
+	}, request.GetActionName())
+	if err != nil {
+		return err
+	}
+	response, _ := raw.(*vpc.CreateFlowLogResponse)
+	if response.FlowLogId == "" {
+		return WrapError(fmt.Errorf("CreateFlowLog got an empty flow log ID: %s", response.GetHttpContentString()))
+	}
+	d.SetId(response.FlowLogId)
+
+	if err := vpcService.WaitForFlowLog(d.Id(), Active, DefaultTimeout); err != nil {
+		return WrapError(err)
+	}
+
+	return resourceAliyunVpcFlowLogUpdate(d, meta)
+}
+
+func resourceAliyunVpcFlowLogRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	flowLog, err := vpcService.DescribeFlowLog(d.Id())
+	if err != nil {
+		if NotFoundError(err) {
+			d.SetId("")
+			return nil
+		}
+		return WrapError(err)
+	}
+
+	d.Set("resource_type", flowLog.ResourceType)
+	d.Set("resource_id", flowLog.ResourceId)
+	d.Set("traffic_type", flowLog.TrafficType)
+	d.Set("project_name", flowLog.ProjectName)
+	d.Set("log_store_name", flowLog.LogStoreName)
+	d.Set("name", flowLog.FlowLogName)
+	d.Set("description", flowLog.Descripthon)
+	d.Set("status", flowLog.Status)
+
+	return nil
+}
+
+func resourceAliyunVpcFlowLogUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	d.Partial(true)
+
+	if !d.IsNewResource() && (d.HasChange("name") || d.HasChange("description")) {
+		request := vpc.CreateModifyFlowLogAttributeRequest()
+		request.RegionId = client.RegionId
+		request.FlowLogId = d.Id()
+		request.FlowLogName = d.Get("name").(string)
+		request.Description = d.Get("description").(string)
+		if _, err := vpcService.ProcessFlowLogRequest(d.Id(), func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ModifyFlowLogAttribute(request)
+		}, request.GetActionName()); err != nil {
+			return err
+		}
+		d.SetPartial("name")
+		d.SetPartial("description")
+	}
+
+	// The flow log is already active when it is created
+	if status := Status(d.Get("status").(string)); d.HasChange("status") && !(d.IsNewResource() && status == Active) {
+		var err error
+		if status == Active {
+			request := vpc.CreateActiveFlowLogRequest()
+			request.RegionId = client.RegionId
+			request.FlowLogId = d.Id()
+			_, err = vpcService.ProcessFlowLogRequest(d.Id(), func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.ActiveFlowLog(request)
+			}, request.GetActionName())
+		} else {
+			request := vpc.CreateDeactiveFlowLogRequest()
+			request.RegionId = client.RegionId
+			request.FlowLogId = d.Id()
+			_, err = vpcService.ProcessFlowLogRequest(d.Id(), func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.DeactiveFlowLog(request)
+			}, request.GetActionName())
+		}
+		if err != nil {
+			return err
+		}
+		if err := vpcService.WaitForFlowLog(d.Id(), status, DefaultTimeout); err != nil {
+			return WrapError(err)
+		}
+		d.SetPartial("status")
+	}
+
+	d.Partial(false)
+
+	return resourceAliyunVpcFlowLogRead(d, meta)
+}
+
+func resourceAliyunVpcFlowLogDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	request := vpc.CreateDeleteFlowLogRequest()
+	request.RegionId = client.RegionId
+	request.FlowLogId = d.Id()
+
+	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.DeleteFlowLog(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, FlowLogInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+
+		if _, err := vpcService.DescribeFlowLog(d.Id()); err != nil {
+			if NotFoundError(err) {
+				return nil
+			}
+			return resource.NonRetryableError(WrapError(err))
+		}
+		return resource.RetryableError(WrapErrorf(Error(GetTimeoutMessage("Flow Log", "Deleted")), DeleteTimeoutMsg, d.Id(), request.GetActionName(), ProviderERROR))
+	})
+}
@@ -0,0 +1,187 @@
+package alicloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+func TestAccAlicloudVpcFlowLog_basic(t *testing.T) {
+	var flowLog vpc.FlowLog
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+
+		// module name
+		IDRefreshName: "alicloud_vpc_flow_log.foo",
+
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcFlowLogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcFlowLogConfig("tf-testAccVpcFlowLogConfig", "Active", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcFlowLogExists("alicloud_vpc_flow_log.foo", &flowLog),
+					resource.TestCheckResourceAttrPair("alicloud_vpc_flow_log.foo", "resource_id", "alicloud_vswitch.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "resource_type", "VSwitch"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "traffic_type", "All"),
+					resource.TestCheckResourceAttrPair("alicloud_vpc_flow_log.foo", "project_name", "alicloud_log_project.foo", "name"),
+					resource.TestCheckResourceAttrPair("alicloud_vpc_flow_log.foo", "log_store_name", "alicloud_log_store.foo", "name"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "name", "tf-testAccVpcFlowLogConfig"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "status", "Active"),
+				),
+			},
+			{
+				Config: testAccVpcFlowLogConfig("tf-testAccVpcFlowLogConfig-update", "Inactive", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcFlowLogExists("alicloud_vpc_flow_log.foo", &flowLog),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "name", "tf-testAccVpcFlowLogConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "status", "Inactive"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVpcFlowLog_update runs the CRUD of the flow log against the fake API server, which reports the flow
+// log Activating after it is created, activated or deactivated so that the waiters are run. The fake API server does not
+// serve Log Service, so the project and the logstore are not created.
+func TestUnitAlicloudVpcFlowLog_update(t *testing.T) {
+	var flowLog vpc.FlowLog
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_vpc_flow_log.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcFlowLogDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcFlowLogConfig("tf-testAccVpcFlowLogConfig", "Inactive", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcFlowLogExists("alicloud_vpc_flow_log.foo", &flowLog),
+					resource.TestCheckResourceAttrPair("alicloud_vpc_flow_log.foo", "resource_id", "alicloud_vswitch.foo", "id"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "project_name", "tf-testaccvpcflowlog"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "log_store_name", "tf-testaccvpcflowlog"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "description", "The flow log of the vswitch"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "status", "Inactive"),
+				),
+			},
+			{
+				Config: testAccVpcFlowLogConfig("tf-testAccVpcFlowLogConfig-update", "Active", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcFlowLogExists("alicloud_vpc_flow_log.foo", &flowLog),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "name", "tf-testAccVpcFlowLogConfig-update"),
+					resource.TestCheckResourceAttr("alicloud_vpc_flow_log.foo", "status", "Active"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckVpcFlowLogExists(n string, flowLog *vpc.FlowLog) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Flow Log ID is set")
+		}
+
+		client := testAccProvider.Meta().(*connectivity.AliyunClient)
+		vpcService := VpcService{client}
+		v, err := vpcService.DescribeFlowLog(rs.Primary.ID)
+		if err != nil {
+			return WrapError(err)
+		}
+
+		*flowLog = v
+		return nil
+	}
+}
+
+func testAccCheckVpcFlowLogDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*connectivity.AliyunClient)
+	vpcService := VpcService{client}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "alicloud_vpc_flow_log" {
+			continue
+		}
+
+		flowLog, err := vpcService.DescribeFlowLog(rs.Primary.ID)
+		if err != nil {
+			if NotFoundError(err) {
+				continue
+			}
+			return WrapError(err)
+		}
+		return WrapError(fmt.Errorf("Flow Log %s still exist", flowLog.FlowLogId))
+	}
+
+	return nil
+}
+
+// testAccVpcFlowLogConfig returns the flow log of a vswitch. The project and the logstore are created along with it when
+// withLogStore is true, otherwise their names are given directly.
+func testAccVpcFlowLogConfig(name, status string, withLogStore bool) string {
+	logStore := `
+	locals {
+		project_name = "tf-testaccvpcflowlog"
+		log_store_name = "tf-testaccvpcflowlog"
+	}
+	`
+	if withLogStore {
+		logStore = `
+	resource "alicloud_log_project" "foo" {
+		name = "tf-testaccvpcflowlog"
+		description = "tf unit test"
+	}
+
+	resource "alicloud_log_store" "foo" {
+		project = "${alicloud_log_project.foo.name}"
+		name = "tf-testaccvpcflowlog"
+	}
+
+	locals {
+		project_name = "${alicloud_log_project.foo.name}"
+		log_store_name = "${alicloud_log_store.foo.name}"
+	}
+	`
+	}
+	return fmt.Sprintf(`
+	data "alicloud_zones" "default" {
+		"available_resource_creation"= "VSwitch"
+	}
+
+	resource "alicloud_vpc" "foo" {
+		name = "tf-testAccVpcFlowLogConfig"
+		cidr_block = "172.16.0.0/12"
+	}
+
+	resource "alicloud_vswitch" "foo" {
+		vpc_id = "${alicloud_vpc.foo.id}"
+		cidr_block = "172.16.0.0/21"
+		availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+	}
+	%s
+	resource "alicloud_vpc_flow_log" "foo" {
+		resource_type = "VSwitch"
+		resource_id = "${alicloud_vswitch.foo.id}"
+		traffic_type = "All"
+		project_name = "${local.project_name}"
+		log_store_name = "${local.log_store_name}"
+		name = "%s"
+		description = "The flow log of the vswitch"
+		status = "%s"
+	}
+	`, logStore, name, status)
+}
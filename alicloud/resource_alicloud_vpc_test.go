@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -193,6 +194,136 @@ func TestAccAlicloudVpc_update(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudVpc_update runs the CRUD of the VPC against the fake API server.
+func TestUnitAlicloudVpc_update(t *testing.T) {
+	var vpc vpc.DescribeVpcAttributeResponse
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "cidr_block", "172.16.0.0/12"),
+					resource.TestCheckResourceAttrSet("alicloud_vpc.foo", "router_id"),
+					resource.TestCheckResourceAttrSet("alicloud_vpc.foo", "route_table_id"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "name", "tf-testAccVpcConfig"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "description", ""),
+				),
+			},
+			{
+				Config: testAccVpcConfigUpdateNameAndDesc,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "name", "tf_testAccVpcConfigUpdateNameAndDesc"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "description", "who am i"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVpc_tags runs the tagging of the VPC against the fake API server.
+func TestUnitAlicloudVpc_tags(t *testing.T) {
+	var vpc vpc.DescribeVpcAttributeResponse
+	server, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_vpc.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcConfigTags,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.%", "2"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.foo", "foo"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.bar", "bar"),
+					server.CheckTags("alicloud_vpc.foo", map[string]string{"foo": "foo", "bar": "bar"}),
+				),
+			},
+			{
+				Config: testAccVpcConfigTagsUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.%", "2"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.foo", "foo-update"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "tags.baz", "baz"),
+					server.CheckTags("alicloud_vpc.foo", map[string]string{"foo": "foo-update", "baz": "baz"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlicloudVpc_ipv6(t *testing.T) {
+	var vpc vpc.DescribeVpcAttributeResponse
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "alicloud_vpc.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcConfigIpv6(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "enable_ipv6", "true"),
+					resource.TestCheckResourceAttrSet("alicloud_vpc.foo", "ipv6_cidr_block"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVpc_ipv6 runs enabling and disabling IPv6 on the VPC against the fake API server.
+func TestUnitAlicloudVpc_ipv6(t *testing.T) {
+	var vpc vpc.DescribeVpcAttributeResponse
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_vpc.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVpcDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVpcConfigIpv6(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "enable_ipv6", "false"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "ipv6_cidr_block", ""),
+				),
+			},
+			{
+				Config: testAccVpcConfigIpv6(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "enable_ipv6", "true"),
+					resource.TestMatchResourceAttr("alicloud_vpc.foo", "ipv6_cidr_block", regexp.MustCompile(`^2408:4000:[0-9a-f]+::/56$`)),
+				),
+			},
+			{
+				Config: testAccVpcConfigIpv6(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVpcExists("alicloud_vpc.foo", &vpc),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "enable_ipv6", "false"),
+					resource.TestCheckResourceAttr("alicloud_vpc.foo", "ipv6_cidr_block", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAlicloudVpc_multi(t *testing.T) {
 	var vpc vpc.DescribeVpcAttributeResponse
 
@@ -276,6 +407,16 @@ resource "alicloud_vpc" "foo" {
 }
 `
 
+func testAccVpcConfigIpv6(enabled bool) string {
+	return fmt.Sprintf(`
+resource "alicloud_vpc" "foo" {
+        name = "tf-testAccVpcConfigIpv6"
+        cidr_block = "172.16.0.0/12"
+        enable_ipv6 = %t
+}
+`, enabled)
+}
+
 const testAccVpcConfigUpdateName = `
 resource "alicloud_vpc" "foo" {
 	cidr_block = "172.16.0.0/12"
@@ -313,3 +454,25 @@ resource "alicloud_vpc" "bar_3" {
 	name = "${var.name}-3"
 }
 `
+
+const testAccVpcConfigTags = `
+resource "alicloud_vpc" "foo" {
+	cidr_block = "172.16.0.0/12"
+	name = "tf-testAccVpcConfigTags"
+	tags {
+		foo = "foo"
+		bar = "bar"
+	}
+}
+`
+
+const testAccVpcConfigTagsUpdate = `
+resource "alicloud_vpc" "foo" {
+	cidr_block = "172.16.0.0/12"
+	name = "tf-testAccVpcConfigTags"
+	tags {
+		foo = "foo-update"
+		baz = "baz"
+	}
+}
+`
@@ -20,6 +20,11 @@ func resourceAliyunVpnGateway() *schema.Resource {
 		Update: resourceAliyunVpnGatewayUpdate,
 		Delete: resourceAliyunVpnGatewayDelete,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * DefaultTimeout * time.Second),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -146,7 +151,7 @@ func resourceAliyunVpnGatewayCreate(d *schema.ResourceData, meta interface{}) er
 	d.SetId(vpn.VpnGatewayId)
 
 	time.Sleep(10 * time.Second)
-	if err := vpnGatewayService.WaitForVpn(vpn.VpnGatewayId, Active, 2*DefaultTimeout); err != nil {
+	if err := vpnGatewayService.WaitForVpn(vpn.VpnGatewayId, Active, int(d.Timeout(schema.TimeoutCreate).Seconds())); err != nil {
 		return fmt.Errorf("WaitVpnGateway %s got error: %#v, %s", Active, err, vpn.VpnGatewayId)
 	}
 
@@ -253,7 +258,7 @@ func resourceAliyunVpnGatewayDelete(d *schema.ResourceData, meta interface{}) er
 
 	req := vpc.CreateDeleteVpnGatewayRequest()
 	req.VpnGatewayId = d.Id()
-	return resource.Retry(5*time.Minute, func() *resource.RetryError {
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
 		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
 			return vpcClient.DeleteVpnGateway(req)
 		})
@@ -2,6 +2,8 @@ package alicloud
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
@@ -45,6 +47,22 @@ func resourceAliyunSubnet() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"enable_ipv6": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ipv6_cidr_block_mask": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validateIntegerInRange(0, 255),
+			},
+			"ipv6_cidr_block": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"tags": tagsSchema(),
 		},
 	}
 }
@@ -106,6 +124,26 @@ func resourceAliyunSwitchRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("cidr_block", vswitch.CidrBlock)
 	d.Set("name", vswitch.VSwitchName)
 	d.Set("description", vswitch.Description)
+	ipv6CidrBlock, err := parseIpv6CidrBlock(&vswitch)
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("ipv6_cidr_block", ipv6CidrBlock)
+	d.Set("enable_ipv6", ipv6CidrBlock != "")
+	if ipv6CidrBlock != "" {
+		mask, err := ipv6CidrBlockMask(ipv6CidrBlock)
+		if err != nil {
+			return WrapError(err)
+		}
+		d.Set("ipv6_cidr_block_mask", mask)
+	}
+
+	tagService := TagService{client}
+	tags, err := tagService.DescribeTags(connectivity.VPCCode, TagResourceVSwitch, d.Id())
+	if err != nil {
+		return WrapError(err)
+	}
+	d.Set("tags", providerTags(client, d, tags))
 
 	return nil
 }
@@ -115,6 +153,11 @@ func resourceAliyunSwitchUpdate(d *schema.ResourceData, meta interface{}) error
 
 	d.Partial(true)
 
+	tagService := TagService{client}
+	if err := tagService.SetResourceTags(d, connectivity.VPCCode, TagResourceVSwitch); err != nil {
+		return WrapError(err)
+	}
+
 	attributeUpdate := false
 	request := vpc.CreateModifyVSwitchAttributeRequest()
 	request.VSwitchId = d.Id()
@@ -132,6 +175,19 @@ func resourceAliyunSwitchUpdate(d *schema.ResourceData, meta interface{}) error
 
 		attributeUpdate = true
 	}
+
+	enableIpv6 := d.Get("enable_ipv6").(bool)
+	if !d.IsNewResource() && (d.HasChange("enable_ipv6") || (enableIpv6 && d.HasChange("ipv6_cidr_block_mask"))) {
+		d.SetPartial("enable_ipv6")
+		d.SetPartial("ipv6_cidr_block_mask")
+		// The parameters are not supported by the vendored SDK yet
+		request.QueryParams["EnableIPv6"] = strconv.FormatBool(enableIpv6)
+		if enableIpv6 {
+			request.QueryParams["Ipv6CidrBlock"] = strconv.Itoa(d.Get("ipv6_cidr_block_mask").(int))
+		}
+
+		attributeUpdate = true
+	}
 	if attributeUpdate {
 		_, err := client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
 			return vpcClient.ModifyVSwitchAttribute(request)
@@ -207,7 +263,21 @@ func buildAliyunSwitchArgs(d *schema.ResourceData, meta interface{}) (*vpc.Creat
 	if v, ok := d.GetOk("description"); ok && v != "" {
 		request.Description = v.(string)
 	}
+	if d.Get("enable_ipv6").(bool) {
+		// The parameter is not supported by the vendored SDK yet
+		request.QueryParams["Ipv6CidrBlock"] = strconv.Itoa(d.Get("ipv6_cidr_block_mask").(int))
+	}
 	request.ClientToken = buildClientToken("TF-CreateVSwitch")
 
 	return request, nil
 }
+
+// ipv6CidrBlockMask returns the mask of the IPv6 CIDR block of the vswitch, which is the 8 bits following the /56
+// IPv6 CIDR block of the VPC.
+func ipv6CidrBlockMask(cidrBlock string) (int, error) {
+	ip, _, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return 0, WrapError(err)
+	}
+	return int(ip.To16()[7]), nil
+}
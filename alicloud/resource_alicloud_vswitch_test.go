@@ -3,6 +3,7 @@ package alicloud
 import (
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -167,6 +168,104 @@ func TestAccAlicloudVSwitch_Update(t *testing.T) {
 	})
 }
 
+// TestUnitAlicloudVSwitch_update runs the CRUD of the VSwitch against the fake API server.
+func TestUnitAlicloudVSwitch_update(t *testing.T) {
+	var vsw vpc.DescribeVSwitchAttributesResponse
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_vswitch.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVswitchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVswitchConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVswitchExists("alicloud_vswitch.foo", &vsw),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "cidr_block", "172.16.0.0/21"),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "availability_zone", fakeApiZones[0]),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "name", "tf-testAccVswitchConfig"),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "description", ""),
+				),
+			},
+			{
+				Config: testAccVswitchConfigUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVswitchExists("alicloud_vswitch.foo", &vsw),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "name", "tf-testAccVswitchConfigUpdate"),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "description", "How Are You"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAlicloudVSwitch_ipv6(t *testing.T) {
+	var vsw vpc.DescribeVSwitchAttributesResponse
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		IDRefreshName: "alicloud_vswitch.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVswitchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVswitchConfigIpv6(true, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVswitchExists("alicloud_vswitch.foo", &vsw),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "enable_ipv6", "true"),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "ipv6_cidr_block_mask", "1"),
+					resource.TestCheckResourceAttrSet("alicloud_vswitch.foo", "ipv6_cidr_block"),
+				),
+			},
+		},
+	})
+}
+
+// TestUnitAlicloudVSwitch_ipv6 runs enabling, changing and disabling IPv6 on the VSwitch against the fake API server,
+// and the IPv6 CIDR block of the VSwitch is allocated from the one of the VPC by the mask.
+func TestUnitAlicloudVSwitch_ipv6(t *testing.T) {
+	var vsw vpc.DescribeVSwitchAttributesResponse
+	_, teardown := testAccFakeApi(t)
+	defer teardown()
+
+	resource.UnitTest(t, resource.TestCase{
+		IDRefreshName: "alicloud_vswitch.foo",
+		Providers:     testAccProviders,
+		CheckDestroy:  testAccCheckVswitchDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVswitchConfigIpv6(true, 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVswitchExists("alicloud_vswitch.foo", &vsw),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "enable_ipv6", "true"),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "ipv6_cidr_block_mask", "0"),
+					resource.TestMatchResourceAttr("alicloud_vswitch.foo", "ipv6_cidr_block", regexp.MustCompile(`^2408:4000:[0-9a-f]+:0::/64$`)),
+				),
+			},
+			{
+				Config: testAccVswitchConfigIpv6(true, 18),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVswitchExists("alicloud_vswitch.foo", &vsw),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "ipv6_cidr_block_mask", "18"),
+					resource.TestMatchResourceAttr("alicloud_vswitch.foo", "ipv6_cidr_block", regexp.MustCompile(`^2408:4000:[0-9a-f]+:12::/64$`)),
+				),
+			},
+			{
+				Config: testAccVswitchConfigIpv6(false, 18),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVswitchExists("alicloud_vswitch.foo", &vsw),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "enable_ipv6", "false"),
+					resource.TestCheckResourceAttr("alicloud_vswitch.foo", "ipv6_cidr_block", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAlicloudVSwitch_multi(t *testing.T) {
 	var vsw vpc.DescribeVSwitchAttributesResponse
 
@@ -261,6 +360,32 @@ resource "alicloud_vswitch" "foo" {
   name = "${var.name}"
 }
 `
+
+func testAccVswitchConfigIpv6(enabled bool, mask int) string {
+	return fmt.Sprintf(`
+data "alicloud_zones" "default" {
+	"available_resource_creation"= "VSwitch"
+}
+variable "name" {
+  default = "tf-testAccVswitchConfigIpv6"
+}
+resource "alicloud_vpc" "foo" {
+  name = "${var.name}"
+  cidr_block = "172.16.0.0/12"
+  enable_ipv6 = true
+}
+
+resource "alicloud_vswitch" "foo" {
+  vpc_id = "${alicloud_vpc.foo.id}"
+  cidr_block = "172.16.0.0/21"
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  name = "${var.name}"
+  enable_ipv6 = %t
+  ipv6_cidr_block_mask = %d
+}
+`, enabled, mask)
+}
+
 const testAccVswitchConfigRename = `
 data "alicloud_zones" "default" {
 	"available_resource_creation"= "VSwitch"
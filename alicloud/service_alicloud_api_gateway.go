@@ -193,7 +193,7 @@ func (s *CloudApiService) WaitForAppAttachmentAuthorization(id string, timeout i
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("Authorization", AuthorizationDone))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return err
@@ -70,7 +70,7 @@ func (s *CenService) WaitForCenInstance(cenId string, status Status, timeout int
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("CEN", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -120,7 +120,7 @@ func (s *CenService) WaitForCenChildInstanceAttached(instanceId string, cenId st
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("CEN Child Instance Attachment", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -145,7 +145,7 @@ func (s *CenService) WaitForCenChildInstanceDetached(instanceId string, cenId st
 		if timeout <= 0 {
 			return GetTimeErrorFromString(fmt.Sprintf("Waitting for %s detach timeout.", instanceId))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -200,7 +200,7 @@ func (s *CenService) WaitForCenBandwidthPackage(cenBwpId string, status Status,
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("CEN Bandwidth Package", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -224,7 +224,7 @@ func (s *CenService) WaitForCenBandwidthPackageUpdate(cenBwpId string, bandwidth
 		if timeout <= 0 {
 			return GetTimeErrorFromString(fmt.Sprintf("Waitting for CEN bandwidth package update is timeout"))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -247,7 +247,7 @@ func (s *CenService) WaitForCenBandwidthPackageAttachment(cenBwpId string, statu
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("CEN Bandwidth Package Attachment", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -331,7 +331,7 @@ func (s *CenService) WaitForCenInterRegionBandwidthLimitActive(cenId string, loc
 		if timeout <= 0 {
 			return GetTimeErrorFromString(fmt.Sprintf("Waitting for bandwidth limit CenId %s localRegionId %s oppositeRegionId %s timeout.", cenId, localRegionId, oppositeRegionId))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -355,7 +355,7 @@ func (s *CenService) WaitForCenInterRegionBandwidthLimitDestroy(cenId string, lo
 		if timeout <= 0 {
 			return GetTimeErrorFromString(fmt.Sprintf("Waitting for bandwidth limit CenId %s localRegionId %s oppositeRegionId %s timeout.", cenId, localRegionId, oppositeRegionId))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -445,7 +445,7 @@ func (s *CenService) WaitForRouterEntryPublished(id string, status Status, timeo
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("CEN RouteEntries", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
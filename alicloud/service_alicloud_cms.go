@@ -65,7 +65,7 @@ func (s *CmsService) WaitForCmsAlarm(id string, enabled bool, timeout int) error
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("Alarm", strconv.FormatBool(enabled)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -50,7 +50,7 @@ func (s *CommonBandwidthPackageService) WaitForCommonBandwidthPackage(commonBand
 		timeout = DefaultTimeout
 	}
 	for {
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 		resp, err := s.DescribeCommonBandwidthPackage(commonBandwidthPackageId)
 
 		if err != nil {
@@ -84,7 +84,7 @@ func (s *CommonBandwidthPackageService) WaitForCommonBandwidthPackageAttachment(
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("Common Bandwidth Package Attachment", string("Unavailable")))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -52,6 +52,46 @@ resource "alicloud_security_group_rule" "default" {
 }
 `
 
+// EcsInstanceIpv6CommonTestCase is the same as EcsInstanceCommonTestCase, and IPv6 is enabled on the VPC and the vswitch.
+const EcsInstanceIpv6CommonTestCase = `
+data "alicloud_zones" "default" {
+  available_disk_category     = "cloud_efficiency"
+  available_resource_creation = "VSwitch"
+}
+
+data "alicloud_instance_types" "default" {
+  availability_zone = "${data.alicloud_zones.default.zones.0.id}"
+  cpu_core_count    = 2
+  memory_size       = 4
+}
+
+data "alicloud_images" "default" {
+  name_regex  = "^ubuntu_14.*_64"
+  most_recent = true
+  owners      = "system"
+}
+
+resource "alicloud_vpc" "default" {
+  name        = "${var.name}"
+  cidr_block  = "172.16.0.0/16"
+  enable_ipv6 = true
+}
+
+resource "alicloud_vswitch" "default" {
+  vpc_id               = "${alicloud_vpc.default.id}"
+  cidr_block           = "172.16.0.0/24"
+  availability_zone    = "${data.alicloud_zones.default.zones.0.id}"
+  name                 = "${var.name}"
+  enable_ipv6          = true
+  ipv6_cidr_block_mask = 1
+}
+
+resource "alicloud_security_group" "default" {
+  name   = "${var.name}"
+  vpc_id = "${alicloud_vpc.default.id}"
+}
+`
+
 const RdsCommonTestCase = `
 data "alicloud_zones" "default" {
   available_resource_creation = "${var.creation}"
@@ -5,7 +5,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/denverdino/aliyungo/common"
 	"github.com/denverdino/aliyungo/cs"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -109,7 +111,68 @@ func (s *CsService) WaitForContainerApplication(clusterName, appName string, sta
 		if timeout <= 0 {
 			return GetTimeErrorFromString(fmt.Sprintf("Waitting for container application %s is timeout and current status is %s.", string(status), app.CurrentState))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
+
+type csClusterTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// DescribeClusterTags returns the tags of the cluster by DescribeClusterDetail, which is not supported by the CS SDK yet.
+func (s *CsService) DescribeClusterTags(clusterId string) (map[string]string, error) {
+	var detail struct {
+		Tags []csClusterTag `json:"tags"`
+	}
+	invoker := NewInvoker()
+	if err := invoker.Run(func() error {
+		_, err := s.client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
+			return nil, csClient.Invoke(common.Region(s.client.RegionId), "GET", "/clusters/"+clusterId, nil, nil, &detail)
+		})
+		return err
+	}); err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, clusterId, "DescribeClusterDetail", DenverdinoAliyungo)
+	}
+	tags := make(map[string]string)
+	for _, t := range detail.Tags {
+		tags[t.Key] = t.Value
+	}
+	return tags, nil
+}
+
+// SetClusterTags updates the tags of the cluster when "tags" or the default tags of the provider have been changed.
+// ModifyClusterTags replaces all of the tags of the cluster, so the tags ignored by the provider are kept as they are.
+func (s *CsService) SetClusterTags(d *schema.ResourceData) error {
+	if _, _, changed := tagsChange(s.client, d); !changed {
+		return nil
+	}
+
+	current, err := s.DescribeClusterTags(d.Id())
+	if err != nil {
+		return err
+	}
+	configured := mergeDefaultTags(s.client, d.Get("tags").(map[string]interface{}))
+	tags := make([]csClusterTag, 0)
+	for key, value := range current {
+		if _, ok := configured[key]; !ok && providerTagIgnored(s.client, key) {
+			tags = append(tags, csClusterTag{key, value})
+		}
+	}
+	for key, value := range configured {
+		tags = append(tags, csClusterTag{key, value.(string)})
+	}
+
+	invoker := NewInvoker()
+	if err := invoker.Run(func() error {
+		_, err := s.client.WithCsClient(func(csClient *cs.Client) (interface{}, error) {
+			return nil, csClient.Invoke(common.Region(s.client.RegionId), "POST", fmt.Sprintf("/clusters/%s/tags", d.Id()), nil, tags, nil)
+		})
+		return err
+	}); err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), "ModifyClusterTags", DenverdinoAliyungo)
+	}
+	d.SetPartial("tags")
+	return nil
+}
@@ -100,7 +100,7 @@ func (s *DrdsService) WaitForDrdsInstance(instanceId string, status string, time
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 	}
 	return nil
 }
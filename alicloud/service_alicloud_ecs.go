@@ -1,7 +1,9 @@
 package alicloud
 
 import (
+	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 
 	"time"
@@ -9,9 +11,9 @@ import (
 	"strconv"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/ecs"
 	"github.com/hashicorp/terraform/helper/resource"
-	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -209,11 +211,15 @@ func (s *EcsService) DescribeSecurityGroupRule(groupId, direction, ipProtocol, p
 		if strings.ToLower(string(ru.IpProtocol)) == ipProtocol && ru.PortRange == portRange {
 			cidr := ru.SourceCidrIp
 			if direction == string(DirectionIngress) && cidr == "" {
-				cidr = ru.SourceGroupId
+				if cidr = ru.Ipv6SourceCidrIp; cidr == "" {
+					cidr = ru.SourceGroupId
+				}
 			}
 			if direction == string(DirectionEgress) {
 				if cidr = ru.DestCidrIp; cidr == "" {
-					cidr = ru.DestGroupId
+					if cidr = ru.Ipv6DestCidrIp; cidr == "" {
+						cidr = ru.DestGroupId
+					}
 				}
 			}
 
@@ -227,7 +233,217 @@ func (s *EcsService) DescribeSecurityGroupRule(groupId, direction, ipProtocol, p
 
 }
 
-func (s *EcsService) DescribeAvailableResources(d *schema.ResourceData, meta interface{}, destination DestinationResource) (zoneId string, validZones []ecs.AvailableZone, err error) {
+// securityGroupPermission is a permission of the security group. Besides the fields of ecs.Permission, it holds the
+// prefix lists which are not supported by the SDK yet.
+type securityGroupPermission struct {
+	ecs.Permission
+	SourcePrefixListId string `json:"SourcePrefixListId" xml:"SourcePrefixListId"`
+	DestPrefixListId   string `json:"DestPrefixListId" xml:"DestPrefixListId"`
+}
+
+type describeSecurityGroupPermissionsResponse struct {
+	Permissions struct {
+		Permission []securityGroupPermission `json:"Permission" xml:"Permission"`
+	} `json:"Permissions" xml:"Permissions"`
+}
+
+// The maximum number of the permissions authorized or revoked in one request.
+const securityGroupPermissionsBatchSize = 100
+
+func (s *EcsService) buildEcsCommonRequest(apiName string) (*requests.CommonRequest, error) {
+	// Get product code from the built request
+	ruleReq := ecs.CreateModifySecurityGroupRuleRequest()
+	request, err := s.client.NewCommonRequest(ruleReq.GetProduct(), ruleReq.GetLocationServiceCode(), strings.ToUpper(string(Https)), connectivity.ApiVersion20140526)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+	request.ApiName = apiName
+	return request, nil
+}
+
+// DescribeSecurityGroupPermissions returns all of the permissions of the security group in the direction. It is sent as
+// a common request to read the prefix lists of the permissions.
+func (s *EcsService) DescribeSecurityGroupPermissions(groupId string, direction Direction) ([]securityGroupPermission, error) {
+	request, err := s.buildEcsCommonRequest("DescribeSecurityGroupAttribute")
+	if err != nil {
+		return nil, err
+	}
+	request.QueryParams["SecurityGroupId"] = groupId
+	request.QueryParams["Direction"] = string(direction)
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		if IsExceptedError(err, InvalidSecurityGroupIdNotFound) {
+			return nil, GetNotFoundErrorFromString(GetNotFoundMessage("Security Group", groupId))
+		}
+		return nil, WrapErrorf(err, DefaultErrorMsg, groupId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	var response describeSecurityGroupPermissionsResponse
+	if err := json.Unmarshal(raw.(*responses.CommonResponse).GetHttpContentBytes(), &response); err != nil {
+		return nil, WrapError(err)
+	}
+
+	var permissions []securityGroupPermission
+	for _, permission := range response.Permissions.Permission {
+		if Direction(permission.Direction) == direction {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions, nil
+}
+
+// ModifySecurityGroupPermissions authorizes or revokes the permissions of the security group in batches, and each of
+// the permissions is a map of the request parameters, such as "IpProtocol" and "SourceCidrIp".
+func (s *EcsService) ModifySecurityGroupPermissions(groupId, apiName string, permissions []map[string]string) error {
+	for start := 0; start < len(permissions); start += securityGroupPermissionsBatchSize {
+		end := start + securityGroupPermissionsBatchSize
+		if end > len(permissions) {
+			end = len(permissions)
+		}
+		request, err := s.buildEcsCommonRequest(apiName)
+		if err != nil {
+			return err
+		}
+		request.QueryParams["SecurityGroupId"] = groupId
+		for i, permission := range permissions[start:end] {
+			for key, value := range permission {
+				request.QueryParams[fmt.Sprintf("Permissions.%d.%s", i+1, key)] = value
+			}
+		}
+		_, err = s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.ProcessCommonRequest(request)
+		})
+		if err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, groupId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+	}
+	return nil
+}
+
+// ModifySecurityGroupPermissionDescription changes the description of the permission identified by the other parameters.
+func (s *EcsService) ModifySecurityGroupPermissionDescription(groupId string, direction Direction, permission map[string]string) error {
+	apiName := "ModifySecurityGroupRule"
+	if direction == DirectionEgress {
+		apiName = "ModifySecurityGroupEgressRule"
+	}
+	request, err := s.buildEcsCommonRequest(apiName)
+	if err != nil {
+		return err
+	}
+	for key, value := range permission {
+		request.QueryParams[key] = value
+	}
+	request.QueryParams["SecurityGroupId"] = groupId
+	_, err = s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, groupId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	return nil
+}
+
+// instanceTypeSpec is the specification of an instance type. Besides the fields of ecs.InstanceType, it holds the CPU
+// architecture which is not supported by the SDK yet.
+type instanceTypeSpec struct {
+	ecs.InstanceType
+	CpuArchitecture string `json:"CpuArchitecture" xml:"CpuArchitecture"`
+}
+
+type describeInstanceTypeSpecsResponse struct {
+	InstanceTypes struct {
+		InstanceType []instanceTypeSpec `json:"InstanceType" xml:"InstanceType"`
+	} `json:"InstanceTypes" xml:"InstanceTypes"`
+}
+
+// DescribeInstanceTypeSpecs returns the specifications of the instance types in the family, or all of them when the
+// family is empty. It is sent as a common request to read the CPU architectures.
+func (s *EcsService) DescribeInstanceTypeSpecs(family string) ([]instanceTypeSpec, error) {
+	request, err := s.buildEcsCommonRequest("DescribeInstanceTypes")
+	if err != nil {
+		return nil, err
+	}
+	if family != "" {
+		request.QueryParams["InstanceTypeFamily"] = family
+	}
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, family, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	var response describeInstanceTypeSpecsResponse
+	if err := json.Unmarshal(raw.(*responses.CommonResponse).GetHttpContentBytes(), &response); err != nil {
+		return nil, WrapError(err)
+	}
+	return response.InstanceTypes.InstanceType, nil
+}
+
+// DescribeInstanceTypePrice returns the price of an instance of the type, which is hourly for the PostPaid instance
+// and monthly for the PrePaid one.
+func (s *EcsService) DescribeInstanceTypePrice(instanceType string, chargeType PayType) (price ecs.Price, err error) {
+	request := ecs.CreateDescribePriceRequest()
+	request.ResourceType = "instance"
+	request.InstanceType = instanceType
+	request.IoOptimized = string(IOOptimized)
+	request.InstanceNetworkType = strings.ToLower(string(Vpc))
+	request.Period = requests.NewInteger(1)
+	request.PriceUnit = "Hour"
+	if chargeType == PrePaid {
+		request.PriceUnit = string(Month)
+	}
+
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribePrice(request)
+	})
+	if err != nil {
+		return price, WrapErrorf(err, DefaultErrorMsg, instanceType, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	response, _ := raw.(*ecs.DescribePriceResponse)
+	if response == nil {
+		return price, WrapError(fmt.Errorf("Describing the price of %s got a nil response.", instanceType))
+	}
+	return response.PriceInfo.Price, nil
+}
+
+// DescribeSpotPriceHistory returns all of the spot prices matching the request page by page, along with their currency.
+func (s *EcsService) DescribeSpotPriceHistory(request *ecs.DescribeSpotPriceHistoryRequest) (prices []ecs.SpotPriceType, currency string, err error) {
+	offset := 0
+	for {
+		request.Offset = requests.NewInteger(offset)
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeSpotPriceHistory(request)
+		})
+		if err != nil {
+			return nil, "", WrapErrorf(err, DefaultErrorMsg, request.InstanceType, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		}
+		response, _ := raw.(*ecs.DescribeSpotPriceHistoryResponse)
+
+		if response == nil || len(response.SpotPrices.SpotPriceType) < 1 {
+			break
+		}
+		currency = response.Currency
+		prices = append(prices, response.SpotPrices.SpotPriceType...)
+
+		// The next offset is not moved forward when all of the prices have been returned.
+		if response.NextOffset <= offset {
+			break
+		}
+		offset = response.NextOffset
+	}
+	return prices, currency, nil
+}
+
+// resourceGetter is implemented by both schema.ResourceData and schema.ResourceDiff, so the available resources can be
+// checked during plan as well as apply.
+type resourceGetter interface {
+	GetOk(key string) (interface{}, bool)
+}
+
+func (s *EcsService) DescribeAvailableResources(d resourceGetter, meta interface{}, destination DestinationResource) (zoneId string, validZones []ecs.AvailableZone, err error) {
 	client := meta.(*connectivity.AliyunClient)
 	// Before creating resources, check input parameters validity according available zone.
 	// If availability zone is nil, it will return all of supported resources in the current.
@@ -462,6 +678,9 @@ func (s *EcsService) DescribeTags(resourceId string, resourceType TagResourceTyp
 func (s *EcsService) DescribeImageById(id string) (image ecs.Image, err error) {
 	req := ecs.CreateDescribeImagesRequest()
 	req.ImageId = id
+	// Only the available images are returned by default, but the custom images being created are needed as well.
+	req.Status = strings.Join([]string{string(ImageCreating), string(ImageWaiting), string(ImageAvailable),
+		string(ImageUnAvailable), string(ImageCreateFailed)}, ",")
 	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
 		return ecsClient.DescribeImages(req)
 	})
@@ -475,6 +694,161 @@ func (s *EcsService) DescribeImageById(id string) (image ecs.Image, err error) {
 	return resp.Images.Image[0], nil
 }
 
+func (s *EcsService) DescribeImageShareAccount(imageId, accountId string) (err error) {
+	req := ecs.CreateDescribeImageSharePermissionRequest()
+	req.ImageId = imageId
+	req.PageSize = requests.NewInteger(PageSizeLarge)
+	req.PageNumber = requests.NewInteger(1)
+	for {
+		raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DescribeImageSharePermission(req)
+		})
+		if err != nil {
+			return err
+		}
+		resp, _ := raw.(*ecs.DescribeImageSharePermissionResponse)
+		if resp == nil || len(resp.Accounts.Account) < 1 {
+			break
+		}
+		for _, account := range resp.Accounts.Account {
+			if account.AliyunId == accountId {
+				return nil
+			}
+		}
+		if len(resp.Accounts.Account) < PageSizeLarge {
+			break
+		}
+		if req.PageNumber, err = getNextpageNumber(req.PageNumber); err != nil {
+			return err
+		}
+	}
+	return GetNotFoundErrorFromString(GetNotFoundMessage("Image share account", imageId+COLON_SEPARATED+accountId))
+}
+
+// WaitForImage waits for the custom image to the given status, and it returns an error once the creation has failed.
+func (s *EcsService) WaitForImage(imageId string, status ImageStatus, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	for {
+		image, err := s.DescribeImageById(imageId)
+		if err != nil && !NotFoundError(err) {
+			return err
+		}
+		if image.Status == string(status) {
+			break
+		}
+		if image.Status == string(ImageCreateFailed) {
+			return fmt.Errorf("Creating image %s got the status %s.", imageId, image.Status)
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return GetTimeErrorFromString(GetTimeoutMessage("ECS Image", string(status)))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+func (s *EcsService) DescribeSnapshotById(id string) (snapshot ecs.Snapshot, err error) {
+	req := ecs.CreateDescribeSnapshotsRequest()
+	req.SnapshotIds = convertListToJsonString([]interface{}{id})
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeSnapshots(req)
+	})
+	if err != nil {
+		return
+	}
+	resp, _ := raw.(*ecs.DescribeSnapshotsResponse)
+	if resp == nil || len(resp.Snapshots.Snapshot) < 1 {
+		return snapshot, GetNotFoundErrorFromString(GetNotFoundMessage("Snapshot", id))
+	}
+	return resp.Snapshots.Snapshot[0], nil
+}
+
+func (s *EcsService) DescribeSnapshotPolicyById(id string) (policy ecs.AutoSnapshotPolicy, err error) {
+	req := ecs.CreateDescribeAutoSnapshotPolicyExRequest()
+	req.AutoSnapshotPolicyId = id
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeAutoSnapshotPolicyEx(req)
+	})
+	if err != nil {
+		return
+	}
+	resp, _ := raw.(*ecs.DescribeAutoSnapshotPolicyExResponse)
+	if resp == nil || len(resp.AutoSnapshotPolicies.AutoSnapshotPolicy) < 1 {
+		return policy, GetNotFoundErrorFromString(GetNotFoundMessage("Snapshot policy", id))
+	}
+	return resp.AutoSnapshotPolicies.AutoSnapshotPolicy[0], nil
+}
+
+func (s *EcsService) DescribeLaunchTemplateById(id string) (template ecs.LaunchTemplateSet, err error) {
+	req := ecs.CreateDescribeLaunchTemplatesRequest()
+	req.LaunchTemplateId = &[]string{id}
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeLaunchTemplates(req)
+	})
+	if err != nil {
+		return
+	}
+	resp, _ := raw.(*ecs.DescribeLaunchTemplatesResponse)
+	if resp == nil || len(resp.LaunchTemplateSets.LaunchTemplateSet) < 1 {
+		return template, GetNotFoundErrorFromString(GetNotFoundMessage("Launch template", id))
+	}
+	return resp.LaunchTemplateSets.LaunchTemplateSet[0], nil
+}
+
+// DescribeLaunchTemplateVersion returns the version of the launch template along with its launch data.
+func (s *EcsService) DescribeLaunchTemplateVersion(id string, version int) (templateVersion ecs.LaunchTemplateVersionSet, err error) {
+	req := ecs.CreateDescribeLaunchTemplateVersionsRequest()
+	req.LaunchTemplateId = id
+	req.LaunchTemplateVersion = &[]string{strconv.Itoa(version)}
+	req.DetailFlag = requests.NewBoolean(true)
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeLaunchTemplateVersions(req)
+	})
+	if err != nil {
+		return
+	}
+	resp, _ := raw.(*ecs.DescribeLaunchTemplateVersionsResponse)
+	if resp == nil || len(resp.LaunchTemplateVersionSets.LaunchTemplateVersionSet) < 1 {
+		return templateVersion, GetNotFoundErrorFromString(GetNotFoundMessage("Launch template version", fmt.Sprintf("%s:%d", id, version)))
+	}
+	return resp.LaunchTemplateVersionSets.LaunchTemplateVersionSet[0], nil
+}
+
+func (s *EcsService) DescribeDedicatedHostById(id string) (host ecs.DedicatedHost, err error) {
+	req := ecs.CreateDescribeDedicatedHostsRequest()
+	req.DedicatedHostIds = convertListToJsonString([]interface{}{id})
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeDedicatedHosts(req)
+	})
+	if err != nil {
+		return
+	}
+	resp, _ := raw.(*ecs.DescribeDedicatedHostsResponse)
+	if resp == nil || len(resp.DedicatedHosts.DedicatedHost) < 1 {
+		return host, GetNotFoundErrorFromString(GetNotFoundMessage("Dedicated host", id))
+	}
+	return resp.DedicatedHosts.DedicatedHost[0], nil
+}
+
+func (s *EcsService) DescribeDeploymentSetById(id string) (set ecs.DeploymentSet, err error) {
+	req := ecs.CreateDescribeDeploymentSetsRequest()
+	req.DeploymentSetIds = convertListToJsonString([]interface{}{id})
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeDeploymentSets(req)
+	})
+	if err != nil {
+		return
+	}
+	resp, _ := raw.(*ecs.DescribeDeploymentSetsResponse)
+	if resp == nil || len(resp.DeploymentSets.DeploymentSet) < 1 {
+		return set, GetNotFoundErrorFromString(GetNotFoundMessage("Deployment set", id))
+	}
+	return resp.DeploymentSets.DeploymentSet[0], nil
+}
+
 func (s *EcsService) DescribeNetworkInterfaceById(instanceId string, eniId string) (networkInterface ecs.NetworkInterfaceSet, err error) {
 	req := ecs.CreateDescribeNetworkInterfacesRequest()
 	if instanceId != "" {
@@ -509,14 +883,14 @@ func (s *EcsService) WaitForEcsInstance(instanceId string, status Status, timeou
 		}
 		if instance.Status == string(status) {
 			//Sleep one more time for timing issues
-			time.Sleep(DefaultIntervalMedium * time.Second)
+			time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 			break
 		}
 		timeout = timeout - DefaultIntervalShort
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("ECS Instance", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 	}
 	return nil
@@ -534,15 +908,62 @@ func (s *EcsService) WaitForEcsDisk(diskId string, status Status, timeout int) e
 		}
 		if instance.Status == string(status) {
 			//Sleep one more time for timing issues
-			time.Sleep(DefaultIntervalMedium * time.Second)
+			time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 			break
 		}
 		timeout = timeout - DefaultIntervalShort
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("ECS Disk", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+
+	}
+	return nil
+}
 
+// WaitForSnapshot waits for the snapshot to the given status, and it returns an error once the snapshot has failed.
+func (s *EcsService) WaitForSnapshot(snapshotId string, status SnapshotStatus, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	for {
+		snapshot, err := s.DescribeSnapshotById(snapshotId)
+		if err != nil && !NotFoundError(err) {
+			return err
+		}
+		if snapshot.Status == string(status) {
+			break
+		}
+		if snapshot.Status == string(SnapshotFailed) {
+			return fmt.Errorf("Creating snapshot %s got the status %s.", snapshotId, snapshot.Status)
+		}
+		log.Printf("[DEBUG] The progress of snapshot %s is %s.", snapshotId, snapshot.Progress)
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return GetTimeErrorFromString(GetTimeoutMessage("ECS Snapshot", string(status)))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+func (s *EcsService) WaitForDedicatedHost(hostId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	for {
+		host, err := s.DescribeDedicatedHostById(hostId)
+		if err != nil && !NotFoundError(err) {
+			return err
+		}
+		if host.Status == string(status) {
+			break
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return GetTimeErrorFromString(GetTimeoutMessage("ECS Dedicated Host", string(status)))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -565,7 +986,7 @@ func (s *EcsService) WaitForEcsNetworkInterface(eniId string, status Status, tim
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("ECS eni", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 
 	return nil
@@ -592,7 +1013,7 @@ func (s *EcsService) WaitForVpcAttributesChanged(instanceId, vswitchId, privateI
 		if time.Now().After(deadline) {
 			return fmt.Errorf("Wait for VPC attributes changed timeout")
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 		instance, err := s.DescribeInstanceById(instanceId)
 		if err != nil {
@@ -617,7 +1038,7 @@ func (s *EcsService) WaitForPrivateIpsCountChanged(eniId string, count int) erro
 		if time.Now().After(deadline) {
 			return fmt.Errorf("Wait for private IP addrsses count changed timeout")
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 		ips, err := s.QueryPrivateIps(eniId)
 		if err != nil {
@@ -635,7 +1056,7 @@ func (s *EcsService) WaitForPrivateIpsListChanged(eniId string, ipList []string)
 		if time.Now().After(deadline) {
 			return fmt.Errorf("Wait for private IP addrsses list changed timeout")
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 		ips, err := s.QueryPrivateIps(eniId)
 		if err != nil {
@@ -667,6 +1088,229 @@ func (s *EcsService) WaitForPrivateIpsListChanged(eniId string, ipList []string)
 	}
 }
 
+// DescribeInstanceNetworkInterfaces returns the network interfaces of the instance in the given type, Primary or Secondary.
+func (s *EcsService) DescribeInstanceNetworkInterfaces(instanceId string, eniType NetworkInterfaceType) (networkInterfaces []ecs.NetworkInterfaceSet, err error) {
+	req := ecs.CreateDescribeNetworkInterfacesRequest()
+	req.InstanceId = instanceId
+	req.Type = string(eniType)
+	req.PageSize = requests.NewInteger(PageSizeLarge)
+	raw, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+		return ecsClient.DescribeNetworkInterfaces(req)
+	})
+	if err != nil {
+		return
+	}
+	resp, _ := raw.(*ecs.DescribeNetworkInterfacesResponse)
+	if resp == nil {
+		return
+	}
+	return resp.NetworkInterfaceSets.NetworkInterfaceSet, nil
+}
+
+// AssignPrivateIps assigns the secondary private IPs to the network interface, and the given number of IPs are
+// allocated automatically when the IP list is empty.
+func (s *EcsService) AssignPrivateIps(eniId string, ips []string, count int) error {
+	args := ecs.CreateAssignPrivateIpAddressesRequest()
+	args.NetworkInterfaceId = eniId
+	if len(ips) > 0 {
+		args.PrivateIpAddress = &ips
+	} else {
+		args.SecondaryPrivateIpAddressCount = requests.NewInteger(count)
+	}
+	return resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		_, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.AssignPrivateIpAddresses(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+		return nil
+	})
+}
+
+func (s *EcsService) UnassignPrivateIps(eniId string, ips []string) error {
+	args := ecs.CreateUnassignPrivateIpAddressesRequest()
+	args.NetworkInterfaceId = eniId
+	args.PrivateIpAddress = &ips
+	return resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		_, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.UnassignPrivateIpAddresses(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+		return nil
+	})
+}
+
+func ipv6Addresses(eni ecs.NetworkInterfaceSet) []string {
+	ips := make([]string, 0, len(eni.Ipv6Sets.Ipv6Set))
+	for _, ip := range eni.Ipv6Sets.Ipv6Set {
+		ips = append(ips, ip.Ipv6Address)
+	}
+	return ips
+}
+
+func (s *EcsService) QueryIpv6Addresses(eniId string) ([]string, error) {
+	eni, err := s.DescribeNetworkInterfaceById("", eniId)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+	return ipv6Addresses(eni), nil
+}
+
+// AssignIpv6Addresses assigns the IPv6 addresses to the network interface, and the given number of addresses are
+// allocated automatically when the address list is empty. The vswitch of the network interface must have IPv6 enabled.
+func (s *EcsService) AssignIpv6Addresses(eniId string, ips []string, count int) error {
+	args := ecs.CreateAssignIpv6AddressesRequest()
+	args.NetworkInterfaceId = eniId
+	if len(ips) > 0 {
+		args.Ipv6Address = &ips
+	} else {
+		args.Ipv6AddressCount = requests.NewInteger(count)
+	}
+	return resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		_, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.AssignIpv6Addresses(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+		return nil
+	})
+}
+
+func (s *EcsService) UnassignIpv6Addresses(eniId string, ips []string) error {
+	args := ecs.CreateUnassignIpv6AddressesRequest()
+	args.NetworkInterfaceId = eniId
+	args.Ipv6Address = &ips
+	return resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		_, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.UnassignIpv6Addresses(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+		return nil
+	})
+}
+
+// WaitForIpv6AddressesChanged waits for the network interface to have the given IPv6 addresses, or the given number of
+// IPv6 addresses when the address list is nil.
+func (s *EcsService) WaitForIpv6AddressesChanged(eniId string, ips []string, count int) error {
+	if ips != nil {
+		count = len(ips)
+	}
+	timeout := DefaultTimeout
+	for {
+		current, err := s.QueryIpv6Addresses(eniId)
+		if err != nil {
+			return WrapError(err)
+		}
+		if len(current) == count {
+			exist := make(map[string]bool, len(current))
+			for _, ip := range current {
+				exist[ip] = true
+			}
+			matched := true
+			for _, ip := range ips {
+				if !exist[ip] {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				break
+			}
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("IPv6 Addresses", fmt.Sprint(count))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+// AttachNetworkInterface attaches the network interface to the instance and waits for it to be in use.
+func (s *EcsService) AttachNetworkInterface(instanceId, eniId string) error {
+	args := ecs.CreateAttachNetworkInterfaceRequest()
+	args.InstanceId = instanceId
+	args.NetworkInterfaceId = eniId
+	err := resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		_, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.AttachNetworkInterface(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.WaitForEcsNetworkInterface(eniId, InUse, DefaultTimeout)
+}
+
+// DetachNetworkInterface detaches the network interface from the instance and waits for it to be available.
+func (s *EcsService) DetachNetworkInterface(instanceId, eniId string) error {
+	args := ecs.CreateDetachNetworkInterfaceRequest()
+	args.InstanceId = instanceId
+	args.NetworkInterfaceId = eniId
+	err := resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		_, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DetachNetworkInterface(args)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return s.WaitForEcsNetworkInterface(eniId, Available, DefaultTimeout)
+}
+
+// DeleteNetworkInterface deletes the network interface, and it retries until the interface is released by the instance.
+func (s *EcsService) DeleteNetworkInterface(eniId string) error {
+	args := ecs.CreateDeleteNetworkInterfaceRequest()
+	args.NetworkInterfaceId = eniId
+	return resource.Retry(DefaultTimeout*time.Second, func() *resource.RetryError {
+		_, err := s.client.WithEcsClient(func(ecsClient *ecs.Client) (interface{}, error) {
+			return ecsClient.DeleteNetworkInterface(args)
+		})
+		if err != nil {
+			if NotFoundError(err) || IsExceptedError(err, InvalidEcsNetworkInterfaceIdNotFound) {
+				return nil
+			}
+			if IsExceptedErrors(err, NetworkInterfaceInvalidOperations) {
+				return resource.RetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+			}
+			return resource.NonRetryableError(WrapErrorf(err, DefaultErrorMsg, eniId, args.GetActionName(), AlibabaCloudSdkGoERROR))
+		}
+		return nil
+	})
+}
+
 func (s *EcsService) AttachKeyPair(keyname string, instanceIds []interface{}) error {
 	args := ecs.CreateAttachKeyPairRequest()
 	args.KeyPairName = keyname
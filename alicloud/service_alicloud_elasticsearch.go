@@ -46,7 +46,7 @@ func (s *ElasticsearchService) DescribeElasticsearchInstance(instanceId string)
 }
 
 func (s *ElasticsearchService) WaitForElasticsearchInstance(instanceId string, status []ElasticsearchStatus, timeout int) error {
-	time.Sleep(DefaultIntervalLong * time.Second)
+	time.Sleep(DefaultIntervalLong * waitIntervalUnit)
 	for _, elasticsearchStatus := range status {
 		for {
 			if resp, err := s.DescribeElasticsearchInstance(instanceId); err == nil {
@@ -60,7 +60,7 @@ func (s *ElasticsearchService) WaitForElasticsearchInstance(instanceId string, s
 			}
 
 			timeout = timeout - DefaultIntervalLong
-			time.Sleep(DefaultIntervalLong * time.Second)
+			time.Sleep(DefaultIntervalLong * waitIntervalUnit)
 		}
 	}
 
@@ -112,7 +112,7 @@ func updatePrivateWhitelist(d *schema.ResourceData, meta interface{}) error {
 		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 	}
 
-	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout))
+	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())))
 }
 
 func updatePublicWhitelist(d *schema.ResourceData, meta interface{}) error {
@@ -136,7 +136,7 @@ func updatePublicWhitelist(d *schema.ResourceData, meta interface{}) error {
 		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 	}
 
-	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout))
+	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())))
 }
 
 func updateDateNodeAmount(d *schema.ResourceData, meta interface{}) error {
@@ -163,7 +163,7 @@ func updateDateNodeAmount(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout))
+	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())))
 }
 
 func updateDataNodeSpec(d *schema.ResourceData, meta interface{}) error {
@@ -194,7 +194,7 @@ func updateDataNodeSpec(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout))
+	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())))
 }
 
 func updateMasterNode(d *schema.ResourceData, meta interface{}) error {
@@ -231,7 +231,7 @@ func updateMasterNode(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout))
+	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())))
 }
 
 func updateKibanaWhitelist(d *schema.ResourceData, meta interface{}) error {
@@ -255,7 +255,7 @@ func updateKibanaWhitelist(d *schema.ResourceData, meta interface{}) error {
 		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 	}
 
-	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout))
+	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())))
 }
 
 func updatePassword(d *schema.ResourceData, meta interface{}) error {
@@ -279,7 +279,7 @@ func updatePassword(d *schema.ResourceData, meta interface{}) error {
 		return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.GetActionName(), AlibabaCloudSdkGoERROR)
 	}
 
-	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, WaitInstanceActiveTimeout))
+	return WrapError(elasticsearchService.WaitForElasticsearchInstance(d.Id(), []ElasticsearchStatus{ElasticsearchStatusActive}, int(d.Timeout(schema.TimeoutUpdate).Seconds())))
 }
 
 func getChargeType(paymentType string) string {
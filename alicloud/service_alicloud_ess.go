@@ -372,7 +372,7 @@ func (s *EssService) WaitForScalingGroup(groupId string, status Status, timeout
 			return WrapError(Error(GetTimeoutMessage("Scaling Group", string(status))))
 		}
 
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 	}
 	return nil
@@ -61,7 +61,7 @@ func (s *HaVipService) WaitForHaVip(haVipId string, status Status, timeout int)
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("HaVip", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -100,7 +100,7 @@ func (s *HaVipService) WaitForHaVipAttachment(haVipId string, instanceId string,
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("HaVip Attachment", string("Unavailable")))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -72,7 +72,7 @@ func (s *KvstoreService) WaitForRKVInstance(instanceId string, status Status, ti
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 	}
 	return nil
 }
@@ -96,7 +96,7 @@ func (s *KvstoreService) WaitForRKVInstanceVpcAuthMode(instanceId string, status
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 	}
 	return nil
 }
@@ -1,7 +1,12 @@
 package alicloud
 
 import (
+	"bytes"
+	"encoding/xml"
+	"net/http"
+
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -19,3 +24,85 @@ func (s *OssService) QueryOssBucketById(id string) (info *oss.BucketInfo, err er
 	bucket, _ := raw.(oss.GetBucketInfoResult)
 	return &bucket.BucketInfo, nil
 }
+
+type ossBucketTagging struct {
+	XMLName xml.Name       `xml:"Tagging"`
+	Tags    []ossBucketTag `xml:"TagSet>Tag"`
+}
+
+type ossBucketTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// DescribeOssBucketTags returns the tags of the bucket. The OSS SDK does not support the bucket tagging yet, so the
+// requests are sent by the connection of the client.
+func (s *OssService) DescribeOssBucketTags(bucket string) (map[string]string, error) {
+	raw, err := s.client.WithOssClient(func(ossClient *oss.Client) (interface{}, error) {
+		resp, err := ossClient.Conn.Do("GET", bucket, "", map[string]interface{}{"tagging": nil}, nil, nil, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		var tagging ossBucketTagging
+		err = xml.NewDecoder(resp.Body).Decode(&tagging)
+		return tagging, err
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, bucket, "GetBucketTagging", AliyunOssGoSdk)
+	}
+	tags := make(map[string]string)
+	for _, t := range raw.(ossBucketTagging).Tags {
+		tags[t.Key] = t.Value
+	}
+	return tags, nil
+}
+
+// SetOssBucketTags updates the tags of the bucket when "tags" or the default tags of the provider have been changed.
+// PutBucketTagging replaces all of the tags of the bucket, so the tags ignored by the provider are kept as they are.
+func (s *OssService) SetOssBucketTags(d *schema.ResourceData) error {
+	if _, _, changed := tagsChange(s.client, d); !changed {
+		return nil
+	}
+
+	current, err := s.DescribeOssBucketTags(d.Id())
+	if err != nil {
+		return err
+	}
+	tags := mergeDefaultTags(s.client, d.Get("tags").(map[string]interface{}))
+	tagging := ossBucketTagging{}
+	for key, value := range current {
+		if _, ok := tags[key]; !ok && providerTagIgnored(s.client, key) {
+			tagging.Tags = append(tagging.Tags, ossBucketTag{key, value})
+		}
+	}
+	for key, value := range tags {
+		tagging.Tags = append(tagging.Tags, ossBucketTag{key, value.(string)})
+	}
+
+	_, err = s.client.WithOssClient(func(ossClient *oss.Client) (interface{}, error) {
+		params := map[string]interface{}{"tagging": nil}
+		if len(tagging.Tags) < 1 {
+			resp, err := ossClient.Conn.Do("DELETE", d.Id(), "", params, nil, nil, 0, nil)
+			if err == nil {
+				resp.Body.Close()
+			}
+			return nil, err
+		}
+		body, err := xml.Marshal(tagging)
+		if err != nil {
+			return nil, err
+		}
+		headers := map[string]string{oss.HTTPHeaderContentType: http.DetectContentType(body)}
+		resp, err := ossClient.Conn.Do("PUT", d.Id(), "", params, headers, bytes.NewReader(body), 0, nil)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, d.Id(), "PutBucketTagging", AliyunOssGoSdk)
+	}
+	d.SetPartial("tags")
+	return nil
+}
@@ -160,7 +160,7 @@ func (s *OtsService) WaitForOtsInstance(name string, status Status, timeout int)
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("OTS Instance", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -80,7 +80,7 @@ func (s *PvtzService) WaitZoneAttachment(zoneId string, vpcIdMap map[string]stri
 		if timeout <= 0 {
 			return WrapError(Error(GetTimeoutMessage("PrivateZone Attachment", "Ready")))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
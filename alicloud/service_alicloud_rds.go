@@ -527,7 +527,7 @@ func (s *RdsService) WaitForDBInstance(instanceId string, status Status, timeout
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 	}
 	return nil
 }
@@ -551,7 +551,7 @@ func (s *RdsService) WaitForDBConnection(instanceId string, netType IPType, time
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 
 	}
 	return nil
@@ -576,7 +576,7 @@ func (s *RdsService) WaitForDBReadWriteSplitting(instanceId string, timeout int)
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 	}
 	return nil
 }
@@ -601,7 +601,7 @@ func (s *RdsService) WaitForAccount(instanceId string, accountName string, statu
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 
 	}
 	return nil
@@ -637,7 +637,7 @@ func (s *RdsService) WaitForAccountPrivilege(instanceId, accountName, dbName, pr
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 
 	}
 	return nil
@@ -672,7 +672,7 @@ func (s *RdsService) WaitForAccountPrivilegeRevoked(instanceId, accountName, dbN
 		}
 
 		timeout = timeout - DefaultIntervalMedium
-		time.Sleep(DefaultIntervalMedium * time.Second)
+		time.Sleep(DefaultIntervalMedium * waitIntervalUnit)
 
 	}
 	return nil
@@ -2,9 +2,12 @@ package alicloud
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform/helper/mutexkv"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
@@ -15,6 +18,17 @@ type RouteTableService struct {
 	client *connectivity.AliyunClient
 }
 
+// routerMutexKV serializes the changes of the route entries in the same router. A router only allows one route entry to
+// be created or deleted at a time across all of its route tables.
+var routerMutexKV = mutexkv.NewMutexKV()
+
+// routeTableRoute is a route entry of a route table. It has one next hop, or several ones with weights when it is an
+// ECMP route entry.
+type routeTableRoute struct {
+	DestinationCidrBlock string
+	NextHops             []vpc.NextHop
+}
+
 func (s *RouteTableService) DescribeRouteTable(routeTableId string) (v vpc.RouterTableListType, err error) {
 	request := vpc.CreateDescribeRouteTableListRequest()
 	request.RouteTableId = routeTableId
@@ -66,7 +80,7 @@ func (s *RouteTableService) WaitForRouteTable(routeTableId string, timeout int)
 		timeout = DefaultTimeout
 	}
 	for {
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 		resp, err := s.DescribeRouteTable(routeTableId)
 
 		if err != nil {
@@ -100,7 +114,7 @@ func (s *RouteTableService) WaitForRouteTableAttachment(routeTableId string, vSw
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("Route Table Attachment", string("Unavailable")))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -113,3 +127,108 @@ func (s *RouteTableService) GetRouteTableIdAndVSwitchId(d *schema.ResourceData,
 	}
 	return parts[0], parts[1], nil
 }
+
+// DescribeRouteTableRoutes returns the custom route entries of the route table, and the system ones are left out.
+func (s *RouteTableService) DescribeRouteTableRoutes(routeTableId string) ([]routeTableRoute, error) {
+	vpcService := VpcService{s.client}
+	table, err := vpcService.QueryRouteTableById(routeTableId)
+	if err != nil {
+		return nil, err
+	}
+	var routes []routeTableRoute
+	for _, entry := range table.RouteEntrys.RouteEntry {
+		if entry.Type != RouteEntryCustom {
+			continue
+		}
+		route := routeTableRoute{DestinationCidrBlock: entry.DestinationCidrBlock}
+		if len(entry.NextHops.NextHop) > 1 {
+			route.NextHops = entry.NextHops.NextHop
+		} else {
+			route.NextHops = []vpc.NextHop{{NextHopType: entry.NextHopType, NextHopId: entry.InstanceId}}
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// CreateRouteTableRoute creates the route entry once all of the other entries of the route table are available, and
+// waits for it to be available.
+func (s *RouteTableService) CreateRouteTableRoute(routeTableId string, route routeTableRoute) error {
+	request := vpc.CreateCreateRouteEntryRequest()
+	request.RegionId = s.client.RegionId
+	request.RouteTableId = routeTableId
+	request.DestinationCidrBlock = route.DestinationCidrBlock
+	if len(route.NextHops) > 1 {
+		var nextHops []vpc.CreateRouteEntryNextHopList
+		for _, nextHop := range route.NextHops {
+			nextHops = append(nextHops, vpc.CreateRouteEntryNextHopList{
+				NextHopType: nextHop.NextHopType,
+				NextHopId:   nextHop.NextHopId,
+				Weight:      strconv.Itoa(nextHop.Weight),
+			})
+		}
+		request.NextHopList = &nextHops
+	} else {
+		request.NextHopType = route.NextHops[0].NextHopType
+		request.NextHopId = route.NextHops[0].NextHopId
+	}
+
+	return s.processRouteEntryRequest(routeTableId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		// The token is used for idempotency check, so it is updated for every attempt
+		request.ClientToken = buildClientToken("TF-CreateRouteEntry")
+		args := *request
+		return vpcClient.CreateRouteEntry(&args)
+	})
+}
+
+// DeleteRouteTableRoute deletes the route entry once all of the other entries of the route table are available, and
+// waits for it to be removed.
+func (s *RouteTableService) DeleteRouteTableRoute(routeTableId string, route routeTableRoute) error {
+	request := vpc.CreateDeleteRouteEntryRequest()
+	request.RegionId = s.client.RegionId
+	request.RouteTableId = routeTableId
+	request.DestinationCidrBlock = route.DestinationCidrBlock
+	if len(route.NextHops) > 1 {
+		var nextHops []vpc.DeleteRouteEntryNextHopList
+		for _, nextHop := range route.NextHops {
+			nextHops = append(nextHops, vpc.DeleteRouteEntryNextHopList{
+				NextHopType: nextHop.NextHopType,
+				NextHopId:   nextHop.NextHopId,
+			})
+		}
+		request.NextHopList = &nextHops
+	} else {
+		request.NextHopId = route.NextHops[0].NextHopId
+	}
+
+	return s.processRouteEntryRequest(routeTableId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		raw, err := vpcClient.DeleteRouteEntry(request)
+		if IsExceptedError(err, InvalidRouteEntryNotFound) {
+			return raw, nil
+		}
+		return raw, err
+	})
+}
+
+// processRouteEntryRequest sends the request which changes a route entry of the route table. It is retried while
+// another entry of the router is being changed, and it returns after all of the entries are available again.
+func (s *RouteTableService) processRouteEntryRequest(routeTableId, action string, invoke func(vpcClient *vpc.Client) (interface{}, error)) error {
+	vpcService := VpcService{s.client}
+	err := resource.Retry(10*time.Minute, func() *resource.RetryError {
+		if err := vpcService.WaitForAllRouteEntries(routeTableId, Available, DefaultTimeout); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		_, err := s.client.WithVpcClient(invoke)
+		if err != nil {
+			if IsExceptedErrors(err, RouteEntryInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return WrapErrorf(err, DefaultErrorMsg, routeTableId, action, AlibabaCloudSdkGoERROR)
+	}
+	return WrapError(vpcService.WaitForAllRouteEntries(routeTableId, Available, DefaultTimeout))
+}
@@ -161,7 +161,7 @@ func (s *SlbService) WaitForLoadBalancer(loadBalancerId string, status Status, t
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("LoadBalancer", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -185,7 +185,7 @@ func (s *SlbService) WaitForListener(loadBalancerId string, port int, protocol P
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("LoadBalancer Listener", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 	}
 	return nil
@@ -362,11 +362,7 @@ func (s *SlbService) readFileContent(file_name string) (string, error) {
 // tags field to be named "tags"
 func (s *SlbService) setSlbInstanceTags(d *schema.ResourceData) error {
 
-	if d.HasChange("tags") {
-		oraw, nraw := d.GetChange("tags")
-		o := oraw.(map[string]interface{})
-		n := nraw.(map[string]interface{})
-		create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
+	if create, remove, changed := tagsChange(s.client, d); changed {
 
 		// Set tags
 		if len(remove) > 0 {
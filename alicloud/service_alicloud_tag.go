@@ -0,0 +1,208 @@
+package alicloud
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/r-kvstore"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/rds"
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
+)
+
+// TagService tags the resources by TagResources, UntagResources and ListTagResources, which are the same APIs
+// across the products. The products supporting them are described in tagApis.
+type TagService struct {
+	client *connectivity.AliyunClient
+}
+
+// The maximum number of the tags in a TagResources or UntagResources request
+const tagResourcesMaxTags = 20
+
+// tagApi describes the product which supports the tag APIs.
+type tagApi struct {
+	product     string
+	serviceCode string
+	apiVersion  connectivity.ApiVersion
+	process     func(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error)
+}
+
+var tagApis = map[connectivity.ServiceCode]tagApi{
+	connectivity.VPCCode: {"Vpc", "vpc", connectivity.ApiVersion20160428, func(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error) {
+		return client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ProcessCommonRequest(request)
+		})
+	}},
+	connectivity.RDSCode: {"Rds", "rds", connectivity.ApiVersion20140815, func(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error) {
+		return client.WithRdsClient(func(rdsClient *rds.Client) (interface{}, error) {
+			return rdsClient.ProcessCommonRequest(request)
+		})
+	}},
+	connectivity.KVSTORECode: {"R-kvstore", "redisa", connectivity.ApiVersion20150101, func(client *connectivity.AliyunClient, request *requests.CommonRequest) (interface{}, error) {
+		return client.WithRkvClient(func(rkvClient *r_kvstore.Client) (interface{}, error) {
+			return rkvClient.ProcessCommonRequest(request)
+		})
+	}},
+}
+
+type tagResource struct {
+	ResourceId   string `json:"ResourceId"`
+	ResourceType string `json:"ResourceType"`
+	TagKey       string `json:"TagKey"`
+	TagValue     string `json:"TagValue"`
+}
+
+type listTagResourcesResponse struct {
+	NextToken    string `json:"NextToken"`
+	TagResources struct {
+		TagResource []tagResource `json:"TagResource"`
+	} `json:"TagResources"`
+}
+
+func (s *TagService) buildTagRequest(code connectivity.ServiceCode, action string, resourceType TagResourceType) (*requests.CommonRequest, tagApi, error) {
+	api, ok := tagApis[code]
+	if !ok {
+		return nil, api, fmt.Errorf("The product %s does not support %s.", code, action)
+	}
+	request, err := s.client.NewCommonRequest(api.product, api.serviceCode, "HTTPS", api.apiVersion)
+	if err != nil {
+		return nil, api, WrapError(err)
+	}
+	request.ApiName = action
+	request.QueryParams["RegionId"] = s.client.RegionId
+	request.QueryParams["ResourceType"] = string(resourceType)
+	return request, api, nil
+}
+
+// listTagResources returns the tags of the resources. The resources are filtered by the ids and the tags when they are not empty.
+func (s *TagService) listTagResources(code connectivity.ServiceCode, resourceType TagResourceType, resourceIds []string, tags map[string]interface{}) ([]tagResource, error) {
+	request, api, err := s.buildTagRequest(code, "ListTagResources", resourceType)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range resourceIds {
+		request.QueryParams[fmt.Sprintf("ResourceId.%d", i+1)] = id
+	}
+	i := 1
+	for key, value := range tags {
+		request.QueryParams[fmt.Sprintf("Tag.%d.Key", i)] = key
+		request.QueryParams[fmt.Sprintf("Tag.%d.Value", i)] = value.(string)
+		i++
+	}
+
+	var result []tagResource
+	for {
+		raw, err := api.process(s.client, request)
+		if err != nil {
+			return nil, WrapErrorf(err, DefaultErrorMsg, resourceIds, request.ApiName, AlibabaCloudSdkGoERROR)
+		}
+		var response listTagResourcesResponse
+		if err := json.Unmarshal(raw.(*responses.CommonResponse).GetHttpContentBytes(), &response); err != nil {
+			return nil, WrapError(err)
+		}
+		result = append(result, response.TagResources.TagResource...)
+		if response.NextToken == "" {
+			return result, nil
+		}
+		request.QueryParams["NextToken"] = response.NextToken
+	}
+}
+
+// DescribeTags returns the tags of the resource.
+func (s *TagService) DescribeTags(code connectivity.ServiceCode, resourceType TagResourceType, resourceId string) (map[string]string, error) {
+	resources, err := s.listTagResources(code, resourceType, []string{resourceId}, nil)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string)
+	for _, r := range resources {
+		if r.ResourceId == resourceId {
+			tags[r.TagKey] = r.TagValue
+		}
+	}
+	return tags, nil
+}
+
+// DescribeTaggedResourceIds returns the ids of the resources which have all of the tags.
+func (s *TagService) DescribeTaggedResourceIds(code connectivity.ServiceCode, resourceType TagResourceType, tags map[string]interface{}) (map[string]bool, error) {
+	resources, err := s.listTagResources(code, resourceType, nil, tags)
+	if err != nil {
+		return nil, err
+	}
+	matched := make(map[string]int)
+	for _, r := range resources {
+		if value, ok := tags[r.TagKey]; ok && value.(string) == r.TagValue {
+			matched[r.ResourceId]++
+		}
+	}
+	ids := make(map[string]bool)
+	for id, count := range matched {
+		if count == len(tags) {
+			ids[id] = true
+		}
+	}
+	return ids, nil
+}
+
+// SetResourceTags updates the tags of the resource when "tags" or the default tags of the provider have been changed.
+func (s *TagService) SetResourceTags(d *schema.ResourceData, code connectivity.ServiceCode, resourceType TagResourceType) error {
+	create, remove, changed := tagsChange(s.client, d)
+	if !changed {
+		return nil
+	}
+
+	// TagResources overwrites the values of the existing keys, so only the keys not being created are removed.
+	created := make(map[string]bool)
+	for _, t := range create {
+		created[t.Key] = true
+	}
+	var keys []string
+	for _, t := range remove {
+		if !created[t.Key] {
+			keys = append(keys, t.Key)
+		}
+	}
+
+	for start := 0; start < len(keys); start += tagResourcesMaxTags {
+		request, api, err := s.buildTagRequest(code, "UntagResources", resourceType)
+		if err != nil {
+			return err
+		}
+		request.QueryParams["ResourceId.1"] = d.Id()
+		end := start + tagResourcesMaxTags
+		if end > len(keys) {
+			end = len(keys)
+		}
+		for i, key := range keys[start:end] {
+			request.QueryParams[fmt.Sprintf("TagKey.%d", i+1)] = key
+		}
+		if _, err := api.process(s.client, request); err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.ApiName, AlibabaCloudSdkGoERROR)
+		}
+	}
+
+	for start := 0; start < len(create); start += tagResourcesMaxTags {
+		request, api, err := s.buildTagRequest(code, "TagResources", resourceType)
+		if err != nil {
+			return err
+		}
+		request.QueryParams["ResourceId.1"] = d.Id()
+		end := start + tagResourcesMaxTags
+		if end > len(create) {
+			end = len(create)
+		}
+		for i, t := range create[start:end] {
+			request.QueryParams[fmt.Sprintf("Tag.%d.Key", i+1)] = t.Key
+			request.QueryParams[fmt.Sprintf("Tag.%d.Value", i+1)] = t.Value
+		}
+		if _, err := api.process(s.client, request); err != nil {
+			return WrapErrorf(err, DefaultErrorMsg, d.Id(), request.ApiName, AlibabaCloudSdkGoERROR)
+		}
+	}
+
+	d.SetPartial("tags")
+	return nil
+}
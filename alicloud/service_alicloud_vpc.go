@@ -1,14 +1,18 @@
 package alicloud
 
 import (
+	"encoding/json"
 	"time"
 
 	"fmt"
 
 	"strings"
+	"sync"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
+	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/responses"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/vpc"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/terraform-providers/terraform-provider-alicloud/alicloud/connectivity"
 )
 
@@ -111,6 +115,20 @@ func (s *VpcService) DescribeVpc(vpcId string) (v vpc.DescribeVpcAttributeRespon
 	return
 }
 
+// parseIpv6CidrBlock returns the IPv6 CIDR block in the response of DescribeVpcAttribute or DescribeVSwitchAttributes,
+// which is not supported by the vendored SDK yet. It is empty when IPv6 is not enabled.
+func parseIpv6CidrBlock(response interface {
+	GetHttpContentBytes() []byte
+}) (string, error) {
+	var v struct {
+		Ipv6CidrBlock string
+	}
+	if err := json.Unmarshal(response.GetHttpContentBytes(), &v); err != nil {
+		return "", WrapError(err)
+	}
+	return v.Ipv6CidrBlock, nil
+}
+
 func (s *VpcService) DescribeVswitch(vswitchId string) (v vpc.DescribeVSwitchAttributesResponse, err error) {
 	request := vpc.CreateDescribeVSwitchAttributesRequest()
 	request.VSwitchId = vswitchId
@@ -136,21 +154,51 @@ func (s *VpcService) DescribeVswitch(vswitchId string) (v vpc.DescribeVSwitchAtt
 	return
 }
 
-func (s *VpcService) DescribeSnatEntry(snatTableId string, snatEntryId string) (snat vpc.SnatTableEntry, err error) {
+// snatEntry is a SNAT entry along with its name, which is not supported by the vendored SDK yet.
+type snatEntry struct {
+	vpc.SnatTableEntry
+	SnatEntryName string
+}
+
+// forwardEntry is a DNAT entry along with its name, which is not supported by the vendored SDK yet.
+type forwardEntry struct {
+	vpc.ForwardTableEntry
+	ForwardEntryName string
+}
+
+func (s *VpcService) DescribeSnatEntry(snatTableId string, snatEntryId string) (snat snatEntry, err error) {
+	entries, err := s.describeSnatTableEntries(snatTableId, snatEntryId)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.SnatEntryId == snatEntryId {
+			return entry, nil
+		}
+	}
+	return snat, GetNotFoundErrorFromString(GetNotFoundMessage("Snat Entry", snatEntryId))
+}
+
+// DescribeSnatTableEntries returns all of the SNAT entries of the SNAT table page by page.
+func (s *VpcService) DescribeSnatTableEntries(snatTableId string) ([]snatEntry, error) {
+	return s.describeSnatTableEntries(snatTableId, "")
+}
 
+func (s *VpcService) describeSnatTableEntries(snatTableId, snatEntryId string) (entries []snatEntry, err error) {
 	request := vpc.CreateDescribeSnatTableEntriesRequest()
 	request.RegionId = string(s.client.Region)
 	request.SnatTableId = snatTableId
+	request.SnatEntryId = snatEntryId
 	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
 
 	for {
+		var raw interface{}
 		invoker := NewInvoker()
-		var snatEntries *vpc.DescribeSnatTableEntriesResponse
 		err = invoker.Run(func() error {
-			raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			raw, err = s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
 				return vpcClient.DescribeSnatTableEntries(request)
 			})
-			snatEntries, _ = raw.(*vpc.DescribeSnatTableEntriesResponse)
 			return err
 		})
 
@@ -158,68 +206,97 @@ func (s *VpcService) DescribeSnatEntry(snatTableId string, snatEntryId string) (
 		//so judge the snatEntries length priority
 		if err != nil {
 			if IsExceptedErrors(err, []string{InvalidSnatTableIdNotFound, InvalidSnatEntryIdNotFound}) {
-				return snat, GetNotFoundErrorFromString(GetNotFoundMessage("Snat Entry", snatEntryId))
+				return nil, GetNotFoundErrorFromString(GetNotFoundMessage("Snat Entry", snatEntryId))
 			}
-			return snat, err
-		}
-
-		if snatEntries == nil || len(snatEntries.SnatTableEntries.SnatTableEntry) < 1 {
-			break
+			return nil, WrapErrorf(err, DefaultErrorMsg, snatTableId, request.GetActionName(), AlibabaCloudSdkGoERROR)
 		}
 
-		for _, snat := range snatEntries.SnatTableEntries.SnatTableEntry {
-			if snat.SnatEntryId == snatEntryId {
-				return snat, nil
+		var response struct {
+			SnatTableEntries struct {
+				SnatTableEntry []snatEntry
 			}
 		}
+		if err = json.Unmarshal(raw.(*vpc.DescribeSnatTableEntriesResponse).GetHttpContentBytes(), &response); err != nil {
+			return nil, WrapError(err)
+		}
+		entries = append(entries, response.SnatTableEntries.SnatTableEntry...)
 
-		if len(snatEntries.SnatTableEntries.SnatTableEntry) < PageSizeLarge {
+		if len(response.SnatTableEntries.SnatTableEntry) < PageSizeLarge {
 			break
 		}
 		if page, err := getNextpageNumber(request.PageNumber); err != nil {
-			return snat, err
+			return nil, WrapError(err)
 		} else {
 			request.PageNumber = page
 		}
 	}
 
-	return snat, GetNotFoundErrorFromString(GetNotFoundMessage("Snat Entry", snatEntryId))
+	return entries, nil
+}
+
+func (s *VpcService) DescribeForwardEntry(forwardTableId string, forwardEntryId string) (entry forwardEntry, err error) {
+	entries, err := s.describeForwardTableEntries(forwardTableId, forwardEntryId)
+	if err != nil {
+		return
+	}
+	for _, forward := range entries {
+		if forward.ForwardEntryId == forwardEntryId {
+			return forward, nil
+		}
+	}
+	return entry, GetNotFoundErrorFromString(GetNotFoundMessage("Forward Entry", forwardTableId))
 }
 
-func (s *VpcService) DescribeForwardEntry(forwardTableId string, forwardEntryId string) (entry vpc.ForwardTableEntry, err error) {
+// DescribeForwardTableEntries returns all of the DNAT entries of the forward table page by page.
+func (s *VpcService) DescribeForwardTableEntries(forwardTableId string) ([]forwardEntry, error) {
+	return s.describeForwardTableEntries(forwardTableId, "")
+}
 
-	args := vpc.CreateDescribeForwardTableEntriesRequest()
-	args.RegionId = string(s.client.Region)
-	args.ForwardTableId = forwardTableId
+func (s *VpcService) describeForwardTableEntries(forwardTableId, forwardEntryId string) (entries []forwardEntry, err error) {
+	request := vpc.CreateDescribeForwardTableEntriesRequest()
+	request.RegionId = string(s.client.Region)
+	request.ForwardTableId = forwardTableId
+	request.ForwardEntryId = forwardEntryId
+	request.PageSize = requests.NewInteger(PageSizeLarge)
+	request.PageNumber = requests.NewInteger(1)
 
-	invoker := NewInvoker()
-	err = invoker.Run(func() error {
-		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
-			return vpcClient.DescribeForwardTableEntries(args)
+	for {
+		var raw interface{}
+		invoker := NewInvoker()
+		err = invoker.Run(func() error {
+			raw, err = s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+				return vpcClient.DescribeForwardTableEntries(request)
+			})
+			return err
 		})
-		//this special deal cause the DescribeSnatEntry can't find the records would be throw "cant find the snatTable error"
-		//so judge the snatEntries length priority
 		if err != nil {
 			if IsExceptedErrors(err, []string{InvalidForwardEntryIdNotFound, InvalidForwardTableIdNotFound}) {
-				return GetNotFoundErrorFromString(GetNotFoundMessage("Forward Entry", forwardTableId))
+				return nil, GetNotFoundErrorFromString(GetNotFoundMessage("Forward Entry", forwardTableId))
 			}
-			return err
-		}
-		resp, _ := raw.(*vpc.DescribeForwardTableEntriesResponse)
-		if resp == nil || len(resp.ForwardTableEntries.ForwardTableEntry) <= 0 {
-			return GetNotFoundErrorFromString(GetNotFoundMessage("Forward Entry", forwardTableId))
+			return nil, WrapErrorf(err, DefaultErrorMsg, forwardTableId, request.GetActionName(), AlibabaCloudSdkGoERROR)
 		}
 
-		for _, forward := range resp.ForwardTableEntries.ForwardTableEntry {
-			if forward.ForwardEntryId == forwardEntryId {
-				entry = forward
-				return nil
+		var response struct {
+			ForwardTableEntries struct {
+				ForwardTableEntry []forwardEntry
 			}
 		}
+		if err = json.Unmarshal(raw.(*vpc.DescribeForwardTableEntriesResponse).GetHttpContentBytes(), &response); err != nil {
+			return nil, WrapError(err)
+		}
+		entries = append(entries, response.ForwardTableEntries.ForwardTableEntry...)
 
-		return GetNotFoundErrorFromString(GetNotFoundMessage("Forward Entry", forwardTableId))
-	})
-	return
+		if len(response.ForwardTableEntries.ForwardTableEntry) < PageSizeLarge {
+			break
+		}
+		if page, err := getNextpageNumber(request.PageNumber); err != nil {
+			return nil, WrapError(err)
+		} else {
+			request.PageNumber = page
+		}
+	}
+
+	return entries, nil
 }
 
 func (s *VpcService) QueryRouteTableById(routeTableId string) (rt vpc.RouteTable, err error) {
@@ -307,7 +384,7 @@ func (s *VpcService) WaitForVpc(vpcId string, status Status, timeout int) error
 		if timeout <= 0 {
 			return WrapError(Error(GetTimeoutMessage("VPC", string(status))))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -329,7 +406,7 @@ func (s *VpcService) WaitForVSwitch(vswitchId string, status Status, timeout int
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("VSwitch", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -361,7 +438,7 @@ func (s *VpcService) WaitForAllRouteEntries(routeTableId string, status Status,
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("All Route Entries", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -384,7 +461,7 @@ func (s *VpcService) WaitForRouterInterface(regionId, interfaceId string, status
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("Router Interface", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -407,7 +484,7 @@ func (s *VpcService) WaitForEip(allocationId string, status Status, timeout int)
 		if timeout <= 0 {
 			return WrapError(Error(GetTimeoutMessage("EIP", string(status))))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -454,7 +531,7 @@ func (s *VpcService) WaitForForwardEntry(tableId, id string, status Status, time
 		if timeout <= 0 {
 			return WrapError(Error(GetTimeoutMessage("Forward Entry", string(status))))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -477,7 +554,742 @@ func (s *VpcService) WaitForSnatEntry(tableId, id string, status Status, timeout
 		if timeout <= 0 {
 			return WrapError(Error(GetTimeoutMessage("Snat Entry", string(status))))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+// CreateForwardEntry creates the DNAT entry and returns its ID without waiting for it to be available.
+func (s *VpcService) CreateForwardEntry(entry forwardEntry) (string, error) {
+	request := vpc.CreateCreateForwardEntryRequest()
+	request.RegionId = string(s.client.Region)
+	request.ForwardTableId = entry.ForwardTableId
+	request.ExternalIp = entry.ExternalIp
+	request.ExternalPort = entry.ExternalPort
+	request.IpProtocol = entry.IpProtocol
+	request.InternalIp = entry.InternalIp
+	request.InternalPort = entry.InternalPort
+	if entry.ForwardEntryName != "" {
+		// The parameter is not supported by the vendored SDK yet
+		request.QueryParams["ForwardEntryName"] = entry.ForwardEntryName
+	}
+
+	raw, err := s.processNatEntryRequest(entry.ForwardTableId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.CreateForwardEntry(request)
+	})
+	if err != nil {
+		return "", err
+	}
+	response, _ := raw.(*vpc.CreateForwardEntryResponse)
+	return response.ForwardEntryId, nil
+}
+
+// ModifyForwardEntry updates the DNAT entry to the given one without waiting for it to be available.
+func (s *VpcService) ModifyForwardEntry(entry forwardEntry) error {
+	request := vpc.CreateModifyForwardEntryRequest()
+	request.RegionId = string(s.client.Region)
+	request.ForwardTableId = entry.ForwardTableId
+	request.ForwardEntryId = entry.ForwardEntryId
+	request.ExternalIp = entry.ExternalIp
+	request.ExternalPort = entry.ExternalPort
+	request.IpProtocol = entry.IpProtocol
+	request.InternalIp = entry.InternalIp
+	request.InternalPort = entry.InternalPort
+	// The parameter is not supported by the vendored SDK yet
+	request.QueryParams["ForwardEntryName"] = entry.ForwardEntryName
+
+	_, err := s.processNatEntryRequest(entry.ForwardEntryId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ModifyForwardEntry(request)
+	})
+	return err
+}
+
+// DeleteForwardEntry deletes the DNAT entry without waiting for it to be removed, and an entry not found is ignored.
+func (s *VpcService) DeleteForwardEntry(forwardTableId, forwardEntryId string) error {
+	request := vpc.CreateDeleteForwardEntryRequest()
+	request.RegionId = string(s.client.Region)
+	request.ForwardTableId = forwardTableId
+	request.ForwardEntryId = forwardEntryId
+
+	_, err := s.processNatEntryRequest(forwardEntryId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		raw, err := vpcClient.DeleteForwardEntry(request)
+		if IsExceptedErrors(err, []string{InvalidForwardEntryIdNotFound, InvalidForwardTableIdNotFound}) {
+			return raw, nil
+		}
+		return raw, err
+	})
+	return err
+}
+
+// CreateSnatEntry creates the SNAT entry and returns its ID without waiting for it to be available.
+func (s *VpcService) CreateSnatEntry(entry snatEntry) (string, error) {
+	request := vpc.CreateCreateSnatEntryRequest()
+	request.RegionId = string(s.client.Region)
+	request.SnatTableId = entry.SnatTableId
+	request.SourceVSwitchId = entry.SourceVSwitchId
+	request.SourceCIDR = entry.SourceCIDR
+	request.SnatIp = entry.SnatIp
+	if entry.SnatEntryName != "" {
+		// The parameter is not supported by the vendored SDK yet
+		request.QueryParams["SnatEntryName"] = entry.SnatEntryName
+	}
+
+	raw, err := s.processNatEntryRequest(entry.SnatTableId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.CreateSnatEntry(request)
+	})
+	if err != nil {
+		return "", err
+	}
+	response, _ := raw.(*vpc.CreateSnatEntryResponse)
+	return response.SnatEntryId, nil
+}
+
+// ModifySnatEntry updates the SNAT IP and the name of the SNAT entry without waiting for it to be available.
+func (s *VpcService) ModifySnatEntry(entry snatEntry) error {
+	request := vpc.CreateModifySnatEntryRequest()
+	request.RegionId = string(s.client.Region)
+	request.SnatTableId = entry.SnatTableId
+	request.SnatEntryId = entry.SnatEntryId
+	request.SnatIp = entry.SnatIp
+	// The parameter is not supported by the vendored SDK yet
+	request.QueryParams["SnatEntryName"] = entry.SnatEntryName
+
+	_, err := s.processNatEntryRequest(entry.SnatEntryId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ModifySnatEntry(request)
+	})
+	return err
+}
+
+// DeleteSnatEntry deletes the SNAT entry without waiting for it to be removed, and an entry not found is ignored.
+func (s *VpcService) DeleteSnatEntry(snatTableId, snatEntryId string) error {
+	request := vpc.CreateDeleteSnatEntryRequest()
+	request.RegionId = string(s.client.Region)
+	request.SnatTableId = snatTableId
+	request.SnatEntryId = snatEntryId
+
+	_, err := s.processNatEntryRequest(snatEntryId, request.GetActionName(), func(vpcClient *vpc.Client) (interface{}, error) {
+		raw, err := vpcClient.DeleteSnatEntry(request)
+		if IsExceptedErrors(err, []string{InvalidSnatTableIdNotFound, InvalidSnatEntryIdNotFound}) {
+			return raw, nil
+		}
+		return raw, err
+	})
+	return err
+}
+
+// processNatEntryRequest sends the request which changes a DNAT or SNAT entry. It is retried while the EIP is being
+// associated with the NAT gateway or the table is busy.
+func (s *VpcService) processNatEntryRequest(id, action string, invoke func(vpcClient *vpc.Client) (interface{}, error)) (raw interface{}, err error) {
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err = s.client.WithVpcClient(invoke)
+		if err != nil {
+			if IsExceptedErrors(err, NatEntryInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		err = WrapErrorf(err, DefaultErrorMsg, id, action, AlibabaCloudSdkGoERROR)
+	}
+	return
+}
+
+// WaitForAllForwardEntries waits for all of the DNAT entries of the forward table to be in the status. The entries
+// being deleted are not in any status, so it also waits for them to be removed.
+func (s *VpcService) WaitForAllForwardEntries(forwardTableId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	for {
+		entries, err := s.DescribeForwardTableEntries(forwardTableId)
+		if err != nil {
+			return WrapError(err)
+		}
+		success := true
+		for _, entry := range entries {
+			if entry.Status != string(status) {
+				success = false
+				break
+			}
+		}
+		if success {
+			return nil
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("All Forward Entries", string(status))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+}
+
+// WaitForAllSnatEntries waits for all of the SNAT entries of the SNAT table to be in the status. The entries being
+// deleted are not in any status, so it also waits for them to be removed.
+func (s *VpcService) WaitForAllSnatEntries(snatTableId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	for {
+		entries, err := s.DescribeSnatTableEntries(snatTableId)
+		if err != nil {
+			return WrapError(err)
+		}
+		success := true
+		for _, entry := range entries {
+			if entry.Status != string(status) {
+				success = false
+				break
+			}
+		}
+		if success {
+			return nil
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("All Snat Entries", string(status))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+}
+
+// natEntryBatchSize is the number of the DNAT or SNAT entries which are changed concurrently before waiting for the
+// whole table. Waiting once per batch instead of once per entry is what makes a large table fast to apply.
+const natEntryBatchSize = 20
+
+// processNatEntryBatches runs the changes of the entries of a DNAT or SNAT table concurrently in batches, and waits for
+// the table after every batch. The concurrent requests are still limited by max_concurrent_requests of the provider.
+func processNatEntryBatches(changes []func() error, wait func() error) error {
+	for start := 0; start < len(changes); start += natEntryBatchSize {
+		end := start + natEntryBatchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		errs := make([]error, end-start)
+		var wg sync.WaitGroup
+		for i, change := range changes[start:end] {
+			wg.Add(1)
+			go func(i int, change func() error) {
+				defer wg.Done()
+				errs[i] = change()
+			}(i, change)
+		}
+		wg.Wait()
+
+		var messages []string
+		for _, err := range errs {
+			if err != nil {
+				messages = append(messages, err.Error())
+			}
+		}
+		if len(messages) > 0 {
+			return WrapError(fmt.Errorf("%d of the NAT entries failed to be changed:\n%s", len(messages), strings.Join(messages, "\n")))
+		}
+		if err := wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// networkAclEntry is an ingress or egress entry of a network ACL. The ingress entries have the source CIDR block and the
+// egress ones have the destination CIDR block.
+type networkAclEntry struct {
+	NetworkAclEntryId   string
+	NetworkAclEntryName string
+	Description         string
+	Policy              string
+	Protocol            string
+	Port                string
+	SourceCidrIp        string
+	DestinationCidrIp   string
+	EntryType           string
+}
+
+// networkAclResource is a vswitch associated with a network ACL.
+type networkAclResource struct {
+	ResourceId   string
+	ResourceType string
+	Status       string
+}
+
+// networkAcl is a network ACL returned by DescribeNetworkAcls. The network ACL APIs are not supported by the vendored
+// SDK yet, so they are sent as common requests.
+type networkAcl struct {
+	NetworkAclId      string
+	NetworkAclName    string
+	Description       string
+	VpcId             string
+	Status            string
+	IngressAclEntries struct {
+		IngressAclEntry []networkAclEntry
+	}
+	EgressAclEntries struct {
+		EgressAclEntry []networkAclEntry
+	}
+	Resources struct {
+		Resource []networkAclResource
+	}
+}
+
+type describeNetworkAclsResponse struct {
+	NetworkAcls struct {
+		NetworkAcl []networkAcl
+	}
+}
+
+func (s *VpcService) buildVpcCommonRequest(apiName string) (*requests.CommonRequest, error) {
+	// Get product code from the built request
+	vpcReq := vpc.CreateDescribeVpcsRequest()
+	request, err := s.client.NewCommonRequest(vpcReq.GetProduct(), vpcReq.GetLocationServiceCode(), strings.ToUpper(string(Https)), connectivity.ApiVersion20160428)
+	if err != nil {
+		return nil, WrapError(err)
+	}
+	request.ApiName = apiName
+	return request, nil
+}
+
+func (s *VpcService) DescribeNetworkAcl(aclId string) (acl networkAcl, err error) {
+	request, err := s.buildVpcCommonRequest("DescribeNetworkAcls")
+	if err != nil {
+		return
+	}
+	request.QueryParams["NetworkAclId"] = aclId
+
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		err = WrapErrorf(err, DefaultErrorMsg, aclId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		return
+	}
+	var response describeNetworkAclsResponse
+	if err = json.Unmarshal(raw.(*responses.CommonResponse).GetHttpContentBytes(), &response); err != nil {
+		err = WrapError(err)
+		return
+	}
+	for _, v := range response.NetworkAcls.NetworkAcl {
+		if v.NetworkAclId == aclId {
+			return v, nil
+		}
+	}
+	err = GetNotFoundErrorFromString(GetNotFoundMessage("Network Acl", aclId))
+	return
+}
+
+func (s *VpcService) DescribeNetworkAclAttachment(aclId, vswitchId string) (r networkAclResource, err error) {
+	acl, err := s.DescribeNetworkAcl(aclId)
+	if err != nil {
+		return
+	}
+	for _, v := range acl.Resources.Resource {
+		if v.ResourceId == vswitchId {
+			return v, nil
+		}
+	}
+	err = GetNotFoundErrorFromString(GetNotFoundMessage("Network Acl Attachment", aclId+COLON_SEPARATED+vswitchId))
+	return
+}
+
+// ProcessNetworkAclRequest sends the request which changes the network ACL, and retries it while the ACL or its vswitches
+// are being changed by another request.
+func (s *VpcService) ProcessNetworkAclRequest(aclId string, request *requests.CommonRequest) (*responses.CommonResponse, error) {
+	var response *responses.CommonResponse
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ProcessCommonRequest(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, NetworkAclInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		response = raw.(*responses.CommonResponse)
+		return nil
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, aclId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	return response, nil
+}
+
+// UpdateNetworkAclEntries replaces all of the custom entries of the network ACL in the direction with the entries in
+// order, and the first one has the highest priority.
+func (s *VpcService) UpdateNetworkAclEntries(aclId string, direction Direction, entries []map[string]string) error {
+	prefix := "IngressAclEntries"
+	flag := "UpdateIngressAclEntries"
+	if direction == DirectionEgress {
+		prefix = "EgressAclEntries"
+		flag = "UpdateEgressAclEntries"
+	}
+	request, err := s.buildVpcCommonRequest("UpdateNetworkAclEntries")
+	if err != nil {
+		return err
+	}
+	request.QueryParams["NetworkAclId"] = aclId
+	request.QueryParams[flag] = "true"
+	for i, entry := range entries {
+		for key, value := range entry {
+			request.QueryParams[fmt.Sprintf("%s.%d.%s", prefix, i+1, key)] = value
+		}
+	}
+	if _, err := s.ProcessNetworkAclRequest(aclId, request); err != nil {
+		return err
+	}
+	return s.WaitForNetworkAcl(aclId, Available, DefaultTimeout)
+}
+
+func (s *VpcService) WaitForNetworkAcl(aclId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	for {
+		acl, err := s.DescribeNetworkAcl(aclId)
+		if err != nil {
+			return WrapError(err)
+		}
+		if acl.Status == string(status) {
+			break
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("Network Acl", string(status))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+// WaitForNetworkAclAttachment waits for the vswitch to reach the status in the network ACL. The status Deleted means
+// the vswitch has been unassociated from the ACL.
+func (s *VpcService) WaitForNetworkAclAttachment(aclId, vswitchId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	for {
+		attachment, err := s.DescribeNetworkAclAttachment(aclId, vswitchId)
+		if err != nil {
+			if !NotFoundError(err) {
+				return WrapError(err)
+			}
+			if status == Deleted {
+				break
+			}
+		} else if attachment.Status == string(status) {
+			break
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("Network Acl Attachment", string(status))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+// ipv6Gateway is an IPv6 gateway returned by DescribeIpv6Gateways. The IPv6 gateway APIs are not supported by the
+// vendored SDK yet, so they are sent as common requests.
+type ipv6Gateway struct {
+	Ipv6GatewayId string
+	Name          string
+	Description   string
+	VpcId         string
+	Spec          string
+	Status        string
+}
+
+// ipv6EgressRule is an egress-only rule of an IPv6 gateway, which denies the inbound traffic from the internet to the
+// IPv6 address while the outbound traffic is allowed.
+type ipv6EgressRule struct {
+	Ipv6EgressOnlyRuleId string
+	Name                 string
+	Description          string
+	InstanceId           string
+	InstanceType         string
+	Status               string
+}
+
+// ipv6Address is an IPv6 address allocated from the IPv6 CIDR block of a vswitch.
+type ipv6Address struct {
+	Ipv6AddressId        string
+	Ipv6Address          string
+	Ipv6GatewayId        string
+	VpcId                string
+	VSwitchId            string
+	AssociatedInstanceId string
+	Status               string
+}
+
+func (s *VpcService) DescribeIpv6Gateway(gatewayId string) (gateway ipv6Gateway, err error) {
+	request, err := s.buildVpcCommonRequest("DescribeIpv6Gateways")
+	if err != nil {
+		return
+	}
+	request.QueryParams["Ipv6GatewayId"] = gatewayId
+
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		err = WrapErrorf(err, DefaultErrorMsg, gatewayId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		return
+	}
+	var response struct {
+		Ipv6Gateways struct {
+			Ipv6Gateway []ipv6Gateway
+		}
+	}
+	if err = json.Unmarshal(raw.(*responses.CommonResponse).GetHttpContentBytes(), &response); err != nil {
+		err = WrapError(err)
+		return
+	}
+	for _, v := range response.Ipv6Gateways.Ipv6Gateway {
+		if v.Ipv6GatewayId == gatewayId {
+			return v, nil
+		}
+	}
+	err = GetNotFoundErrorFromString(GetNotFoundMessage("Ipv6 Gateway", gatewayId))
+	return
+}
+
+func (s *VpcService) DescribeIpv6EgressRule(gatewayId, ruleId string) (rule ipv6EgressRule, err error) {
+	request, err := s.buildVpcCommonRequest("DescribeIpv6EgressOnlyRules")
+	if err != nil {
+		return
+	}
+	request.QueryParams["Ipv6GatewayId"] = gatewayId
+	request.QueryParams["Ipv6EgressOnlyRuleId"] = ruleId
+
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		if IsExceptedError(err, InvalidIpv6GatewayIdNotFound) {
+			err = GetNotFoundErrorFromString(GetNotFoundMessage("Ipv6 Egress Rule", gatewayId+COLON_SEPARATED+ruleId))
+			return
+		}
+		err = WrapErrorf(err, DefaultErrorMsg, gatewayId+COLON_SEPARATED+ruleId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		return
+	}
+	var response struct {
+		Ipv6EgressOnlyRules struct {
+			Ipv6EgressOnlyRule []ipv6EgressRule
+		}
+	}
+	if err = json.Unmarshal(raw.(*responses.CommonResponse).GetHttpContentBytes(), &response); err != nil {
+		err = WrapError(err)
+		return
+	}
+	for _, v := range response.Ipv6EgressOnlyRules.Ipv6EgressOnlyRule {
+		if v.Ipv6EgressOnlyRuleId == ruleId {
+			return v, nil
+		}
+	}
+	err = GetNotFoundErrorFromString(GetNotFoundMessage("Ipv6 Egress Rule", gatewayId+COLON_SEPARATED+ruleId))
+	return
+}
+
+// DescribeIpv6Address returns the IPv6 address by the address itself or by its ID, and the other one is left empty.
+func (s *VpcService) DescribeIpv6Address(address, addressId string) (ip ipv6Address, err error) {
+	request, err := s.buildVpcCommonRequest("DescribeIpv6Addresses")
+	if err != nil {
+		return
+	}
+	id := addressId
+	if address != "" {
+		id = address
+		request.QueryParams["Ipv6Address"] = address
+	} else {
+		request.QueryParams["Ipv6AddressId"] = addressId
+	}
+
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.ProcessCommonRequest(request)
+	})
+	if err != nil {
+		err = WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		return
+	}
+	var response struct {
+		Ipv6Addresses struct {
+			Ipv6Address []ipv6Address
+		}
+	}
+	if err = json.Unmarshal(raw.(*responses.CommonResponse).GetHttpContentBytes(), &response); err != nil {
+		err = WrapError(err)
+		return
+	}
+	for _, v := range response.Ipv6Addresses.Ipv6Address {
+		if (address != "" && v.Ipv6Address == address) || (address == "" && v.Ipv6AddressId == addressId) {
+			return v, nil
+		}
+	}
+	err = GetNotFoundErrorFromString(GetNotFoundMessage("Ipv6 Address", id))
+	return
+}
+
+// ProcessIpv6GatewayRequest sends the request which changes the IPv6 gateway or its egress-only rules, and retries it
+// while the gateway is being changed by another request.
+func (s *VpcService) ProcessIpv6GatewayRequest(id string, request *requests.CommonRequest) (*responses.CommonResponse, error) {
+	var response *responses.CommonResponse
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+			return vpcClient.ProcessCommonRequest(request)
+		})
+		if err != nil {
+			if IsExceptedErrors(err, Ipv6GatewayInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		response = raw.(*responses.CommonResponse)
+		return nil
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, request.GetActionName(), AlibabaCloudSdkGoERROR)
+	}
+	return response, nil
+}
+
+func (s *VpcService) WaitForIpv6Gateway(gatewayId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	for {
+		gateway, err := s.DescribeIpv6Gateway(gatewayId)
+		if err != nil {
+			return WrapError(err)
+		}
+		if gateway.Status == string(status) {
+			break
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("Ipv6 Gateway", string(status))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+// WaitForIpv6EgressRule waits for the egress-only rule to reach the status. The status Deleted means the rule has been
+// removed from the IPv6 gateway.
+func (s *VpcService) WaitForIpv6EgressRule(gatewayId, ruleId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	for {
+		rule, err := s.DescribeIpv6EgressRule(gatewayId, ruleId)
+		if err != nil {
+			if !NotFoundError(err) {
+				return WrapError(err)
+			}
+			if status == Deleted {
+				break
+			}
+		} else if rule.Status == string(status) {
+			break
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("Ipv6 Egress Rule", string(status))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
+	}
+	return nil
+}
+
+func (s *VpcService) DescribeFlowLog(flowLogId string) (flowLog vpc.FlowLog, err error) {
+	request := vpc.CreateDescribeFlowLogsRequest()
+	request.RegionId = s.client.RegionId
+	request.FlowLogId = flowLogId
+
+	raw, err := s.client.WithVpcClient(func(vpcClient *vpc.Client) (interface{}, error) {
+		return vpcClient.DescribeFlowLogs(request)
+	})
+	if err != nil {
+		err = WrapErrorf(err, DefaultErrorMsg, flowLogId, request.GetActionName(), AlibabaCloudSdkGoERROR)
+		return
+	}
+	response, _ := raw.(*vpc.DescribeFlowLogsResponse)
+	// The description is misspelled as Descripthon by the vendored SDK, so it is parsed from the content.
+	var content struct {
+		FlowLogs struct {
+			FlowLog []struct {
+				FlowLogId   string
+				Description string
+			}
+		}
+	}
+	if err = json.Unmarshal(response.GetHttpContentBytes(), &content); err != nil {
+		err = WrapError(err)
+		return
+	}
+	for _, v := range response.FlowLogs.FlowLog {
+		if v.FlowLogId != flowLogId {
+			continue
+		}
+		for _, c := range content.FlowLogs.FlowLog {
+			if c.FlowLogId == flowLogId && v.Descripthon == "" {
+				v.Descripthon = c.Description
+			}
+		}
+		return v, nil
+	}
+	err = GetNotFoundErrorFromString(GetNotFoundMessage("Flow Log", flowLogId))
+	return
+}
+
+// ProcessFlowLogRequest sends the request which changes the flow log, and retries it while the flow log or the resource
+// it captures is being changed by another request.
+func (s *VpcService) ProcessFlowLogRequest(id string, invoke func(vpcClient *vpc.Client) (interface{}, error), action string) (interface{}, error) {
+	var raw interface{}
+	err := resource.Retry(5*time.Minute, func() *resource.RetryError {
+		var err error
+		raw, err = s.client.WithVpcClient(invoke)
+		if err != nil {
+			if IsExceptedErrors(err, FlowLogInvalidOperations) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, WrapErrorf(err, DefaultErrorMsg, id, action, AlibabaCloudSdkGoERROR)
+	}
+	return raw, nil
+}
+
+// WaitForFlowLog waits for the flow log to reach the status. The status Deleted means the flow log has been deleted.
+func (s *VpcService) WaitForFlowLog(flowLogId string, status Status, timeout int) error {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	for {
+		flowLog, err := s.DescribeFlowLog(flowLogId)
+		if err != nil {
+			if !NotFoundError(err) {
+				return WrapError(err)
+			}
+			if status == Deleted {
+				break
+			}
+		} else if flowLog.Status == string(status) {
+			break
+		}
+		timeout = timeout - DefaultIntervalShort
+		if timeout <= 0 {
+			return WrapError(Error(GetTimeoutMessage("Flow Log", string(status))))
+		}
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
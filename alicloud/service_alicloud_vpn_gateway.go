@@ -139,7 +139,7 @@ func (s *VpnGatewayService) WaitForVpn(vpnId string, status Status, timeout int)
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("VPN", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 	}
 	return nil
 }
@@ -154,7 +154,7 @@ func (s *VpnGatewayService) WaitForCustomerGateway(id string, status Status, tim
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("VPN", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 		_, err := s.DescribeCustomerGateway(id)
 		if err != nil {
@@ -176,7 +176,7 @@ func (s *VpnGatewayService) WaitForSslVpnClientCert(id string, status Status, ti
 		if timeout <= 0 {
 			return GetTimeErrorFromString(GetTimeoutMessage("SSL VPN client cert", string(status)))
 		}
-		time.Sleep(DefaultIntervalShort * time.Second)
+		time.Sleep(DefaultIntervalShort * waitIntervalUnit)
 
 		resp, err := s.DescribeSslVpnClientCert(id)
 		if err != nil {
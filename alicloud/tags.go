@@ -32,12 +32,7 @@ func tagsSchema() *schema.Schema {
 // tags field to be named "tags"
 func setTags(client *connectivity.AliyunClient, resourceType TagResourceType, d *schema.ResourceData) error {
 
-	if d.HasChange("tags") {
-		oraw, nraw := d.GetChange("tags")
-		o := oraw.(map[string]interface{})
-		n := nraw.(map[string]interface{})
-		create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
-
+	if create, remove, changed := tagsChange(client, d); changed {
 		// Set tags
 		if len(remove) > 0 {
 			log.Printf("[DEBUG] Removing tags: %#v from %s", remove, d.Id())
@@ -110,6 +105,91 @@ func diffTags(oldTags, newTags []Tag) ([]Tag, []Tag) {
 	return tagsFromMap(create), remove
 }
 
+// tagsChange returns the tags to create and remove when the tags of the resource or the default tags of the provider
+// have been changed. The default tags are merged into both the old and new tags, so they are applied to the new
+// resources and are kept when they are not set in the resource. The default tag with an empty old value is missing
+// from the resource, see providerTags, and it is only created.
+func tagsChange(client *connectivity.AliyunClient, d *schema.ResourceData) ([]Tag, []Tag, bool) {
+	isNew := d.IsNewResource() && len(client.DefaultTags) > 0
+	if !d.HasChange("tags") && !isNew {
+		return nil, nil, false
+	}
+
+	oraw, nraw := d.GetChange("tags")
+	o := oraw.(map[string]interface{})
+	if !isNew {
+		o = mergeDefaultTags(client, o)
+	}
+	n := mergeDefaultTags(client, nraw.(map[string]interface{}))
+	create, remove := diffTags(tagsFromMap(o), tagsFromMap(n))
+
+	var removed []Tag
+	for _, t := range remove {
+		if _, ok := n[t.Key]; ok && t.Value == "" {
+			continue
+		}
+		removed = append(removed, t)
+	}
+	return create, removed, true
+}
+
+// mergeDefaultTags returns the tags merged with the default tags of the provider, and the tags override the default
+// ones with the same keys.
+func mergeDefaultTags(client *connectivity.AliyunClient, tags map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for k, v := range client.DefaultTags {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// providerTags returns the tags of the resource to be set to "tags". The tags ignored by the provider are removed,
+// and so are the default tags which are not set in the resource, so the default tags do not show as a drift.
+// The default tag which is missing from the resource is kept with an empty value, so it is created by the next
+// applying. The default tag with a different value is kept as it is, and it is updated by the next applying.
+func providerTags(client *connectivity.AliyunClient, d *schema.ResourceData, tags map[string]string) map[string]string {
+	configured := d.Get("tags").(map[string]interface{})
+	result := make(map[string]string)
+	for k, v := range tags {
+		if providerTagIgnored(client, k) {
+			continue
+		}
+		if value, ok := client.DefaultTags[k]; ok && value == v {
+			if _, ok := configured[k]; !ok {
+				continue
+			}
+		}
+		result[k] = v
+	}
+	for k := range client.DefaultTags {
+		if _, ok := tags[k]; ok || providerTagIgnored(client, k) {
+			continue
+		}
+		if _, ok := configured[k]; !ok {
+			result[k] = ""
+		}
+	}
+	return result
+}
+
+// providerTagIgnored checks whether the tag is ignored by the "ignore_tags" of the provider.
+func providerTagIgnored(client *connectivity.AliyunClient, key string) bool {
+	for _, k := range client.IgnoreTagKeys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range client.IgnoreTagKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // tagsFromMap returns the tags for the given map of data.
 func tagsFromMap(m map[string]interface{}) []Tag {
 	result := make([]Tag, 0, len(m))
@@ -669,17 +669,38 @@ func validateRegion(v interface{}, k string) (ws []string, errors []error) {
 	return
 }
 
+// validateForwardPort checks the port of a DNAT entry, which is any, a port or a port range like 1000/1100.
 func validateForwardPort(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if value != "any" {
-		valueConv, err := strconv.Atoi(value)
-		if err != nil || valueConv < 1 || valueConv > 65535 {
-			errors = append(errors, fmt.Errorf("%q must be a valid port between 1 and 65535 or any ", k))
+		if _, _, err := parseForwardPortRange(value); err != nil {
+			errors = append(errors, fmt.Errorf("%q must be a valid port between 1 and 65535, a port range like 1000/1100 or any ", k))
 		}
 	}
 	return
 }
 
+// parseForwardPortRange returns the first and the last port of a DNAT port or port range.
+func parseForwardPortRange(value string) (from, to int, err error) {
+	parts := strings.Split(value, "/")
+	if len(parts) > 2 {
+		return 0, 0, fmt.Errorf("invalid port range %s", value)
+	}
+	if from, err = strconv.Atoi(parts[0]); err != nil {
+		return
+	}
+	to = from
+	if len(parts) == 2 {
+		if to, err = strconv.Atoi(parts[1]); err != nil {
+			return
+		}
+	}
+	if from < 1 || to > 65535 || from > to {
+		err = fmt.Errorf("invalid port range %s", value)
+	}
+	return
+}
+
 func validateOssBucketName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if len(value) < 3 || len(value) > 63 {
@@ -1467,3 +1488,48 @@ func validateDataSourceSlbsTagsNum(v interface{}, k string) (ws []string, errors
 	}
 	return
 }
+
+func validateSnapshotPolicyRetentionDays(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value != -1 && (value < 1 || value > 65536) {
+		errors = append(errors, fmt.Errorf("%q must be -1 (retained permanently) or in [1-65536], got %d.", k, value))
+		return
+	}
+	return
+}
+
+func validateSnapshotPolicyTimePoint(v interface{}, k string) (ws []string, errors []error) {
+	value, err := strconv.Atoi(v.(string))
+	if err != nil || value < 0 || value > 23 {
+		errors = append(errors, fmt.Errorf("%q must be an hour in [0-23], got %s.", k, v.(string)))
+		return
+	}
+	return
+}
+
+func validateSnapshotPolicyRepeatWeekday(v interface{}, k string) (ws []string, errors []error) {
+	value, err := strconv.Atoi(v.(string))
+	if err != nil || value < 1 || value > 7 {
+		errors = append(errors, fmt.Errorf("%q must be a weekday in [1-7], got %s.", k, v.(string)))
+		return
+	}
+	return
+}
+
+func validateLaunchTemplateVersion(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "Default" || value == "Latest" {
+		return
+	}
+	if version, err := strconv.Atoi(value); err != nil || version < 1 {
+		errors = append(errors, fmt.Errorf("%q must be 'Default', 'Latest' or a positive version number, got %s.", k, value))
+	}
+	return
+}
+
+func validateUTCTime(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := time.Parse(UTCTimeLayout, v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a UTC time in the format %s, got %s.", k, UTCTimeLayout, v.(string)))
+	}
+	return
+}
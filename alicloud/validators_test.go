@@ -464,3 +464,21 @@ func TestValidateIntegerInRange(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateForwardPort(t *testing.T) {
+	validPorts := []string{"any", "1", "8080", "65535", "1000/1100", "22/22"}
+	for _, v := range validPorts {
+		_, errors := validateForwardPort(v, "external_port")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid forward port: %q", v, errors)
+		}
+	}
+
+	invalidPorts := []string{"0", "65536", "http", "1100/1000", "1/2/3", "1000/", "1-100"}
+	for _, v := range invalidPorts {
+		_, errors := validateForwardPort(v, "external_port")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid forward port", v)
+		}
+	}
+}